@@ -36,10 +36,13 @@ Generate perfect sqlc.yaml configurations in minutes, not hours!`,
 		SilenceErrors: true,
 	}
 
+	commands.AddGlobalFlags(rootCmd)
+
 	// Add commands
 	rootCmd.AddCommand(newVersionCmd())
 	rootCmd.AddCommand(commands.NewInitCommand())
 	rootCmd.AddCommand(commands.NewValidateCommand())
+	rootCmd.AddCommand(commands.NewLintCommand())
 	rootCmd.AddCommand(commands.NewGenerateCommand())
 	rootCmd.AddCommand(commands.NewDoctorCommand())
 	rootCmd.AddCommand(commands.NewMigrateCommand())
@@ -0,0 +1,256 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/schema"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+)
+
+// CatalogQuery is a named query against the system catalog of a database.
+// The name identifies the query's rows in recorded fixtures.
+type CatalogQuery struct {
+	Name string
+	SQL  string
+}
+
+// CatalogRow is a row of a catalog query keyed by column name. Values are
+// strings, integers, floats, booleans or nil for SQL NULL.
+type CatalogRow map[string]any
+
+// CatalogQuerier runs catalog queries. The PostgreSQL and MySQL schema readers only
+// talk to the database through it, so they can be tested with recorded fixtures.
+type CatalogQuerier interface {
+	QueryCatalog(ctx context.Context, query CatalogQuery) ([]CatalogRow, error)
+}
+
+// String returns a column as text, or "" for NULL.
+func (r CatalogRow) String(column string) string {
+	switch value := r[column].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// Null reports whether a column is NULL or missing.
+func (r CatalogRow) Null(column string) bool {
+	return r[column] == nil
+}
+
+// Bool returns a boolean column. Drivers that report booleans as text or
+// numbers ("t", "YES", 1) are accepted as well.
+func (r CatalogRow) Bool(column string) bool {
+	switch value := r[column].(type) {
+	case bool:
+		return value
+	case int64:
+		return value != 0
+	case float64:
+		return value != 0
+	default:
+		switch strings.ToLower(r.String(column)) {
+		case "t", "true", "y", "yes", "1":
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// Int returns an integer column, or 0 for NULL.
+func (r CatalogRow) Int(column string) int {
+	switch value := r[column].(type) {
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	default:
+		n, _ := strconv.Atoi(r.String(column))
+
+		return n
+	}
+}
+
+// sqlCatalogQuerier runs catalog queries on a database connection.
+type sqlCatalogQuerier struct {
+	db *sql.DB
+}
+
+// NewSQLCatalogQuerier returns a CatalogQuerier that runs queries on db.
+func NewSQLCatalogQuerier(db *sql.DB) CatalogQuerier {
+	return &sqlCatalogQuerier{db: db}
+}
+
+// QueryCatalog runs the query and returns its rows with text columns as strings.
+func (q *sqlCatalogQuerier) QueryCatalog(ctx context.Context, query CatalogQuery) ([]CatalogRow, error) {
+	rows, err := q.db.QueryContext(ctx, query.SQL)
+	if err != nil {
+		return nil, fmt.Errorf("catalog query %s failed: %w", query.Name, err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("catalog query %s failed: %w", query.Name, err)
+	}
+
+	var result []CatalogRow
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		err := rows.Scan(pointers...)
+		if err != nil {
+			return nil, fmt.Errorf("catalog query %s failed: %w", query.Name, err)
+		}
+
+		row := make(CatalogRow, len(columns))
+
+		for i, column := range columns {
+			if text, ok := values[i].([]byte); ok {
+				values[i] = string(text)
+			}
+
+			row[strings.ToLower(column)] = values[i]
+		}
+
+		result = append(result, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("catalog query %s failed: %w", query.Name, err)
+	}
+
+	return result, nil
+}
+
+// catalogDrivers maps the engines read through their catalog to the database/sql
+// driver name and the build tag of the migration driver that registers it.
+var catalogDrivers = map[string]string{
+	config.EnginePostgreSQL: "postgres",
+	config.EngineMySQL:      "mysql",
+}
+
+// openCatalog connects to a PostgreSQL or MySQL database for reading its catalog.
+func openCatalog(ctx context.Context, engine, uri string) (*sql.DB, error) {
+	driver := catalogDrivers[engine]
+
+	if !slices.Contains(sql.Drivers(), driver) {
+		return nil, apperrors.Newf(apperrors.ErrorCodeDatabaseNotSupported,
+			"%s support is not compiled into this binary; rebuild with -tags %s", engine, migrationDriverTags[driver])
+	}
+
+	// The MySQL driver takes a DSN without the mysql:// scheme golang-migrate uses.
+	dsn := uri
+	if engine == config.EngineMySQL {
+		dsn = strings.TrimPrefix(uri, "mysql://")
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, apperrors.Wrapf(err, apperrors.ErrDatabaseConnection, "failed to open %s database", engine)
+	}
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		_ = db.Close()
+
+		return nil, apperrors.Wrapf(err, apperrors.ErrDatabaseConnection, "failed to connect to %s database %s",
+			engine, maskSensitiveInfo(uri))
+	}
+
+	return db, nil
+}
+
+// queryCatalog runs a catalog query and wraps its error as a connection error.
+func queryCatalog(ctx context.Context, catalog CatalogQuerier, query CatalogQuery) ([]CatalogRow, error) {
+	rows, err := catalog.QueryCatalog(ctx, query)
+	if err != nil {
+		return nil, apperrors.Wrapf(err, apperrors.ErrDatabaseConnection, "failed to read %s from the catalog", query.Name)
+	}
+
+	return rows, nil
+}
+
+// tableByName returns the table with the given name, or nil.
+func tableByName(tables []schema.Table, name string) *schema.Table {
+	for i := range tables {
+		if tables[i].Name == name {
+			return &tables[i]
+		}
+	}
+
+	return nil
+}
+
+// viewByName returns the view with the given name, or nil.
+func viewByName(views []schema.View, name string) *schema.View {
+	for i := range views {
+		if views[i].Name == name {
+			return &views[i]
+		}
+	}
+
+	return nil
+}
+
+// groupCatalogRows splits rows into runs of consecutive rows with the same key columns.
+func groupCatalogRows(rows []CatalogRow, keys ...string) [][]CatalogRow {
+	var groups [][]CatalogRow
+
+	sameKey := func(a, b CatalogRow) bool {
+		for _, key := range keys {
+			if a.String(key) != b.String(key) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	for _, row := range rows {
+		if n := len(groups); n > 0 && sameKey(groups[n-1][0], row) {
+			groups[n-1] = append(groups[n-1], row)
+		} else {
+			groups = append(groups, []CatalogRow{row})
+		}
+	}
+
+	return groups
+}
+
+// uniqueConstraint returns a unique constraint and marks a single unique column.
+func uniqueConstraint(table *schema.Table, name string, columns []string) schema.Constraint {
+	if len(columns) == 1 {
+		for i := range table.Columns {
+			if table.Columns[i].Name == columns[0] {
+				table.Columns[i].Unique = true
+			}
+		}
+	}
+
+	return schema.Constraint{
+		Name:    name,
+		Type:    schema.ConstraintTypeUnique,
+		Table:   table.Name,
+		Columns: columns,
+	}
+}
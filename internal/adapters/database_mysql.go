@@ -0,0 +1,318 @@
+package adapters
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/schema"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+)
+
+// MySQL catalog queries. All of them read the database selected by the connection.
+var (
+	mysqlTables = CatalogQuery{Name: "tables", SQL: `
+SELECT t.TABLE_NAME AS name, t.TABLE_TYPE AS kind, v.VIEW_DEFINITION AS definition
+FROM information_schema.TABLES t
+LEFT JOIN information_schema.VIEWS v ON v.TABLE_SCHEMA = t.TABLE_SCHEMA AND v.TABLE_NAME = t.TABLE_NAME
+WHERE t.TABLE_SCHEMA = DATABASE()
+ORDER BY t.CREATE_TIME, t.TABLE_NAME`}
+
+	mysqlColumns = CatalogQuery{Name: "columns", SQL: `
+SELECT TABLE_NAME AS table_name,
+       COLUMN_NAME AS name,
+       COLUMN_TYPE AS type,
+       DATA_TYPE AS data_type,
+       IS_NULLABLE AS nullable,
+       COLUMN_DEFAULT AS default_value,
+       EXTRA AS extra
+FROM information_schema.COLUMNS
+WHERE TABLE_SCHEMA = DATABASE()
+ORDER BY TABLE_NAME, ORDINAL_POSITION`}
+
+	mysqlIndexes = CatalogQuery{Name: "indexes", SQL: `
+SELECT TABLE_NAME AS table_name,
+       INDEX_NAME AS name,
+       NON_UNIQUE AS non_unique,
+       COLUMN_NAME AS column_name,
+       INDEX_TYPE AS index_type
+FROM information_schema.STATISTICS
+WHERE TABLE_SCHEMA = DATABASE()
+ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX`}
+
+	mysqlForeignKeys = CatalogQuery{Name: "foreign_keys", SQL: `
+SELECT k.CONSTRAINT_NAME AS name,
+       k.TABLE_NAME AS table_name,
+       k.COLUMN_NAME AS column_name,
+       k.REFERENCED_TABLE_NAME AS target_table,
+       k.REFERENCED_COLUMN_NAME AS target_column,
+       r.DELETE_RULE AS on_delete,
+       r.UPDATE_RULE AS on_update
+FROM information_schema.KEY_COLUMN_USAGE k
+JOIN information_schema.REFERENTIAL_CONSTRAINTS r
+  ON r.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA AND r.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+WHERE k.TABLE_SCHEMA = DATABASE() AND k.REFERENCED_TABLE_NAME IS NOT NULL
+ORDER BY k.TABLE_NAME, k.CONSTRAINT_NAME, k.ORDINAL_POSITION`}
+)
+
+// mysqlGeneratedFKName matches the names MySQL gives unnamed foreign keys.
+var mysqlGeneratedFKName = regexp.MustCompile(`_ibfk_\d+$`)
+
+// mysqlNumericTypes are the DATA_TYPE values whose defaults are reported unquoted.
+var mysqlNumericTypes = []string{
+	"tinyint", "smallint", "mediumint", "int", "integer", "bigint",
+	"decimal", "numeric", "float", "double", "real", "bit", "year",
+}
+
+// MySQLSchema reads the selected MySQL database from information_schema: tables,
+// views, columns with their full column types, primary keys, unique constraints,
+// foreign keys, and BTREE, HASH, FULLTEXT and SPATIAL indexes. Generated names of
+// unnamed keys are mapped to the names the schema parser gives them, and the indexes
+// MySQL adds to back foreign keys are left out. Check constraints and functional
+// key parts are not read, since information_schema lacks them before MySQL 8.0.16.
+func MySQLSchema(ctx context.Context, catalog CatalogQuerier, name string) (*schema.Schema, error) {
+	r := &mysqlSchemaReader{catalog: catalog}
+
+	// Foreign keys are read before indexes to recognise the indexes that back them.
+	steps := []func(context.Context) error{r.relations, r.columns, r.foreignKeys, r.indexes}
+	for _, step := range steps {
+		err := step(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return schema.Assemble(name, config.EngineMySQL, r.tables, r.views, r.constraints), nil
+}
+
+// mysqlSchemaReader collects the catalog rows of one MySQL database.
+type mysqlSchemaReader struct {
+	catalog     CatalogQuerier
+	tables      []schema.Table
+	views       []schema.View
+	constraints []schema.Constraint
+	// foreignKeyIndexes are the names MySQL may give the index backing each foreign key.
+	foreignKeyIndexes map[string][]string
+}
+
+func (r *mysqlSchemaReader) relations(ctx context.Context) error {
+	rows, err := queryCatalog(ctx, r.catalog, mysqlTables)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		name := row.String("name")
+
+		switch {
+		case row.String("kind") == "VIEW":
+			r.views = append(r.views, schema.View{
+				Name:       name,
+				Definition: row.String("definition"),
+				Columns:    []schema.Column{},
+			})
+		case name != migrationsTable:
+			r.tables = append(r.tables, schema.Table{Name: name, Columns: []schema.Column{}})
+		}
+	}
+
+	return nil
+}
+
+func (r *mysqlSchemaReader) columns(ctx context.Context) error {
+	rows, err := queryCatalog(ctx, r.catalog, mysqlColumns)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		extra := strings.ToLower(row.String("extra"))
+		column := schema.Column{
+			Name:          row.String("name"),
+			SQLType:       row.String("type"),
+			Nullable:      row.Bool("nullable"),
+			AutoIncrement: strings.Contains(extra, "auto_increment"),
+		}
+		column.Type = schema.ColumnTypeFromSQL(column.SQLType)
+
+		if value, ok := mysqlDefault(row, extra, column.Nullable); ok {
+			column.Default = &value
+		}
+
+		if table := tableByName(r.tables, row.String("table_name")); table != nil {
+			table.Columns = append(table.Columns, column)
+		} else if view := viewByName(r.views, row.String("table_name")); view != nil {
+			column.Nullable = true
+			view.Columns = append(view.Columns, column)
+		}
+	}
+
+	return nil
+}
+
+// mysqlDefault returns a column default as SQL. MySQL reports literal defaults
+// unquoted and marks expression defaults with DEFAULT_GENERATED; MariaDB quotes
+// literals and reports a missing default of a nullable column as NULL.
+func mysqlDefault(row CatalogRow, extra string, nullable bool) (string, bool) {
+	if row.Null("default_value") {
+		return "", false
+	}
+
+	value := row.String("default_value")
+
+	switch {
+	case value == "NULL" && nullable:
+		return "", false
+	case strings.Contains(extra, "default_generated"):
+		upper := strings.ToUpper(value)
+		if strings.HasPrefix(upper, "CURRENT_TIMESTAMP") || strings.HasPrefix(upper, "NOW(") {
+			return value, true
+		}
+
+		return "(" + value + ")", true
+	case slices.Contains(mysqlNumericTypes, strings.ToLower(row.String("data_type"))),
+		strings.HasPrefix(value, "'"):
+		return value, true
+	default:
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'", true
+	}
+}
+
+func (r *mysqlSchemaReader) foreignKeys(ctx context.Context) error {
+	rows, err := queryCatalog(ctx, r.catalog, mysqlForeignKeys)
+	if err != nil {
+		return err
+	}
+
+	r.foreignKeyIndexes = map[string][]string{}
+
+	for _, group := range groupCatalogRows(rows, "table_name", "name") {
+		table := tableByName(r.tables, group[0].String("table_name"))
+		if table == nil {
+			continue
+		}
+
+		columns := make([]string, len(group))
+		for i, row := range group {
+			columns[i] = row.String("column_name")
+		}
+
+		name := group[0].String("name")
+		r.foreignKeyIndexes[table.Name] = append(r.foreignKeyIndexes[table.Name], name, columns[0])
+
+		if mysqlGeneratedFKName.MatchString(name) {
+			name = table.Name + "_" + strings.Join(columns, "_") + "_fkey"
+		}
+
+		for _, row := range group {
+			table.ForeignKeys = append(table.ForeignKeys, schema.ForeignKey{
+				Name:           name,
+				SourceTable:    table.Name,
+				SourceColumn:   row.String("column_name"),
+				TargetTable:    row.String("target_table"),
+				TargetColumn:   row.String("target_column"),
+				OnDeleteAction: row.String("on_delete"),
+				OnUpdateAction: row.String("on_update"),
+			})
+		}
+	}
+
+	return nil
+}
+
+// indexes reads one row per index column. PRIMARY becomes the table's primary key
+// and unique indexes become unique constraints, as in the schema parser.
+func (r *mysqlSchemaReader) indexes(ctx context.Context) error {
+	rows, err := queryCatalog(ctx, r.catalog, mysqlIndexes)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groupCatalogRows(rows, "table_name", "name") {
+		table := tableByName(r.tables, group[0].String("table_name"))
+		if table == nil {
+			continue
+		}
+
+		var columns []string
+
+		for _, row := range group {
+			if !row.Null("column_name") {
+				columns = append(columns, row.String("column_name"))
+			}
+		}
+
+		name := group[0].String("name")
+		// MySQL names unnamed keys after their first column.
+		generated := len(columns) > 0 && name == columns[0]
+
+		switch {
+		case len(columns) == 0:
+			continue
+		case name == "PRIMARY":
+			table.PrimaryKey = &schema.Index{
+				Name:    table.Name + "_pkey",
+				Table:   table.Name,
+				Columns: columns,
+				Unique:  true,
+				Type:    schema.IndexTypeBTree,
+			}
+
+			for i := range table.Columns {
+				if slices.Contains(columns, table.Columns[i].Name) {
+					table.Columns[i].PrimaryKey = true
+				}
+			}
+		case !group[0].Bool("non_unique"):
+			if generated {
+				name = table.Name + "_" + strings.Join(columns, "_") + "_key"
+			}
+
+			r.constraints = append(r.constraints, uniqueConstraint(table, name, columns))
+		case r.backsForeignKey(table, name, columns):
+			continue
+		default:
+			if generated {
+				name = table.Name + "_" + strings.Join(columns, "_") + "_idx"
+			}
+
+			table.Indexes = append(table.Indexes, schema.Index{
+				Name:    name,
+				Table:   table.Name,
+				Columns: columns,
+				Type:    mysqlIndexType(group[0].String("index_type")),
+			})
+		}
+	}
+
+	return nil
+}
+
+// backsForeignKey reports whether an index is the one MySQL created for a foreign
+// key, named after the constraint or its first column and covering its columns.
+func (r *mysqlSchemaReader) backsForeignKey(table *schema.Table, name string, columns []string) bool {
+	if !slices.Contains(r.foreignKeyIndexes[table.Name], name) {
+		return false
+	}
+
+	for _, fk := range table.ForeignKeys {
+		if fk.SourceColumn == columns[0] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mysqlIndexType maps an INDEX_TYPE to the schema index type.
+func mysqlIndexType(indexType string) schema.IndexType {
+	switch strings.ToUpper(indexType) {
+	case "FULLTEXT", "SPATIAL":
+		return schema.IndexTypeFullText
+	case "HASH":
+		return schema.IndexTypeHash
+	default:
+		return schema.IndexTypeBTree
+	}
+}
@@ -0,0 +1,341 @@
+package adapters
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/schema"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+)
+
+// PostgreSQL catalog queries. All of them read the current schema (the first
+// entry of search_path) and return objects in creation order.
+var (
+	postgresRelations = CatalogQuery{Name: "relations", SQL: `
+SELECT c.relname AS name,
+       c.relkind AS kind,
+       CASE WHEN c.relkind IN ('v', 'm') THEN pg_get_viewdef(c.oid, true) END AS definition
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE n.nspname = current_schema()
+  AND c.relkind IN ('r', 'p', 'v', 'm')
+  AND NOT c.relispartition
+ORDER BY c.oid`}
+
+	postgresColumns = CatalogQuery{Name: "columns", SQL: `
+SELECT c.relname AS table_name,
+       a.attname AS name,
+       format_type(a.atttypid, a.atttypmod) AS type,
+       a.attnotnull AS not_null,
+       pg_get_expr(d.adbin, d.adrelid) AS default_value,
+       a.attidentity <> '' AS identity
+FROM pg_attribute a
+JOIN pg_class c ON c.oid = a.attrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+WHERE n.nspname = current_schema()
+  AND c.relkind IN ('r', 'p', 'v', 'm')
+  AND NOT c.relispartition
+  AND a.attnum > 0
+  AND NOT a.attisdropped
+ORDER BY c.oid, a.attnum`}
+
+	postgresEnums = CatalogQuery{Name: "enums", SQL: `
+SELECT t.typname AS name, e.enumlabel AS label
+FROM pg_type t
+JOIN pg_namespace n ON n.oid = t.typnamespace
+JOIN pg_enum e ON e.enumtypid = t.oid
+WHERE n.nspname = current_schema()
+ORDER BY t.oid, e.enumsortorder`}
+
+	postgresIndexes = CatalogQuery{Name: "indexes", SQL: `
+SELECT t.relname AS table_name,
+       i.relname AS name,
+       ix.indisunique AS is_unique,
+       con.contype AS constraint_type,
+       am.amname AS method,
+       pg_get_expr(ix.indpred, ix.indrelid, true) AS predicate,
+       pg_get_indexdef(ix.indexrelid, k.ord, true) AS column_definition
+FROM pg_index ix
+JOIN pg_class i ON i.oid = ix.indexrelid
+JOIN pg_class t ON t.oid = ix.indrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+JOIN pg_am am ON am.oid = i.relam
+LEFT JOIN pg_constraint con ON con.conindid = ix.indexrelid AND con.conrelid = ix.indrelid
+     AND con.contype IN ('p', 'u', 'x')
+CROSS JOIN LATERAL generate_series(1, ix.indnkeyatts) AS k(ord)
+WHERE n.nspname = current_schema()
+ORDER BY t.oid, i.oid, k.ord`}
+
+	postgresForeignKeys = CatalogQuery{Name: "foreign_keys", SQL: `
+SELECT con.conname AS name,
+       src.relname AS table_name,
+       sa.attname AS column_name,
+       tgt.relname AS target_table,
+       ta.attname AS target_column,
+       con.confdeltype AS on_delete,
+       con.confupdtype AS on_update
+FROM pg_constraint con
+JOIN pg_class src ON src.oid = con.conrelid
+JOIN pg_namespace n ON n.oid = src.relnamespace
+JOIN pg_class tgt ON tgt.oid = con.confrelid
+CROSS JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS k(source_attnum, target_attnum, ord)
+JOIN pg_attribute sa ON sa.attrelid = con.conrelid AND sa.attnum = k.source_attnum
+JOIN pg_attribute ta ON ta.attrelid = con.confrelid AND ta.attnum = k.target_attnum
+WHERE con.contype = 'f' AND n.nspname = current_schema()
+ORDER BY src.oid, con.oid, k.ord`}
+
+	postgresChecks = CatalogQuery{Name: "checks", SQL: `
+SELECT con.conname AS name,
+       c.relname AS table_name,
+       pg_get_constraintdef(con.oid, true) AS definition
+FROM pg_constraint con
+JOIN pg_class c ON c.oid = con.conrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE con.contype = 'c' AND n.nspname = current_schema()
+ORDER BY c.oid, con.oid`}
+)
+
+// postgresActions maps pg_constraint action codes to referential actions.
+var postgresActions = map[string]string{
+	"a": "NO ACTION",
+	"r": "RESTRICT",
+	"c": "CASCADE",
+	"n": "SET NULL",
+	"d": "SET DEFAULT",
+}
+
+// postgresSerialTypes maps integer types with a sequence default to their serial alias.
+var postgresSerialTypes = map[string]string{
+	"smallint": "smallserial",
+	"integer":  "serial",
+	"bigint":   "bigserial",
+}
+
+// PostgreSQLSchema reads the current schema of a PostgreSQL database from pg_catalog:
+// tables, views, columns with their formatted types (including arrays and enums),
+// enums, primary keys, unique and check constraints, foreign keys, and indexes with
+// their access method and partial-index predicate.
+func PostgreSQLSchema(ctx context.Context, catalog CatalogQuerier, name string) (*schema.Schema, error) {
+	r := &postgresSchemaReader{catalog: catalog}
+
+	steps := []func(context.Context) error{r.relations, r.columns, r.indexes, r.foreignKeys, r.checks, r.enums}
+	for _, step := range steps {
+		err := step(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s := schema.Assemble(name, config.EnginePostgreSQL, r.tables, r.views, r.constraints)
+	s.Enums = r.enumList
+
+	return s, nil
+}
+
+// postgresSchemaReader collects the catalog rows of one PostgreSQL schema.
+type postgresSchemaReader struct {
+	catalog     CatalogQuerier
+	tables      []schema.Table
+	views       []schema.View
+	constraints []schema.Constraint
+	enumList    []schema.Enum
+}
+
+func (r *postgresSchemaReader) relations(ctx context.Context) error {
+	rows, err := queryCatalog(ctx, r.catalog, postgresRelations)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		name := row.String("name")
+
+		switch row.String("kind") {
+		case "v", "m":
+			definition := strings.TrimSuffix(strings.TrimSpace(row.String("definition")), ";")
+			r.views = append(r.views, schema.View{Name: name, Definition: definition, Columns: []schema.Column{}})
+		default:
+			if name != migrationsTable {
+				r.tables = append(r.tables, schema.Table{Name: name, Columns: []schema.Column{}})
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *postgresSchemaReader) columns(ctx context.Context) error {
+	rows, err := queryCatalog(ctx, r.catalog, postgresColumns)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		column := schema.Column{
+			Name:          row.String("name"),
+			SQLType:       row.String("type"),
+			Nullable:      !row.Bool("not_null"),
+			AutoIncrement: row.Bool("identity"),
+		}
+
+		if !row.Null("default_value") {
+			value := row.String("default_value")
+
+			if serial, ok := postgresSerialTypes[column.SQLType]; ok && strings.HasPrefix(value, "nextval(") {
+				column.SQLType = serial
+				column.AutoIncrement = true
+			} else {
+				value = postgresDefault(value, column.SQLType)
+				column.Default = &value
+			}
+		}
+
+		column.Type = schema.ColumnTypeFromSQL(column.SQLType)
+
+		if table := tableByName(r.tables, row.String("table_name")); table != nil {
+			table.Columns = append(table.Columns, column)
+		} else if view := viewByName(r.views, row.String("table_name")); view != nil {
+			column.Nullable = true
+			view.Columns = append(view.Columns, column)
+		}
+	}
+
+	return nil
+}
+
+// postgresDefault drops the cast PostgreSQL adds to string literal defaults when it
+// only restates the column type, so 'draft'::text reads back as 'draft'.
+func postgresDefault(value, columnType string) string {
+	tokens, err := sqlparse.Tokenize(value)
+	if err != nil || len(tokens) < 3 || tokens[0].Kind != sqlparse.TokenString || !tokens[1].IsPunct("::") {
+		return value
+	}
+
+	castType := sqlparse.SourceText(value, tokens[2:])
+	if strings.EqualFold(castType, columnType) || castType == "text" || castType == "character varying" {
+		return tokens[0].Text
+	}
+
+	return value
+}
+
+// indexes reads one row per index key column. Primary keys and unique constraints
+// become the table's primary key and unique constraints; exclusion constraints are skipped.
+func (r *postgresSchemaReader) indexes(ctx context.Context) error {
+	rows, err := queryCatalog(ctx, r.catalog, postgresIndexes)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groupCatalogRows(rows, "table_name", "name") {
+		first := group[0]
+
+		table := tableByName(r.tables, first.String("table_name"))
+		if table == nil {
+			continue
+		}
+
+		columns := make([]string, len(group))
+		for i, row := range group {
+			columns[i] = row.String("column_definition")
+		}
+
+		index := schema.Index{
+			Name:    first.String("name"),
+			Table:   table.Name,
+			Columns: columns,
+			Unique:  first.Bool("is_unique"),
+			Type:    schema.IndexType(first.String("method")),
+			Where:   first.String("predicate"),
+		}
+
+		switch first.String("constraint_type") {
+		case "p":
+			table.PrimaryKey = &index
+
+			for i := range table.Columns {
+				if slices.Contains(columns, table.Columns[i].Name) {
+					table.Columns[i].PrimaryKey = true
+				}
+			}
+		case "u":
+			r.constraints = append(r.constraints, uniqueConstraint(table, index.Name, columns))
+		case "x":
+			// Exclusion constraints have no equivalent in the schema model.
+		default:
+			table.Indexes = append(table.Indexes, index)
+		}
+	}
+
+	return nil
+}
+
+func (r *postgresSchemaReader) foreignKeys(ctx context.Context) error {
+	rows, err := queryCatalog(ctx, r.catalog, postgresForeignKeys)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		table := tableByName(r.tables, row.String("table_name"))
+		if table == nil {
+			continue
+		}
+
+		table.ForeignKeys = append(table.ForeignKeys, schema.ForeignKey{
+			Name:           row.String("name"),
+			SourceTable:    table.Name,
+			SourceColumn:   row.String("column_name"),
+			TargetTable:    row.String("target_table"),
+			TargetColumn:   row.String("target_column"),
+			OnDeleteAction: postgresActions[row.String("on_delete")],
+			OnUpdateAction: postgresActions[row.String("on_update")],
+		})
+	}
+
+	return nil
+}
+
+func (r *postgresSchemaReader) checks(ctx context.Context) error {
+	rows, err := queryCatalog(ctx, r.catalog, postgresChecks)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		condition := strings.TrimSuffix(strings.TrimSpace(row.String("definition")), " NOT VALID")
+		condition = strings.TrimSuffix(strings.TrimPrefix(condition, "CHECK ("), ")")
+
+		r.constraints = append(r.constraints, schema.Constraint{
+			Name:       row.String("name"),
+			Type:       schema.ConstraintTypeCheck,
+			Table:      row.String("table_name"),
+			Definition: "CHECK (" + condition + ")",
+			Check:      &schema.CheckConstraint{Condition: condition},
+		})
+	}
+
+	return nil
+}
+
+func (r *postgresSchemaReader) enums(ctx context.Context) error {
+	rows, err := queryCatalog(ctx, r.catalog, postgresEnums)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groupCatalogRows(rows, "name") {
+		enum := schema.Enum{Name: group[0].String("name")}
+
+		for _, row := range group {
+			enum.Values = append(enum.Values, row.String("label"))
+		}
+
+		r.enumList = append(r.enumList, enum)
+	}
+
+	return nil
+}
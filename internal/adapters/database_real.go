@@ -3,6 +3,8 @@ package adapters
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
 	"github.com/LarsArtmann/SQLC-Wizzard/internal/schema"
@@ -10,13 +12,22 @@ import (
 )
 
 // RealDatabaseAdapter provides actual database operations.
-type RealDatabaseAdapter struct{}
+type RealDatabaseAdapter struct {
+	// catalog answers PostgreSQL and MySQL catalog queries instead of a connection.
+	catalog CatalogQuerier
+}
 
 // NewRealDatabaseAdapter creates a new real database adapter.
 func NewRealDatabaseAdapter() *RealDatabaseAdapter {
 	return &RealDatabaseAdapter{}
 }
 
+// NewCatalogDatabaseAdapter creates a database adapter that reads PostgreSQL and
+// MySQL schemas through catalog instead of connecting to the database URI.
+func NewCatalogDatabaseAdapter(catalog CatalogQuerier) *RealDatabaseAdapter {
+	return &RealDatabaseAdapter{catalog: catalog}
+}
+
 // OperationType represents the type of database operation for logging purposes.
 type OperationType string
 
@@ -76,28 +87,85 @@ func validateDatabaseConfig(cfg *config.DatabaseConfig) error {
 	return nil
 }
 
-// TestConnection tests database connectivity.
+// databaseEngine returns the engine a database URI connects to, or "" when unknown.
+// SQLite URIs may be plain file paths, as in sqlc's database.uri.
+func databaseEngine(uri string) string {
+	scheme, _, hasScheme := strings.Cut(uri, "://")
+
+	switch {
+	case scheme == "postgres" || scheme == "postgresql":
+		return config.EnginePostgreSQL
+	case scheme == "mysql" || (!hasScheme && strings.Contains(uri, "@tcp(")):
+		return config.EngineMySQL
+	case scheme == "sqlite" || scheme == "sqlite3" || scheme == "file" || strings.HasPrefix(uri, "file:"):
+		return config.EngineSQLite
+	case !hasScheme && !strings.ContainsAny(uri, "@= "):
+		return config.EngineSQLite
+	default:
+		return ""
+	}
+}
+
+// sqlitePath returns the database file of a SQLite URI with environment variables
+// expanded, or false when the URI belongs to another engine.
+func sqlitePath(cfg *config.DatabaseConfig) (string, bool) {
+	uri := os.ExpandEnv(cfg.URI)
+	if databaseEngine(uri) != config.EngineSQLite {
+		return "", false
+	}
+
+	return sqliteDatabasePath(uri), true
+}
+
+// TestConnection tests database connectivity. SQLite databases are opened and their
+// catalog is read; other engines are only validated.
 func (a *RealDatabaseAdapter) TestConnection(
 	ctx context.Context,
 	cfg *config.DatabaseConfig,
 ) error {
-	return a.performDatabaseOperation(ctx, cfg, OperationTestConnection)
+	if err := a.performDatabaseOperation(ctx, cfg, OperationTestConnection); err != nil {
+		return err
+	}
+
+	if path, ok := sqlitePath(cfg); ok {
+		return testSQLiteConnection(ctx, path)
+	}
+
+	return nil
 }
 
-// CreateDatabase creates a new database.
+// CreateDatabase creates a new database. For SQLite this creates the database file.
 func (a *RealDatabaseAdapter) CreateDatabase(
 	ctx context.Context,
 	cfg *config.DatabaseConfig,
 ) error {
-	return a.performDatabaseOperation(ctx, cfg, OperationCreateDatabase)
+	if err := a.performDatabaseOperation(ctx, cfg, OperationCreateDatabase); err != nil {
+		return err
+	}
+
+	if path, ok := sqlitePath(cfg); ok {
+		return createSQLiteDatabase(ctx, path)
+	}
+
+	return nil
 }
 
-// DropDatabase drops a database.
+// DropDatabase drops a database. For SQLite this deletes the database file.
 func (a *RealDatabaseAdapter) DropDatabase(ctx context.Context, cfg *config.DatabaseConfig) error {
-	return a.performDatabaseOperation(ctx, cfg, OperationDropDatabase)
+	if err := a.performDatabaseOperation(ctx, cfg, OperationDropDatabase); err != nil {
+		return err
+	}
+
+	if path, ok := sqlitePath(cfg); ok {
+		return dropSQLiteDatabase(path)
+	}
+
+	return nil
 }
 
-// GetSchema returns database schema information.
+// GetSchema returns database schema information. SQLite databases are introspected
+// through their pragmas, PostgreSQL and MySQL databases through their catalogs;
+// other URIs return an empty schema.
 func (a *RealDatabaseAdapter) GetSchema(
 	ctx context.Context,
 	cfg *config.DatabaseConfig,
@@ -107,7 +175,15 @@ func (a *RealDatabaseAdapter) GetSchema(
 		return nil, err
 	}
 
-	// For now, return a simple empty schema
+	uri := os.ExpandEnv(cfg.URI)
+
+	switch engine := databaseEngine(uri); engine {
+	case config.EngineSQLite:
+		return sqliteSchema(ctx, sqliteDatabasePath(uri))
+	case config.EnginePostgreSQL, config.EngineMySQL:
+		return a.catalogSchema(ctx, engine, uri)
+	}
+
 	return &schema.Schema{
 		Name:   "auto-generated",
 		Tables: []schema.Table{},
@@ -118,19 +194,62 @@ func (a *RealDatabaseAdapter) GetSchema(
 	}, nil
 }
 
-// GenerateMigrations generates database migrations.
+// catalogSchema reads a PostgreSQL or MySQL schema, connecting to uri unless the
+// adapter was created with a catalog.
+func (a *RealDatabaseAdapter) catalogSchema(ctx context.Context, engine, uri string) (*schema.Schema, error) {
+	catalog := a.catalog
+
+	if catalog == nil {
+		db, err := openCatalog(ctx, engine, uri)
+		if err != nil {
+			return nil, err
+		}
+
+		defer func() { _ = db.Close() }()
+
+		catalog = NewSQLCatalogQuerier(db)
+	}
+
+	if engine == config.EngineMySQL {
+		return MySQLSchema(ctx, catalog, databaseName(uri))
+	}
+
+	return PostgreSQLSchema(ctx, catalog, databaseName(uri))
+}
+
+// databaseName returns the database name at the end of a URI or MySQL DSN.
+func databaseName(uri string) string {
+	path, _, _ := strings.Cut(uri, "?")
+
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// GenerateMigrations returns the statements that migrate the database to target,
+// diffing it against the database schema in target's engine dialect.
 func (a *RealDatabaseAdapter) GenerateMigrations(
 	ctx context.Context,
 	cfg *config.DatabaseConfig,
+	target *schema.Schema,
 ) ([]string, error) {
-	err := validateDatabaseConfig(cfg)
+	if err := a.performDatabaseOperation(ctx, cfg, OperationGenerateMigrations); err != nil {
+		return nil, err
+	}
+
+	if target == nil {
+		return nil, apperrors.NewError(apperrors.ErrorCodeInternalServer, "target schema is nil")
+	}
+
+	current, err := a.GetSchema(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	logDatabaseOperation(OperationGenerateMigrations, cfg.URI)
+	statements, err := schema.Diff(current, target).Statements(target.Metadata.DatabaseEngine)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate migrations: %w", err)
+	}
 
-	return []string{}, nil
+	return statements, nil
 }
 
 // maskSensitiveInfo masks sensitive information in database URIs.
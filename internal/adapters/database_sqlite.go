@@ -0,0 +1,654 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/schema"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+)
+
+// sqliteDriverName is the database/sql driver of modernc.org/sqlite, which the
+// golang-migrate SQLite driver compiles in unless the binary is built with -tags nosqlite.
+const sqliteDriverName = "sqlite"
+
+// sqliteMemory is the file name of an in-memory SQLite database.
+const sqliteMemory = ":memory:"
+
+// migrationsTable is the golang-migrate bookkeeping table, which is not part of the schema.
+const migrationsTable = "schema_migrations"
+
+// sqliteDatabasePath returns the file path of a SQLite database URI. sqlc accepts plain
+// paths and file: URIs; golang-migrate uses sqlite:// and sqlite3:// URLs.
+func sqliteDatabasePath(uri string) string {
+	for _, prefix := range []string{"sqlite://", "sqlite3://", "file://", "file:"} {
+		if rest, ok := strings.CutPrefix(uri, prefix); ok {
+			uri = rest
+
+			break
+		}
+	}
+
+	path, _, _ := strings.Cut(uri, "?")
+
+	return path
+}
+
+// openSQLite opens the SQLite database at path. Unless create is set, a missing file is
+// reported instead of silently creating an empty database.
+func openSQLite(ctx context.Context, path string, create bool) (*sql.DB, error) {
+	if !slices.Contains(sql.Drivers(), sqliteDriverName) {
+		return nil, apperrors.NewError(apperrors.ErrorCodeDatabaseNotSupported,
+			"SQLite support is not compiled into this binary; rebuild without -tags nosqlite")
+	}
+
+	if path == "" {
+		return nil, apperrors.NewError(apperrors.ErrorCodeInvalidValue, "SQLite database URI has no file path")
+	}
+
+	if !create && path != sqliteMemory {
+		if _, err := os.Stat(path); err != nil {
+			if os.IsNotExist(err) {
+				return nil, apperrors.FileNotFoundError(path)
+			}
+
+			return nil, apperrors.FileReadError(path, err)
+		}
+	}
+
+	db, err := sql.Open(sqliteDriverName, path)
+	if err != nil {
+		return nil, apperrors.Wrapf(err, apperrors.ErrDatabaseConnection, "failed to open SQLite database %s", path)
+	}
+
+	// Reading the catalog fails on files that are not SQLite databases, which a ping does not.
+	var tables int
+
+	err = db.QueryRowContext(ctx, "SELECT count(*) FROM sqlite_master").Scan(&tables)
+	if err != nil {
+		_ = db.Close()
+
+		return nil, apperrors.Wrapf(err, apperrors.ErrDatabaseConnection, "failed to open SQLite database %s", path)
+	}
+
+	return db, nil
+}
+
+// testSQLiteConnection opens an existing SQLite database and reads its catalog.
+func testSQLiteConnection(ctx context.Context, path string) error {
+	db, err := openSQLite(ctx, path, false)
+	if err != nil {
+		return err
+	}
+
+	return db.Close()
+}
+
+// createSQLiteDatabase creates an empty SQLite database file, including its directory.
+func createSQLiteDatabase(ctx context.Context, path string) error {
+	if path != sqliteMemory {
+		if _, err := os.Stat(path); err == nil {
+			return apperrors.Newf(apperrors.ErrorCodeDatabaseExists, "SQLite database %s already exists", path)
+		}
+
+		if dir := filepath.Dir(path); dir != "." {
+			err := os.MkdirAll(dir, 0o755)
+			if err != nil {
+				return fmt.Errorf("failed to create directory for %s: %w", path, err)
+			}
+		}
+	}
+
+	db, err := openSQLite(ctx, path, true)
+	if err != nil {
+		return err
+	}
+
+	// SQLite only writes the file header with the first change to the database.
+	_, err = db.ExecContext(ctx, "PRAGMA user_version = 0")
+	if err == nil {
+		_, err = db.ExecContext(ctx, "VACUUM")
+	}
+
+	closeErr := db.Close()
+	if err != nil {
+		return apperrors.Wrapf(err, apperrors.ErrDatabaseConnection, "failed to create SQLite database %s", path)
+	}
+
+	return closeErr
+}
+
+// dropSQLiteDatabase deletes a SQLite database file together with its journal files.
+func dropSQLiteDatabase(path string) error {
+	if path == sqliteMemory {
+		return nil
+	}
+
+	err := os.Remove(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return apperrors.FileNotFoundError(path)
+		}
+
+		return fmt.Errorf("failed to drop SQLite database %s: %w", path, err)
+	}
+
+	for _, suffix := range []string{"-journal", "-wal", "-shm"} {
+		err := os.Remove(path + suffix)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s%s: %w", path, suffix, err)
+		}
+	}
+
+	return nil
+}
+
+// sqliteSchema reads the tables, views, indexes and foreign keys of a SQLite database
+// from sqlite_master and the table_info, index_list and foreign_key_list pragmas.
+// Names follow the defaults of the schema parser, so a database created from a schema
+// file compares equal to that file.
+func sqliteSchema(ctx context.Context, path string) (*schema.Schema, error) {
+	db, err := openSQLite(ctx, path, false)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = db.Close() }()
+
+	reader := &sqliteSchemaReader{db: db}
+
+	objects, err := reader.objects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tables []schema.Table
+		views  []schema.View
+	)
+
+	for _, object := range objects {
+		if object.kind == "view" {
+			view, err := reader.view(ctx, object)
+			if err != nil {
+				return nil, err
+			}
+
+			views = append(views, *view)
+
+			continue
+		}
+
+		table, err := reader.table(ctx, object)
+		if err != nil {
+			return nil, err
+		}
+
+		tables = append(tables, *table)
+	}
+
+	resolveImplicitReferences(tables)
+
+	return schema.Assemble(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		config.EngineSQLite, tables, views, reader.constraints), nil
+}
+
+// sqliteObject is a table or view listed in sqlite_master.
+type sqliteObject struct {
+	kind string
+	name string
+	sql  string
+}
+
+// sqliteSchemaReader collects the schema of one SQLite database.
+type sqliteSchemaReader struct {
+	db *sql.DB
+	// constraints are the unique constraints found while reading the tables.
+	constraints []schema.Constraint
+}
+
+// objects lists the user tables and views in creation order.
+func (r *sqliteSchemaReader) objects(ctx context.Context) ([]sqliteObject, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT type, name, sql FROM sqlite_master
+		WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite\_%' ESCAPE '\' AND name != ?
+		ORDER BY rowid`, migrationsTable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SQLite tables: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var objects []sqliteObject
+
+	for rows.Next() {
+		var (
+			object  sqliteObject
+			sqlText sql.NullString
+		)
+
+		err := rows.Scan(&object.kind, &object.name, &sqlText)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list SQLite tables: %w", err)
+		}
+
+		object.sql = sqlText.String
+		objects = append(objects, object)
+	}
+
+	return objects, rows.Err()
+}
+
+// table reads a table with its columns, primary key, indexes and foreign keys.
+func (r *sqliteSchemaReader) table(ctx context.Context, object sqliteObject) (*schema.Table, error) {
+	name := object.name
+
+	columns, primaryKey, err := r.columns(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// AUTOINCREMENT is only allowed on the rowid column and appears nowhere in the pragmas.
+	if len(primaryKey) == 1 && declaresAutoincrement(object.sql) {
+		for i := range columns {
+			if columns[i].AutoIncrement {
+				columns[i].SQLiteAutoincrement = true
+			}
+		}
+	}
+
+	table := &schema.Table{Name: name, Columns: columns}
+
+	if len(primaryKey) > 0 {
+		table.PrimaryKey = &schema.Index{
+			Name:    name + "_pkey",
+			Table:   name,
+			Columns: primaryKey,
+			Unique:  true,
+			Type:    schema.IndexTypeBTree,
+		}
+	}
+
+	err = r.indexes(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+
+	table.ForeignKeys, err = r.foreignKeys(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+// columns reads PRAGMA table_info and returns the columns and the primary key columns in key order.
+func (r *sqliteSchemaReader) columns(ctx context.Context, table string) ([]schema.Column, []string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT name, type, \"notnull\", dflt_value, pk FROM pragma_table_info(?) ORDER BY cid", table)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var (
+		columns []schema.Column
+		keys    []int
+	)
+
+	for rows.Next() {
+		var (
+			column       schema.Column
+			notNull, key int
+			defaultValue sql.NullString
+		)
+
+		err := rows.Scan(&column.Name, &column.SQLType, &notNull, &defaultValue, &key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+		}
+
+		column.Type = schema.ColumnTypeFromSQL(column.SQLType)
+		column.PrimaryKey = key > 0
+		column.Nullable = notNull == 0 && key == 0
+
+		if defaultValue.Valid {
+			value := sqliteDefault(defaultValue.String)
+			column.Default = &value
+		}
+
+		columns = append(columns, column)
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+
+	var primaryKey []string
+
+	for position := 1; slices.Contains(keys, position); position++ {
+		primaryKey = append(primaryKey, columns[slices.Index(keys, position)].Name)
+	}
+
+	// A single INTEGER PRIMARY KEY column is an alias of the rowid and auto-increments.
+	if len(primaryKey) == 1 {
+		i := slices.Index(keys, 1)
+		columns[i].AutoIncrement = strings.EqualFold(columns[i].SQLType, "INTEGER")
+	}
+
+	return columns, primaryKey, nil
+}
+
+// declaresAutoincrement reports whether a CREATE TABLE statement uses the AUTOINCREMENT keyword.
+func declaresAutoincrement(createSQL string) bool {
+	tokens, err := sqlparse.Tokenize(createSQL)
+	if err != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(tokens, func(tok sqlparse.Token) bool { return tok.Is("AUTOINCREMENT") })
+}
+
+// sqliteDefault restores the parentheses SQLite requires around expression defaults,
+// which table_info reports without them.
+func sqliteDefault(value string) string {
+	tokens, err := sqlparse.Tokenize(value)
+	if err != nil || len(tokens) == 0 {
+		return value
+	}
+
+	if tokens[0].IsPunct("-") || tokens[0].IsPunct("+") {
+		tokens = tokens[1:]
+	}
+
+	if len(tokens) == 1 {
+		switch tok := tokens[0]; {
+		case tok.Kind == sqlparse.TokenString, tok.Kind == sqlparse.TokenNumber:
+			return value
+		case tok.Kind == sqlparse.TokenWord && slices.Contains(sqliteLiteralWords, tok.Upper()):
+			return value
+		}
+	}
+
+	return "(" + value + ")"
+}
+
+// sqliteLiteralWords are the keywords SQLite accepts as defaults without parentheses.
+var sqliteLiteralWords = []string{"NULL", "TRUE", "FALSE", "CURRENT_TIME", "CURRENT_DATE", "CURRENT_TIMESTAMP"}
+
+// sqliteIndex is a row of PRAGMA index_list.
+type sqliteIndex struct {
+	name    string
+	unique  bool
+	origin  string
+	partial bool
+}
+
+// indexes reads PRAGMA index_list. Indexes backing UNIQUE constraints become constraints;
+// the index backing a non-rowid primary key is skipped.
+func (r *sqliteSchemaReader) indexes(ctx context.Context, table *schema.Table) error {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT name, \"unique\", origin, partial FROM pragma_index_list(?) ORDER BY seq DESC", table.Name)
+	if err != nil {
+		return fmt.Errorf("failed to read indexes of %s: %w", table.Name, err)
+	}
+
+	var list []sqliteIndex
+
+	for rows.Next() {
+		var index sqliteIndex
+
+		err := rows.Scan(&index.name, &index.unique, &index.origin, &index.partial)
+		if err != nil {
+			_ = rows.Close()
+
+			return fmt.Errorf("failed to read indexes of %s: %w", table.Name, err)
+		}
+
+		list = append(list, index)
+	}
+
+	_ = rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read indexes of %s: %w", table.Name, err)
+	}
+
+	for _, index := range list {
+		if index.origin == "pk" {
+			continue
+		}
+
+		columns, where, err := r.indexColumns(ctx, index)
+		if err != nil {
+			return err
+		}
+
+		if index.origin == "u" {
+			name := table.Name + "_" + strings.Join(columns, "_") + "_key"
+			r.constraints = append(r.constraints, uniqueConstraint(table, name, columns))
+
+			continue
+		}
+
+		table.Indexes = append(table.Indexes, schema.Index{
+			Name:    index.name,
+			Table:   table.Name,
+			Columns: columns,
+			Unique:  index.unique,
+			Type:    schema.IndexTypeBTree,
+			Where:   where,
+		})
+	}
+
+	return nil
+}
+
+// indexColumns reads PRAGMA index_info. Expression columns and the WHERE clause of a
+// partial index are taken from the CREATE INDEX statement.
+func (r *sqliteSchemaReader) indexColumns(ctx context.Context, index sqliteIndex) ([]string, string, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT name FROM pragma_index_info(?) ORDER BY seqno", index.name)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read index %s: %w", index.name, err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var names []sql.NullString
+
+	for rows.Next() {
+		var name sql.NullString
+
+		err := rows.Scan(&name)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read index %s: %w", index.name, err)
+		}
+
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("failed to read index %s: %w", index.name, err)
+	}
+
+	expressions := !slices.ContainsFunc(names, func(name sql.NullString) bool { return !name.Valid })
+	if expressions && !index.partial {
+		columns := make([]string, len(names))
+		for i, name := range names {
+			columns[i] = name.String
+		}
+
+		return columns, "", nil
+	}
+
+	var createSQL string
+
+	err = r.db.QueryRowContext(ctx, "SELECT sql FROM sqlite_master WHERE type = 'index' AND name = ?", index.name).
+		Scan(&createSQL)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read index %s: %w", index.name, err)
+	}
+
+	columns, where := parseSQLiteIndex(createSQL)
+
+	return columns, where, nil
+}
+
+// parseSQLiteIndex returns the column expressions and the WHERE clause of CREATE INDEX.
+func parseSQLiteIndex(createSQL string) ([]string, string) {
+	statements, err := sqlparse.Split(createSQL)
+	if err != nil || len(statements) == 0 {
+		return nil, ""
+	}
+
+	cursor := sqlparse.NewCursor(createSQL, statements[0].Tokens)
+
+	for !cursor.Done() && !cursor.Peek().IsPunct("(") {
+		cursor.Next()
+	}
+
+	group, err := cursor.Group()
+	if err != nil {
+		return nil, ""
+	}
+
+	var columns []string
+
+	for _, part := range sqlparse.SplitTopLevel(group) {
+		if part[0].IsIdent() && (len(part) == 1 || !part[1].IsPunct("(")) {
+			columns = append(columns, part[0].Value)
+		} else {
+			columns = append(columns, sqlparse.SourceText(createSQL, part))
+		}
+	}
+
+	for !cursor.Done() {
+		if cursor.Accept("WHERE") {
+			return columns, cursor.Text(cursor.Rest())
+		}
+
+		cursor.Next()
+	}
+
+	return columns, ""
+}
+
+// foreignKeys reads PRAGMA foreign_key_list. SQLite does not keep constraint names, so the
+// keys are named table_columns_fkey like unnamed keys in the schema parser. The pragma
+// numbers keys from the last declared one, so they are read in reverse to keep declaration order.
+func (r *sqliteSchemaReader) foreignKeys(ctx context.Context, table string) ([]schema.ForeignKey, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, "table", "from", "to", on_update, on_delete FROM pragma_foreign_key_list(?) ORDER BY id DESC, seq`, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read foreign keys of %s: %w", table, err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var (
+		keys    []schema.ForeignKey
+		ids     []int
+		columns = map[int][]string{}
+	)
+
+	for rows.Next() {
+		var (
+			id     int
+			key    schema.ForeignKey
+			target sql.NullString
+		)
+
+		err := rows.Scan(&id, &key.TargetTable, &key.SourceColumn, &target, &key.OnUpdateAction, &key.OnDeleteAction)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read foreign keys of %s: %w", table, err)
+		}
+
+		key.SourceTable = table
+		key.TargetColumn = target.String
+		keys = append(keys, key)
+		ids = append(ids, id)
+		columns[id] = append(columns[id], key.SourceColumn)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read foreign keys of %s: %w", table, err)
+	}
+
+	for i := range keys {
+		keys[i].Name = table + "_" + strings.Join(columns[ids[i]], "_") + "_fkey"
+	}
+
+	return keys, nil
+}
+
+// view reads a view definition and its columns.
+func (r *sqliteSchemaReader) view(ctx context.Context, object sqliteObject) (*schema.View, error) {
+	columns, _, err := r.columns(ctx, object.name)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range columns {
+		columns[i].Nullable = true
+	}
+
+	return &schema.View{Name: object.name, Definition: viewDefinition(object.sql), Columns: columns}, nil
+}
+
+// viewDefinition returns the query of a CREATE VIEW statement.
+func viewDefinition(createSQL string) string {
+	statements, err := sqlparse.Split(createSQL)
+	if err != nil || len(statements) == 0 {
+		return createSQL
+	}
+
+	tokens := statements[0].Tokens
+
+	for i, tok := range tokens {
+		if tok.Is("AS") && i+1 < len(tokens) {
+			return sqlparse.SourceText(createSQL, tokens[i+1:])
+		}
+	}
+
+	return createSQL
+}
+
+// resolveImplicitReferences fills in the target columns of foreign keys that reference
+// a table without naming columns, which SQLite reports as NULL.
+func resolveImplicitReferences(tables []schema.Table) {
+	for i := range tables {
+		for j := range tables[i].ForeignKeys {
+			key := &tables[i].ForeignKeys[j]
+			if key.TargetColumn != "" {
+				continue
+			}
+
+			target := slices.IndexFunc(tables, func(t schema.Table) bool { return strings.EqualFold(t.Name, key.TargetTable) })
+			if target < 0 || tables[target].PrimaryKey == nil {
+				continue
+			}
+
+			// The n-th column of a multi-column key references the n-th primary key column.
+			n := 0
+
+			for _, other := range tables[i].ForeignKeys[:j] {
+				if other.Name == key.Name {
+					n++
+				}
+			}
+
+			if n < len(tables[target].PrimaryKey.Columns) {
+				key.TargetColumn = tables[target].PrimaryKey.Columns[n]
+			}
+		}
+	}
+}
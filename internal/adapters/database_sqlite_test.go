@@ -0,0 +1,189 @@
+//go:build !nosqlite
+
+package adapters_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/adapters"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/schema"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const introspectionSchema = `
+CREATE TABLE users (
+    id INTEGER PRIMARY KEY,
+    email TEXT NOT NULL UNIQUE,
+    name TEXT,
+    created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE teams (
+    org TEXT NOT NULL,
+    slug TEXT NOT NULL,
+    created_at TEXT DEFAULT (datetime('now')),
+    PRIMARY KEY (org, slug)
+);
+
+CREATE TABLE memberships (
+    user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    org TEXT NOT NULL,
+    slug TEXT NOT NULL,
+    role TEXT NOT NULL DEFAULT 'member',
+    FOREIGN KEY (org, slug) REFERENCES teams,
+    UNIQUE (user_id, org, slug)
+);
+
+CREATE INDEX memberships_role_idx ON memberships (role) WHERE role <> 'member';
+
+CREATE INDEX users_lower_email_idx ON users (lower(email));
+
+CREATE VIEW admins AS SELECT user_id, org FROM memberships WHERE role = 'admin';
+`
+
+func createSQLiteDatabase(t *testing.T, ddl string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "app.db")
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	_, err = db.Exec(ddl)
+	require.NoError(t, err)
+
+	return path
+}
+
+func TestRealDatabaseAdapter_SQLiteLifecycle(t *testing.T) {
+	adapter := adapters.NewRealDatabaseAdapter()
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "data", "app.db")
+	cfg := &config.DatabaseConfig{URI: "sqlite://" + path}
+
+	err := adapter.TestConnection(ctx, cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "File not found")
+
+	require.NoError(t, adapter.CreateDatabase(ctx, cfg))
+	assert.FileExists(t, path)
+
+	err = adapter.CreateDatabase(ctx, cfg)
+	require.Error(t, err)
+	assert.True(t, apperrors.Is(err, apperrors.NewError(apperrors.ErrorCodeDatabaseExists, "")))
+
+	require.NoError(t, adapter.TestConnection(ctx, cfg))
+
+	require.NoError(t, adapter.DropDatabase(ctx, cfg))
+	assert.NoFileExists(t, path)
+	assert.Error(t, adapter.DropDatabase(ctx, cfg))
+}
+
+func TestRealDatabaseAdapter_SQLiteRejectsOtherFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	require.NoError(t, os.WriteFile(path, []byte("not a database, just some text that is long enough"), 0o644))
+
+	err := adapters.NewRealDatabaseAdapter().TestConnection(context.Background(), &config.DatabaseConfig{URI: path})
+	require.Error(t, err)
+	assert.True(t, apperrors.Is(err, apperrors.ErrDatabaseConnection))
+}
+
+func TestRealDatabaseAdapter_SQLiteMemory(t *testing.T) {
+	adapter := adapters.NewRealDatabaseAdapter()
+	cfg := &config.DatabaseConfig{URI: ":memory:"}
+
+	require.NoError(t, adapter.TestConnection(context.Background(), cfg))
+
+	got, err := adapter.GetSchema(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.Empty(t, got.Tables)
+}
+
+func TestRealDatabaseAdapter_SQLiteGetSchema(t *testing.T) {
+	path := createSQLiteDatabase(t, introspectionSchema)
+
+	got, err := adapters.NewRealDatabaseAdapter().GetSchema(context.Background(),
+		&config.DatabaseConfig{URI: "file:" + path + "?mode=ro"})
+	require.NoError(t, err)
+
+	assert.Equal(t, config.EngineSQLite, got.Metadata.DatabaseEngine)
+	require.Len(t, got.Tables, 3)
+	assert.Equal(t, []string{"users", "teams", "memberships"},
+		[]string{got.Tables[0].Name, got.Tables[1].Name, got.Tables[2].Name})
+
+	users := got.Tables[0]
+	require.Len(t, users.Columns, 4)
+	assert.True(t, users.Columns[0].PrimaryKey)
+	assert.True(t, users.Columns[0].AutoIncrement)
+	assert.False(t, users.Columns[0].Nullable)
+	assert.True(t, users.Columns[1].Unique)
+	assert.True(t, users.Columns[2].Nullable)
+	assert.Equal(t, schema.ColumnTypeText, users.Columns[3].Type)
+	require.NotNil(t, users.Columns[3].Default)
+	assert.Equal(t, "CURRENT_TIMESTAMP", *users.Columns[3].Default)
+	require.Len(t, users.Indexes, 1)
+	assert.Equal(t, []string{"lower(email)"}, users.Indexes[0].Columns)
+
+	teams := got.Tables[1]
+	require.NotNil(t, teams.PrimaryKey)
+	assert.Equal(t, []string{"org", "slug"}, teams.PrimaryKey.Columns)
+	assert.False(t, users.Columns[0].SQLiteAutoincrement)
+	assert.False(t, teams.Columns[0].AutoIncrement)
+
+	memberships := got.Tables[2]
+	require.Len(t, memberships.ForeignKeys, 3)
+	assert.Equal(t, "memberships_user_id_fkey", memberships.ForeignKeys[0].Name)
+	assert.Equal(t, "CASCADE", memberships.ForeignKeys[0].OnDeleteAction)
+	assert.Equal(t, "memberships_org_slug_fkey", memberships.ForeignKeys[1].Name)
+	assert.Equal(t, "slug", memberships.ForeignKeys[2].TargetColumn)
+	require.Len(t, memberships.Indexes, 1)
+	assert.Equal(t, "role <> 'member'", memberships.Indexes[0].Where)
+
+	require.Len(t, got.Views, 1)
+	assert.Equal(t, "SELECT user_id, org FROM memberships WHERE role = 'admin'", got.Views[0].Definition)
+	assert.Len(t, got.Views[0].Columns, 2)
+}
+
+func TestRealDatabaseAdapter_SQLiteSchemaMatchesParser(t *testing.T) {
+	path := createSQLiteDatabase(t, introspectionSchema)
+
+	parser, err := schema.NewParser(schema.EngineSQLite)
+	require.NoError(t, err)
+	require.NoError(t, parser.ParseSQL("schema.sql", introspectionSchema))
+
+	parsed := parser.Schema("app")
+
+	statements, err := adapters.NewRealDatabaseAdapter().GenerateMigrations(context.Background(),
+		&config.DatabaseConfig{URI: path}, parsed)
+	require.NoError(t, err)
+	assert.Empty(t, statements)
+}
+
+func TestRealDatabaseAdapter_SQLiteAutoincrement(t *testing.T) {
+	const ddl = `CREATE TABLE events (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT);`
+
+	path := createSQLiteDatabase(t, ddl)
+
+	got, err := adapters.NewRealDatabaseAdapter().GetSchema(context.Background(), &config.DatabaseConfig{URI: path})
+	require.NoError(t, err)
+	require.Len(t, got.Tables, 1)
+	assert.True(t, got.Tables[0].Columns[0].SQLiteAutoincrement)
+
+	parser, err := schema.NewParser(schema.EngineSQLite)
+	require.NoError(t, err)
+	require.NoError(t, parser.ParseSQL("schema.sql", ddl))
+
+	statements, err := adapters.NewRealDatabaseAdapter().GenerateMigrations(context.Background(),
+		&config.DatabaseConfig{URI: path}, parser.Schema("app"))
+	require.NoError(t, err)
+	assert.Empty(t, statements)
+}
@@ -42,8 +42,8 @@ type DatabaseAdapter interface {
 	// GetSchema returns database schema information
 	GetSchema(ctx context.Context, cfg *config.DatabaseConfig) (*schema.Schema, error)
 
-	// GenerateMigrations generates database migrations
-	GenerateMigrations(ctx context.Context, cfg *config.DatabaseConfig) ([]string, error)
+	// GenerateMigrations returns the statements that migrate the database to target
+	GenerateMigrations(ctx context.Context, cfg *config.DatabaseConfig, target *schema.Schema) ([]string, error)
 }
 
 // CLIAdapter defines interface for CLI operations
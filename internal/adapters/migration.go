@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/LarsArtmann/SQLC-Wizzard/internal/migration"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/schema"
 )
 
 // MigrationAdapter defines the interface for database migration operations
@@ -12,15 +13,30 @@ type MigrationAdapter interface {
 	// Migrate runs database migrations from a source
 	Migrate(ctx context.Context, source, databaseURL string) error
 
+	// MigrateSteps applies the next n pending migrations
+	MigrateSteps(ctx context.Context, source, databaseURL string, steps int) error
+
 	// Rollback rolls back database migrations
 	Rollback(ctx context.Context, source, databaseURL string, steps int) error
 
+	// Goto migrates up or down to the given version
+	Goto(ctx context.Context, source, databaseURL string, version uint) error
+
+	// Force sets the migration version without running migrations and clears the dirty flag
+	Force(ctx context.Context, source, databaseURL string, version int) error
+
+	// Redo rolls back the last applied migration and applies it again
+	Redo(ctx context.Context, source, databaseURL string) error
+
 	// Status checks migration status
 	Status(ctx context.Context, source, databaseURL string) (*migration.MigrationStatus, error)
 
-	// Validate validates migration files
-	Validate(ctx context.Context, source string) error
+	// Validate checks the migration files of a file:// source or directory
+	Validate(ctx context.Context, source string) (*migration.DirectoryReport, error)
 
 	// CreateMigration creates a new migration file
 	CreateMigration(ctx context.Context, name, directory string) (string, error)
+
+	// GenerateMigration writes a migration file pair with the DDL that turns from into to
+	GenerateMigration(ctx context.Context, name, directory string, from, to *schema.Schema) (string, error)
 }
@@ -0,0 +1,7 @@
+//go:build mysql
+
+package adapters
+
+// Registers the MySQL driver for mysql:// URLs.
+// Build with -tags mysql to include it.
+import _ "github.com/golang-migrate/migrate/v4/database/mysql"
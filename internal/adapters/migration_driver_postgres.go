@@ -0,0 +1,7 @@
+//go:build postgres
+
+package adapters
+
+// Registers the PostgreSQL driver for postgres:// and postgresql:// URLs.
+// Build with -tags postgres to include it.
+import _ "github.com/golang-migrate/migrate/v4/database/postgres"
@@ -0,0 +1,7 @@
+//go:build !nosqlite
+
+package adapters
+
+// Registers the pure-Go SQLite driver (modernc.org/sqlite) for sqlite:// URLs.
+// Build with -tags nosqlite to leave it out.
+import _ "github.com/golang-migrate/migrate/v4/database/sqlite"
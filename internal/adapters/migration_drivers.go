@@ -0,0 +1,38 @@
+package adapters
+
+import (
+	"slices"
+
+	"github.com/golang-migrate/migrate/v4/database"
+)
+
+// migrationDriverTags maps the golang-migrate driver names this project supports
+// to the build tag that compiles them in. SQLite is pure Go and included by default.
+var migrationDriverTags = map[string]string{
+	"sqlite":   "!nosqlite",
+	"postgres": "postgres",
+	"mysql":    "mysql",
+}
+
+// MigrationDrivers returns the golang-migrate database drivers compiled into this binary.
+func MigrationDrivers() []string {
+	drivers := database.List()
+	slices.Sort(drivers)
+
+	return drivers
+}
+
+// MissingMigrationDrivers returns the supported drivers that were left out of this
+// binary, together with the build tag that adds each of them.
+func MissingMigrationDrivers() map[string]string {
+	compiled := database.List()
+	missing := make(map[string]string)
+
+	for driver, tag := range migrationDriverTags {
+		if !slices.Contains(compiled, driver) {
+			missing[driver] = tag
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,143 @@
+//go:build !nosqlite
+
+package adapters_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/adapters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSQLiteMigrations(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"1_create_users.up.sql":   "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL);",
+		"1_create_users.down.sql": "DROP TABLE users;",
+		"2_add_email.up.sql":      "ALTER TABLE users ADD COLUMN email TEXT;",
+		"2_add_email.down.sql":    "ALTER TABLE users DROP COLUMN email;",
+		"3_create_posts.up.sql":   "CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users (id));",
+		"3_create_posts.down.sql": "DROP TABLE posts;",
+	}
+
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	return "file://" + filepath.ToSlash(dir)
+}
+
+func sqliteTables(t *testing.T, path string) []string {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+
+	defer func() { _ = db.Close() }()
+
+	rows, err := db.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'schema_migrations' ORDER BY name")
+	require.NoError(t, err)
+
+	defer func() { _ = rows.Close() }()
+
+	var tables []string
+
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+
+		tables = append(tables, name)
+	}
+
+	require.NoError(t, rows.Err())
+
+	return tables
+}
+
+func TestMigrationDrivers_SQLiteIncludedByDefault(t *testing.T) {
+	assert.Contains(t, adapters.MigrationDrivers(), "sqlite")
+	assert.NotContains(t, adapters.MissingMigrationDrivers(), "sqlite")
+}
+
+func TestRealMigrationAdapter_SQLiteEndToEnd(t *testing.T) {
+	ctx := context.Background()
+	adapter := adapters.NewRealMigrationAdapter()
+	source := writeSQLiteMigrations(t)
+	dbPath := filepath.Join(t.TempDir(), "app.db")
+	databaseURL := "sqlite://" + filepath.ToSlash(dbPath)
+
+	status, err := adapter.Status(ctx, source, databaseURL)
+	require.NoError(t, err)
+	assert.Nil(t, status.GetCurrentVersion())
+
+	require.NoError(t, adapter.MigrateSteps(ctx, source, databaseURL, 1))
+	assert.Equal(t, []string{"users"}, sqliteTables(t, dbPath))
+
+	require.NoError(t, adapter.Migrate(ctx, source, databaseURL))
+	assert.Equal(t, []string{"posts", "users"}, sqliteTables(t, dbPath))
+
+	status, err = adapter.Status(ctx, source, databaseURL)
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), *status.GetCurrentVersion())
+	assert.False(t, status.IsDirty())
+
+	require.NoError(t, adapter.Rollback(ctx, source, databaseURL, 1))
+	assert.Equal(t, []string{"users"}, sqliteTables(t, dbPath))
+
+	require.NoError(t, adapter.Redo(ctx, source, databaseURL))
+
+	status, err = adapter.Status(ctx, source, databaseURL)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), *status.GetCurrentVersion())
+
+	require.NoError(t, adapter.Goto(ctx, source, databaseURL, 3))
+	assert.Equal(t, []string{"posts", "users"}, sqliteTables(t, dbPath))
+
+	require.NoError(t, adapter.Rollback(ctx, source, databaseURL, 3))
+	assert.Empty(t, sqliteTables(t, dbPath))
+
+	require.Error(t, adapter.Redo(ctx, source, databaseURL))
+
+	require.NoError(t, adapter.Force(ctx, source, databaseURL, 1))
+
+	status, err = adapter.Status(ctx, source, databaseURL)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), *status.GetCurrentVersion())
+}
+
+func TestRealMigrationAdapter_SQLiteDirtyState(t *testing.T) {
+	ctx := context.Background()
+	adapter := adapters.NewRealMigrationAdapter()
+	source := writeSQLiteMigrations(t)
+
+	broken := filepath.Join(source[len("file://"):], "4_broken.up.sql")
+	require.NoError(t, os.WriteFile(broken, []byte("CREATE TABLE broken (;"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(broken), "4_broken.down.sql"), []byte("DROP TABLE broken;"), 0o644))
+
+	databaseURL := "sqlite://" + filepath.ToSlash(filepath.Join(t.TempDir(), "app.db"))
+
+	require.Error(t, adapter.Migrate(ctx, source, databaseURL))
+
+	status, err := adapter.Status(ctx, source, databaseURL)
+	require.NoError(t, err)
+	assert.True(t, status.IsDirty())
+	assert.Equal(t, uint(4), *status.GetCurrentVersion())
+
+	err = adapter.MigrateSteps(ctx, source, databaseURL, 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dirty")
+
+	require.NoError(t, adapter.Force(ctx, source, databaseURL, 3))
+
+	status, err = adapter.Status(ctx, source, databaseURL)
+	require.NoError(t, err)
+	assert.False(t, status.IsDirty())
+	assert.Equal(t, uint(3), *status.GetCurrentVersion())
+}
@@ -226,4 +226,7 @@ var (
 	// Generator Errors.
 	ErrInvalidTemplate      = NewError(ErrorCodeInvalidTemplate, "Invalid template")
 	ErrDatabaseNotSupported = NewError(ErrorCodeDatabaseNotSupported, "Database not supported")
+
+	// Database Errors.
+	ErrDatabaseConnection = NewError(ErrorCodeDatabaseConnection, "Database connection failed")
 )
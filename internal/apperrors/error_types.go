@@ -23,6 +23,10 @@ const (
 	ErrorCodeTableNotFound    ErrorCode = "TABLE_NOT_FOUND"
 	ErrorCodeColumnNotFound   ErrorCode = "COLUMN_NOT_FOUND"
 
+	// Database Errors.
+	ErrorCodeDatabaseConnection ErrorCode = "DATABASE_CONNECTION"
+	ErrorCodeDatabaseExists     ErrorCode = "DATABASE_EXISTS"
+
 	// Event Errors.
 	ErrorCodeEventValidation  ErrorCode = "EVENT_VALIDATION"
 	ErrorCodeEventNotFound    ErrorCode = "EVENT_NOT_FOUND"
@@ -2,6 +2,8 @@ package commands_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,6 +12,7 @@ import (
 	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
 )
 
 var _ = Describe("Validate Command Enhanced Testing", func() {
@@ -169,6 +172,213 @@ var _ = Describe("Validate Command Enhanced Testing", func() {
 		})
 	})
 
+	Context("Path Validation", func() {
+		writeConfig := func(schema string) string {
+			cfg := createTestSqlcConfig(schema, "db", "db")
+			cfg.SQL[0].Queries = config.NewSinglePath("queries")
+			cfg.SQL[0].Gen.Go.EmitInterface = true
+			cfg.SQL[0].Gen.Go.EmitPreparedQueries = true
+			cfg.SQL[0].Gen.Go.EmitJSONTags = true
+
+			yamlData, err := config.Marshal(cfg)
+			Expect(err).NotTo(HaveOccurred())
+
+			path := filepath.Join(tempDir, "sqlc.yaml")
+			Expect(os.WriteFile(path, yamlData, 0o644)).To(Succeed())
+
+			return path
+		}
+
+		BeforeEach(func() {
+			Expect(os.WriteFile(filepath.Join(tempDir, "schema.sql"), []byte("CREATE TABLE users (id int);"), 0o644)).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(tempDir, "queries"), 0o755)).To(Succeed())
+		})
+
+		It("should fail when a referenced path does not exist", func() {
+			cmd := commands.NewValidateCommand()
+			cmd.SetArgs([]string{writeConfig("missing.sql")})
+
+			Expect(cmd.Execute()).To(MatchError(ContainSubstring("validation failed")))
+		})
+
+		It("should only fail on warnings in strict mode", func() {
+			configPath := writeConfig("schema.sql")
+
+			cmd := commands.NewValidateCommand()
+			cmd.SetArgs([]string{configPath})
+			Expect(cmd.Execute()).To(Succeed())
+
+			cmd = commands.NewValidateCommand()
+			cmd.SetArgs([]string{configPath, "--strict"})
+			Expect(cmd.Execute()).To(MatchError(ContainSubstring("validation failed with 1 error(s)")))
+		})
+	})
+
+	Context("Auto-fix", func() {
+		const brokenConfig = `# keep this comment
+sql:
+  - engine: sqlite
+    schema: schema.sql
+    queries: queries
+    gen:
+      go:
+        out: internal/store
+        json_tags_case_style: camelCase
+        emit_json_tags: true
+        emit_prepared_queries: true
+`
+
+		var configPath string
+
+		BeforeEach(func() {
+			Expect(os.WriteFile(filepath.Join(tempDir, "schema.sql"), []byte("CREATE TABLE users (id int);"), 0o644)).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(tempDir, "queries"), 0o755)).To(Succeed())
+
+			configPath = filepath.Join(tempDir, "sqlc.yaml")
+			Expect(os.WriteFile(configPath, []byte(brokenConfig), 0o644)).To(Succeed())
+		})
+
+		It("should leave the file alone with --dry-run", func() {
+			cmd := commands.NewValidateCommand()
+			cmd.SetArgs([]string{configPath, "--fix", "--dry-run"})
+			Expect(cmd.Execute()).To(HaveOccurred())
+
+			data, err := os.ReadFile(configPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(brokenConfig))
+		})
+
+		It("should write the fixes and keep comments", func() {
+			cmd := commands.NewValidateCommand()
+			cmd.SetArgs([]string{configPath, "--fix"})
+			Expect(cmd.Execute()).To(Succeed())
+
+			data, err := os.ReadFile(configPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			cfg, err := config.Parse(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Version).To(Equal("2"))
+			Expect(cfg.SQL[0].Gen.Go.Package).To(Equal("store"))
+			Expect(cfg.SQL[0].Gen.Go.JSONTagsCaseStyle).To(Equal("camel"))
+			Expect(cfg.SQL[0].Gen.Go.EmitInterface).To(BeTrue())
+			Expect(string(data)).To(HavePrefix("# keep this comment\n"))
+		})
+
+		It("should upgrade legacy rules as a fix of its own", func() {
+			Expect(os.WriteFile(configPath, []byte(`# keep this comment
+version: "2"
+sql:
+  - engine: sqlite
+    schema: schema.sql
+    queries: queries
+    gen:
+      go:
+        package: store
+        out: internal/store
+        emit_interface: true
+    rules:
+      - name: no-select-star
+        rule: "!query.sql.contains('SELECT *')"
+`), 0o644)).To(Succeed())
+
+			cmd := commands.NewValidateCommand()
+			cmd.SetArgs([]string{configPath, "--fix"})
+
+			var err error
+
+			out := captureStdout(func() { err = cmd.Execute() })
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("Applied 1 fix(es)"))
+			Expect(out).To(ContainSubstring("move the rule definitions to the top-level rules"))
+
+			data, err := os.ReadFile(configPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(HavePrefix("# keep this comment\n"))
+			Expect(string(data)).To(ContainSubstring("    rules:\n      - no-select-star\n"))
+			Expect(string(data)).To(MatchRegexp(`(?m)^rules:\n  - name: no-select-star\n`))
+		})
+
+		It("should reject --dry-run without --fix", func() {
+			cmd := commands.NewValidateCommand()
+			cmd.SetArgs([]string{configPath, "--dry-run"})
+			Expect(cmd.Execute()).To(MatchError(ContainSubstring("--dry-run")))
+		})
+	})
+
+	Context("Machine-readable output", func() {
+		const configYAML = `version: "2"
+sql:
+  - engine: sqlite
+    schema: schema.sql
+    queries: queries
+    gen:
+      go:
+        out: internal/store
+`
+
+		var configPath string
+
+		BeforeEach(func() {
+			Expect(os.WriteFile(filepath.Join(tempDir, "schema.sql"), []byte("CREATE TABLE users (id int);"), 0o644)).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(tempDir, "queries"), 0o755)).To(Succeed())
+
+			configPath = filepath.Join(tempDir, "sqlc.yaml")
+			Expect(os.WriteFile(configPath, []byte(configYAML), 0o644)).To(Succeed())
+		})
+
+		execute := func(args ...string) (string, error) {
+			root := &cobra.Command{Use: "sqlc-wizard", SilenceUsage: true, SilenceErrors: true}
+			commands.AddGlobalFlags(root)
+			root.AddCommand(commands.NewValidateCommand())
+			root.SetArgs(args)
+
+			var err error
+
+			out := captureStdout(func() { err = root.Execute() })
+
+			return out, err
+		}
+
+		It("should emit JSON diagnostics positioned in the config file", func() {
+			out, err := execute("validate", configPath, "--format", "json")
+			Expect(err).To(HaveOccurred())
+
+			var report struct {
+				Tool        string `json:"tool"`
+				Diagnostics []struct {
+					Code string `json:"code"`
+					File string `json:"file"`
+					Line int    `json:"line"`
+				} `json:"diagnostics"`
+			}
+			Expect(json.Unmarshal([]byte(out), &report)).To(Succeed())
+			Expect(report.Tool).To(Equal("validate"))
+
+			var codes []string
+			for _, d := range report.Diagnostics {
+				codes = append(codes, d.Code)
+				if d.Code == "config/sql.gen.go.package" {
+					Expect(d.File).To(Equal(configPath))
+					Expect(d.Line).To(Equal(7)) // the go: mapping, as package is missing
+				}
+			}
+			Expect(codes).To(ContainElements("config/sql.gen.go.package", config.CheckNoSQLFiles))
+		})
+
+		It("should emit GitHub annotations", func() {
+			out, err := execute("validate", configPath, "--format", "github")
+			Expect(err).To(HaveOccurred())
+			Expect(out).To(ContainSubstring("::error file=" + filepath.ToSlash(configPath)))
+			Expect(out).To(ContainSubstring("title=" + config.CheckNoSQLFiles))
+		})
+
+		It("should reject unknown formats", func() {
+			_, err := execute("validate", configPath, "--format", "xml")
+			Expect(err).To(MatchError(ContainSubstring("unknown output format")))
+		})
+	})
+
 	Context("Multiple File Handling", func() {
 		It("should handle multiple configuration files", func() {
 			// Create first config
@@ -221,3 +431,27 @@ var _ = Describe("Validate Command Enhanced Testing", func() {
 		})
 	})
 })
+
+// captureStdout returns what fn writes to os.Stdout.
+func captureStdout(fn func()) string {
+	r, w, err := os.Pipe()
+	Expect(err).NotTo(HaveOccurred())
+
+	stdout := os.Stdout
+	os.Stdout = w
+
+	done := make(chan string)
+
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+
+	fn()
+
+	os.Stdout = stdout
+
+	Expect(w.Close()).To(Succeed())
+
+	return <-done
+}
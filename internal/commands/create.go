@@ -223,10 +223,9 @@ func showCreateSuccess(
 
 	PrintNextSteps([]string{
 		"1. cd " + projectName,
-		"2. make setup          # Install dependencies",
-		"3. make dev             # Start development environment",
-		"4. sqlc generate        # Generate Go code",
-		"5. make test            # Run tests",
+		"2. go generate ./...    # Generate Go code with sqlc",
+		"3. go mod tidy          # Resolve dependencies and write go.sum",
+		"4. go run ./cmd/server  # Connect to the database",
 	})
 
 	fmt.Println()
@@ -3,11 +3,15 @@ package commands
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"runtime"
+	"slices"
+	"strings"
 
 	"github.com/LarsArtmann/SQLC-Wizzard/internal/adapters"
 	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/diagnostics"
 	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
 	"github.com/spf13/cobra"
 )
@@ -89,14 +93,9 @@ type DoctorResult struct {
 	Error    error
 }
 
-// runDoctor executes the diagnostic checks.
-func runDoctor(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
-
-	fmt.Println("🩺 SQLC-Wizard Health Check")
-	fmt.Println("============================")
-
-	checks := []DoctorCheck{
+// doctorChecks returns the diagnostic checks run by the doctor command.
+func doctorChecks() []DoctorCheck {
+	return []DoctorCheck{
 		{
 			Name:        "go-version",
 			Description: "Check Go version compatibility",
@@ -123,6 +122,24 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 			Checker:     checkMemoryAvailability,
 		},
 	}
+}
+
+// runDoctor executes the diagnostic checks.
+func runDoctor(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	checks := doctorChecks()
+
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	if !format.IsText() {
+		return runDoctorReport(ctx, checks, format)
+	}
+
+	fmt.Println("🩺 SQLC-Wizard Health Check")
+	fmt.Println("============================")
 
 	var failed, warned int
 
@@ -184,6 +201,54 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	}
 }
 
+// runDoctorReport runs the checks and writes warnings and failures as diagnostics.
+func runDoctorReport(ctx context.Context, checks []DoctorCheck, format diagnostics.Format) error {
+	report := diagnostics.NewReport("doctor")
+
+	// Some checks print progress; keep it off stdout so the report stays parseable.
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+
+	defer func() { os.Stdout = stdout }()
+
+	for _, check := range checks {
+		result := check.Checker(ctx)
+		if result.Status == DoctorStatusPass {
+			continue
+		}
+
+		d := diagnostics.Diagnostic{
+			Code:     check.Name,
+			Severity: apperrors.ErrorSeverityWarning,
+			Message:  result.Message,
+			Hint:     result.Solution,
+		}
+
+		if result.Status == DoctorStatusFail {
+			d.Severity = apperrors.ErrorSeverityError
+		}
+
+		if result.Error != nil {
+			d.Message = fmt.Sprintf("%s: %v", d.Message, result.Error)
+		}
+
+		report.Add(d)
+	}
+
+	os.Stdout = stdout
+
+	if err := writeReport(format, report); err != nil {
+		return err
+	}
+
+	if failed := report.ErrorCount(); failed > 0 {
+		return apperrors.NewError(apperrors.ErrorCodeInternalServer, "health_check_failed").
+			WithDescription(fmt.Sprintf("environment issues detected: failed=%d, warned=%d", failed, report.WarningCount()))
+	}
+
+	return nil
+}
+
 // checkGoVersion checks Go version compatibility.
 func checkGoVersion(ctx context.Context) *DoctorResult {
 	goVersion := runtime.Version()
@@ -237,27 +302,47 @@ func checkSQLCInstallation(ctx context.Context) *DoctorResult {
 
 // checkDatabaseDrivers checks database driver availability.
 func checkDatabaseDrivers(ctx context.Context) *DoctorResult {
-	dbAdapter := adapters.NewRealDatabaseAdapter()
+	drivers := adapters.MigrationDrivers()
+	missing := adapters.MissingMigrationDrivers()
 
-	// Check connection to SQLite (most common for development)
-	sqliteConfig := &config.DatabaseConfig{
-		URI:     ":memory:",
-		Managed: false,
-	}
-
-	err := dbAdapter.TestConnection(ctx, sqliteConfig)
-	if err != nil {
+	if len(drivers) == 0 {
 		return &DoctorResult{
 			Status:   DoctorStatusWarn,
-			Message:  "SQLite driver may not be available",
-			Solution: "Install SQLite3: brew install sqlite3 (macOS) or apt-get install sqlite3 (Ubuntu)",
-			Error:    err,
+			Message:  "No migration database drivers are compiled into this binary",
+			Solution: "Rebuild without the nosqlite tag, or with -tags " + strings.Join(slices.Sorted(maps.Values(missing)), ","),
+		}
+	}
+
+	message := "Migration drivers: " + strings.Join(drivers, ", ")
+
+	if len(missing) > 0 {
+		tags := slices.Sorted(maps.Values(missing))
+		tags = slices.DeleteFunc(tags, func(tag string) bool { return strings.HasPrefix(tag, "!") })
+
+		if len(tags) > 0 {
+			message += fmt.Sprintf(" (rebuild with -tags %s for %s)",
+				strings.Join(tags, ","), strings.Join(slices.Sorted(maps.Keys(missing)), ", "))
 		}
 	}
 
+	// Listing a driver only proves it registered; open a database to prove it works.
+	if slices.Contains(drivers, "sqlite") {
+		_, err := adapters.NewRealDatabaseAdapter().GetSchema(ctx, &config.DatabaseConfig{URI: ":memory:"})
+		if err != nil {
+			return &DoctorResult{
+				Status:   DoctorStatusWarn,
+				Message:  message + "; SQLite failed to open an in-memory database",
+				Solution: "Check that modernc.org/sqlite supports " + runtime.GOOS + "/" + runtime.GOARCH,
+				Error:    err,
+			}
+		}
+
+		message += "; SQLite opened an in-memory database"
+	}
+
 	return &DoctorResult{
 		Status:  DoctorStatusPass,
-		Message: "SQLite driver is available",
+		Message: message,
 	}
 }
 
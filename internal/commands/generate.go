@@ -3,10 +3,13 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/LarsArtmann/SQLC-Wizzard/generated"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
 	"github.com/LarsArtmann/SQLC-Wizzard/internal/generators"
 	"github.com/LarsArtmann/SQLC-Wizzard/internal/templates"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
 	"github.com/spf13/cobra"
 )
 
@@ -25,25 +28,37 @@ func NewGenerateCommand() *cobra.Command {
 		Use:   "generate",
 		Short: "Generate SQL files and configurations",
 		Long: `Generate creates example SQL files and configurations.
-Use this to quickly scaffold a working sqlc setup.`,
+Use this to quickly scaffold a working sqlc setup, or run "generate crud"
+to write CRUD queries for the tables of an existing schema.
+
+With --config, an example schema and query file is written for every sql[]
+entry of sqlc.yaml, into the entry's schema and queries paths and in the
+dialect of its engine. Without it, PostgreSQL examples go to --output.`,
 		Example: `  sqlc-wizard generate
-  sqlc-wizard generate --output ./generated --force`,
+  sqlc-wizard generate --output ./generated --force
+  sqlc-wizard generate --config sqlc.yaml
+  sqlc-wizard generate crud --table users`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runGenerate(opts)
 		},
 	}
 
 	cmd.Flags().
-		StringVarP(&opts.configPath, "config", "c", "", "Path to sqlc.yaml configuration file")
+		StringVarP(&opts.configPath, "config", "c", "", "Path to sqlc.yaml; write examples into the paths of its sql[] entries")
 	cmd.Flags().
-		StringVarP(&opts.outputDir, "output", "o", ".", "Output directory for generated files")
+		StringVarP(&opts.outputDir, "output", "o", ".", "Output directory for generated files when no --config is given")
 	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Overwrite existing files")
 
+	cmd.AddCommand(newGenerateCRUDCommand())
+
 	return cmd
 }
 
 func runGenerate(opts *GenerateOptions) error {
-	// For now, just generate example files
+	if opts.configPath != "" {
+		return generateFromConfig(opts.configPath, opts.force)
+	}
+
 	return generateExampleFiles(opts.outputDir, opts.force)
 }
 
@@ -94,20 +109,131 @@ func generateExampleFiles(outputDir string, force bool) error {
 	}
 
 	// Generate example files
-	err := generator.GenerateExampleSchema(templateData)
+	err := generateExamples(generator, templateData)
 	if err != nil {
-		return fmt.Errorf("failed to generate schema in %s: %w", outputDir, err)
+		return fmt.Errorf("failed to generate examples in %s: %w", outputDir, err)
 	}
 
-	err = generator.GenerateExampleQueries(templateData)
+	fmt.Printf("✅ Successfully generated example SQL files to %s\n", outputDir)
+	printGeneratedFiles(generator, templateData)
+
+	return nil
+}
+
+// generateFromConfig writes the example schema and queries of every sql[] entry of
+// sqlc.yaml to its schema and queries paths, resolved against the config's directory,
+// using the templates of the entry's engine. Existing files are only replaced with force.
+func generateFromConfig(configPath string, force bool) error {
+	cfg, err := config.ParseFile(configPath)
 	if err != nil {
-		return fmt.Errorf("failed to generate queries in %s: %w", outputDir, err)
+		return fmt.Errorf("failed to parse config: %w", err)
 	}
 
-	fmt.Printf("✅ Successfully generated example SQL files to %s\n", outputDir)
-	fmt.Printf("📄 Generated files:\n")
-	fmt.Printf("   - %s/schema/001_users_table.sql\n", outputDir)
-	fmt.Printf("   - %s/queries/users.sql\n", outputDir)
+	if len(cfg.SQL) == 0 {
+		return apperrors.Newf(apperrors.ErrorCodeConfigValidation, "%s has no sql entries", configPath)
+	}
+
+	baseDir := filepath.Dir(configPath)
+	generator := generators.NewGenerator(baseDir)
+
+	var entries []generated.TemplateData
+
+	written := map[string]bool{}
+
+	for i := range cfg.SQL {
+		data, err := exampleTemplateData(&cfg.SQL[i], baseDir)
+		if err != nil {
+			return fmt.Errorf("sql[%d]: %w", i, err)
+		}
+
+		// Entries sharing their paths with an earlier entry get no second copy.
+		queriesPath, schemaPath := generator.ExamplePaths(data)
+		if written[queriesPath] && written[schemaPath] {
+			continue
+		}
+
+		for _, path := range []string{schemaPath, queriesPath} {
+			if _, err := os.Stat(path); err == nil && !force {
+				return fmt.Errorf("%s already exists. Use --force to overwrite", path)
+			}
+
+			written[path] = true
+		}
+
+		entries = append(entries, data)
+	}
+
+	for _, data := range entries {
+		err := generateExamples(generator, data)
+		if err != nil {
+			return fmt.Errorf("failed to generate %s examples: %w", data.Database.Engine, err)
+		}
+	}
+
+	fmt.Printf("✅ Successfully generated example SQL files for %d sql entr(ies) in %s\n", len(entries), configPath)
+
+	for _, data := range entries {
+		printGeneratedFiles(generator, data)
+	}
+
+	return nil
+}
+
+// exampleTemplateData derives the template data of one sql[] entry: its engine, Go
+// package, and the first of its queries and schema paths.
+func exampleTemplateData(sql *config.SQLConfig, baseDir string) (generated.TemplateData, error) {
+	engine, err := templates.NewDatabaseType(sql.Engine)
+	if err != nil {
+		return generated.TemplateData{}, apperrors.Newf(apperrors.ErrorCodeConfigValidation,
+			"unsupported engine %q (must be one of: postgresql, mysql, sqlite)", sql.Engine)
+	}
+
+	pkg := generated.PackageConfig{Name: "db", Path: "db"}
+	if sql.Gen.Go != nil {
+		pkg = generated.PackageConfig{Name: sql.Gen.Go.Package, Path: sql.Gen.Go.Out, BuildTags: sql.Gen.Go.BuildTags}
+	}
+
+	return generated.TemplateData{
+		ProjectName: sql.Name,
+		ProjectType: templates.MustNewProjectType("microservice"),
+		Package:     pkg,
+		Database: generated.DatabaseConfig{
+			Engine:    engine,
+			UseUUIDs:  engine == templates.DatabaseTypePostgreSQL,
+			UseJSON:   true,
+			UseArrays: engine == templates.DatabaseTypePostgreSQL,
+		},
+		Output: generated.OutputConfig{
+			BaseDir:    baseDir,
+			QueriesDir: sql.Queries.First(),
+			SchemaDir:  sql.Schema.First(),
+		},
+		Validation: generated.ValidationConfig{
+			StrictFunctions: sql.StrictFunctionChecks != nil && *sql.StrictFunctionChecks,
+			StrictOrderBy:   sql.StrictOrderBy != nil && *sql.StrictOrderBy,
+		},
+	}, nil
+}
+
+// generateExamples writes the example schema and queries for data.
+func generateExamples(generator *generators.Generator, data generated.TemplateData) error {
+	err := generator.GenerateExampleSchema(data)
+	if err != nil {
+		return fmt.Errorf("failed to generate schema: %w", err)
+	}
+
+	err = generator.GenerateExampleQueries(data)
+	if err != nil {
+		return fmt.Errorf("failed to generate queries: %w", err)
+	}
 
 	return nil
 }
+
+func printGeneratedFiles(generator *generators.Generator, data generated.TemplateData) {
+	queriesPath, schemaPath := generator.ExamplePaths(data)
+
+	fmt.Printf("📄 Generated %s files:\n", data.Database.Engine)
+	fmt.Printf("   - %s\n", schemaPath)
+	fmt.Printf("   - %s\n", queriesPath)
+}
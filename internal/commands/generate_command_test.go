@@ -0,0 +1,201 @@
+package commands_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/commands"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("generate crud", func() {
+	var tempDir, configPath string
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+
+		return path
+	}
+
+	runCRUD := func(args ...string) error {
+		cmd := commands.NewGenerateCommand()
+		cmd.SetArgs(append([]string{"crud", "--config", configPath}, args...))
+		cmd.SilenceUsage = true
+
+		return cmd.Execute()
+	}
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+
+		writeFile("schema/001_init.sql", `
+CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL);
+CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL REFERENCES users (id), title TEXT NOT NULL);
+`)
+		configPath = writeFile("sqlc.yaml", `version: "2"
+sql:
+  - engine: sqlite
+    schema: schema
+    queries: db/queries
+    gen:
+      go:
+        package: db
+        out: db
+`)
+	})
+
+	It("should be registered under generate", func() {
+		cmd, _, err := commands.NewGenerateCommand().Find([]string{"crud"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd.Use).To(Equal("crud"))
+		Expect(cmd.Flags().Lookup("table").Shorthand).To(Equal("t"))
+		Expect(cmd.Flags().Lookup("preset").DefValue).To(BeEmpty())
+	})
+
+	It("should write queries for every table into the queries path", func() {
+		Expect(runCRUD()).To(Succeed())
+
+		content, err := os.ReadFile(filepath.Join(tempDir, "db/queries/posts.sql"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("-- name: GetPost :one"))
+		Expect(string(content)).To(ContainSubstring("WHERE id = ?"))
+		Expect(filepath.Join(tempDir, "db/queries/users.sql")).To(BeARegularFile())
+
+		lintCmd := commands.NewLintCommand()
+		lintCmd.SetArgs([]string{"--config", configPath})
+		lintCmd.SilenceUsage = true
+		Expect(lintCmd.Execute()).To(Succeed())
+	})
+
+	It("should only write the selected tables", func() {
+		Expect(runCRUD("--table", "users")).To(Succeed())
+
+		Expect(filepath.Join(tempDir, "db/queries/users.sql")).To(BeARegularFile())
+		Expect(filepath.Join(tempDir, "db/queries/posts.sql")).NotTo(BeAnExistingFile())
+	})
+
+	It("should list the available tables for an unknown table", func() {
+		err := runCRUD("--table", "comments")
+		Expect(err).To(MatchError(ContainSubstring("available: users, posts")))
+	})
+
+	It("should write keyset pagination queries", func() {
+		Expect(runCRUD("--pagination", "keyset", "--table", "posts")).To(Succeed())
+
+		content, err := os.ReadFile(filepath.Join(tempDir, "db/queries/posts.sql"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("-- name: ListPostsNextPage :many"))
+
+		Expect(runCRUD("--pagination", "cursor")).To(MatchError(ContainSubstring("unknown pagination")))
+	})
+
+	It("should follow the rules of sqlc.yaml unless --preset is given", func() {
+		configPath = writeFile("sqlc.yaml", `version: "2"
+sql:
+  - engine: sqlite
+    schema: schema
+    queries: db/queries
+    rules:
+      - no-select-star
+      - require-limit
+rules:
+  - name: no-select-star
+    rule: "!query.contains('SELECT *')"
+  - name: require-limit
+    rule: query.type == 'SELECT' && !query.hasLimitClause()
+`)
+
+		Expect(runCRUD("--table", "users")).To(Succeed())
+
+		content, err := os.ReadFile(filepath.Join(tempDir, "db/queries/users.sql"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("WHERE id = ?\nLIMIT 1;"))
+
+		Expect(runCRUD("--table", "users", "--preset", "development", "--force")).To(Succeed())
+
+		content, err = os.ReadFile(filepath.Join(tempDir, "db/queries/users.sql"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("SELECT *\nFROM users\nWHERE id = ?;"))
+	})
+
+	It("should not overwrite query files without --force", func() {
+		existing := writeFile("db/queries/users.sql", "-- hand written\n")
+
+		Expect(runCRUD()).To(MatchError(ContainSubstring("--force")))
+		Expect(filepath.Join(tempDir, "db/queries/posts.sql")).NotTo(BeAnExistingFile())
+
+		Expect(runCRUD("--force")).To(Succeed())
+		content, err := os.ReadFile(existing)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(HavePrefix("-- name: GetUser :one"))
+	})
+})
+
+var _ = Describe("generate --config", func() {
+	var tempDir string
+
+	runGenerate := func(args ...string) error {
+		cmd := commands.NewGenerateCommand()
+		cmd.SetArgs(args)
+		cmd.SilenceUsage = true
+
+		return cmd.Execute()
+	}
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+	})
+
+	It("should write engine-specific examples into the paths of every sql entry", func() {
+		configPath := filepath.Join(tempDir, "sqlc.yaml")
+		Expect(os.WriteFile(configPath, []byte(`version: "2"
+sql:
+  - engine: mysql
+    schema: mysql/schema
+    queries: mysql/query.sql
+    gen:
+      go:
+        package: mysqldb
+        out: mysqldb
+  - engine: sqlite
+    schema: sqlite/schema.sql
+    queries: sqlite/queries
+    gen:
+      go:
+        package: litedb
+        out: litedb
+`), 0o644)).To(Succeed())
+
+		Expect(runGenerate("--config", configPath)).To(Succeed())
+
+		mysqlSchema, err := os.ReadFile(filepath.Join(tempDir, "mysql/schema/001_users_table.sql"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(mysqlSchema)).To(ContainSubstring("MySQL"))
+
+		mysqlQueries, err := os.ReadFile(filepath.Join(tempDir, "mysql/query.sql"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(mysqlQueries)).To(ContainSubstring("WHERE id = ?"))
+
+		Expect(filepath.Join(tempDir, "sqlite/schema.sql")).To(BeARegularFile())
+		Expect(filepath.Join(tempDir, "sqlite/queries/users.sql")).To(BeARegularFile())
+		Expect(filepath.Join(tempDir, "schema")).NotTo(BeAnExistingFile())
+
+		Expect(runGenerate("--config", configPath)).To(MatchError(ContainSubstring("--force")))
+		Expect(runGenerate("--config", configPath, "--force")).To(Succeed())
+	})
+
+	It("should reject engines without templates", func() {
+		configPath := filepath.Join(tempDir, "sqlc.yaml")
+		Expect(os.WriteFile(configPath, []byte(`version: "2"
+sql:
+  - engine: oracle
+    schema: schema
+    queries: queries
+`), 0o644)).To(Succeed())
+
+		Expect(runGenerate("--config", configPath)).To(MatchError(ContainSubstring(`unsupported engine "oracle"`)))
+	})
+})
@@ -0,0 +1,200 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/domain"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/generators"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/lint"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/schema"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// GenerateCRUDOptions contains options for the generate crud command.
+type GenerateCRUDOptions struct {
+	ConfigPath string
+	Tables     []string
+	// Preset overrides the rules named in sqlc.yaml when set.
+	Preset     string
+	Pagination string
+	Force      bool
+}
+
+// crudTarget is the set of tables to generate queries for in one sql[] entry.
+type crudTarget struct {
+	queriesDir string
+	engine     string
+	rules      domain.TypeSafeSafetyRules
+	hasRules   bool
+	tables     []schema.Table
+}
+
+// newGenerateCRUDCommand creates the generate crud command.
+func newGenerateCRUDCommand() *cobra.Command {
+	opts := &GenerateCRUDOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "crud",
+		Short: "Generate CRUD queries from the schema",
+		Long: `Generate crud reads the schema files of every sql[] entry in sqlc.yaml and
+writes Get, List, Create, Update, Delete and Count queries for each table to
+<queries>/<table>.sql, with the placeholders and RETURNING support of the engine.
+
+The safety rules decide between SELECT * and explicit column lists
+(SelectStarPolicy) and where LIMIT clauses are added (LimitClauseRequirement),
+so the queries pass "lint" under the same rules. They are read from the rules
+of each sql[] entry in sqlc.yaml, or from --preset when it is given or the
+entry names no known rules. List queries are paginated with LIMIT and OFFSET
+whenever the rules limit rows. With --pagination keyset, tables with a
+primary key or an index such as (created_at, id) get first, next and previous
+page queries instead, which compare the ordering key with the last row seen
+rather than skipping rows.
+
+Rules requiring WHERE on every SELECT keep only the next and previous page
+queries, and no List for tables without such a key. Count is left out when
+the rules require WHERE or LIMIT on it. A comment at the top of each file
+names the queries that were left out.`,
+		Example: `  sqlc-wizard generate crud
+  sqlc-wizard generate crud --table users --table posts
+  sqlc-wizard generate crud --preset production --force
+  sqlc-wizard generate crud --pagination keyset`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGenerateCRUD(opts)
+		},
+	}
+
+	cmd.Flags().
+		StringVarP(&opts.ConfigPath, "config", "c", "sqlc.yaml", "Path to sqlc.yaml configuration file")
+	cmd.Flags().
+		StringSliceVarP(&opts.Tables, "table", "t", nil, "Tables to generate queries for (default: all tables)")
+	cmd.Flags().StringVar(&opts.Preset, "preset", "",
+		"Safety rule preset: default, development or production (default: the rules of sqlc.yaml, else default)")
+	cmd.Flags().StringVar(&opts.Pagination, "pagination", string(generators.PaginationOffset),
+		"List pagination: offset or keyset")
+	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Overwrite existing query files")
+
+	return cmd
+}
+
+func runGenerateCRUD(opts *GenerateCRUDOptions) error {
+	presetRules, err := lint.RulesForPreset(opts.Preset)
+	if err != nil {
+		return err
+	}
+
+	pagination := generators.Pagination(opts.Pagination)
+	if !pagination.IsValid() {
+		return apperrors.Newf(apperrors.ErrorCodeInvalidValue,
+			"unknown pagination %q (must be one of: offset, keyset)", opts.Pagination)
+	}
+
+	cfg, err := config.ParseFile(opts.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	targets, err := crudTargets(cfg, filepath.Dir(opts.ConfigPath), opts.Tables)
+	if err != nil {
+		return err
+	}
+
+	var written []string
+
+	for _, target := range targets {
+		rules := target.rules
+		if opts.Preset != "" || !target.hasRules {
+			rules = presetRules
+		}
+
+		paths, err := generators.NewGenerator(target.queriesDir).GenerateCRUDQueries(target.tables,
+			generators.CRUDOptions{Engine: target.engine, Rules: rules, Pagination: pagination}, opts.Force)
+		if err != nil {
+			return err
+		}
+
+		written = append(written, paths...)
+	}
+
+	PrintSuccessf("Generated CRUD queries for %d table(s)", len(written))
+
+	for _, path := range written {
+		fmt.Printf("   - %s\n", path)
+	}
+
+	return nil
+}
+
+// crudTargets parses the schema of every sql[] entry and selects the requested tables,
+// or all tables when none are named. A name found in no entry is an error.
+func crudTargets(cfg *config.SqlcConfig, baseDir string, names []string) ([]crudTarget, error) {
+	var (
+		targets   []crudTarget
+		available []string
+		found     = map[string]bool{}
+	)
+
+	for i := range cfg.SQL {
+		sql := &cfg.SQL[i]
+
+		parsed, err := schema.ParseConfig(sql, baseDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse schema of sql[%d]: %w", i, err)
+		}
+
+		target := crudTarget{engine: sql.Engine}
+		target.rules, target.hasRules = lint.RulesForConfig(cfg, sql)
+
+		for _, table := range parsed.Tables {
+			available = append(available, table.Name)
+
+			if len(names) == 0 || slices.Contains(names, table.Name) {
+				target.tables = append(target.tables, table)
+				found[table.Name] = true
+			}
+		}
+
+		if len(target.tables) == 0 {
+			continue
+		}
+
+		target.queriesDir, err = crudQueriesDir(sql, baseDir)
+		if err != nil {
+			return nil, err
+		}
+
+		targets = append(targets, target)
+	}
+
+	for _, name := range names {
+		if !found[name] {
+			return nil, apperrors.Newf(apperrors.ErrorCodeInvalidValue,
+				"table %q not found in the schema (available: %s)", name, strings.Join(available, ", "))
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil, apperrors.NewError(apperrors.ErrorCodeInvalidValue, "the schema has no tables to generate queries for")
+	}
+
+	return targets, nil
+}
+
+// crudQueriesDir returns the first queries path of a sql[] entry, which must be a directory.
+func crudQueriesDir(sql *config.SQLConfig, baseDir string) (string, error) {
+	path := sql.Queries.First()
+	if path == "" {
+		return "", apperrors.Newf(apperrors.ErrorCodeConfigValidation, "sql entry %q has no queries path", sql.Name)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".sql") {
+		return "", apperrors.Newf(apperrors.ErrorCodeConfigValidation,
+			"queries path %s is a file; generate crud writes one file per table into a directory", path)
+	}
+
+	return config.ResolvePath(baseDir, path), nil
+}
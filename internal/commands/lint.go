@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"charm.land/lipgloss/v2"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/diagnostics"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/lint"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// LintOptions contains options for the lint command.
+type LintOptions struct {
+	ConfigPath string
+	Preset     string
+	Files      []string
+	Format     diagnostics.Format
+}
+
+// NewLintCommand creates the lint command.
+func NewLintCommand() *cobra.Command {
+	opts := &LintOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "lint [files...]",
+		Short: "Check query files against safety rules",
+		Long: `Lint checks sqlc query files against the type-safe safety rules locally,
+without sqlc or a database.
+
+By default every file under the "queries" paths of sqlc.yaml is checked.
+Each "-- name:" block is reported with file:line diagnostics for:
+  • SELECT * and column explicitness
+  • Missing WHERE clauses
+  • Missing or oversized LIMIT clauses
+  • Destructive operations (DROP TABLE, TRUNCATE)
+
+Example:
+  sqlc-wizard lint
+  sqlc-wizard lint --preset production
+  sqlc-wizard lint internal/db/queries/users.sql`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := outputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			opts.Files = args
+			opts.Format = format
+
+			return runLint(opts)
+		},
+	}
+
+	cmd.Flags().
+		StringVarP(&opts.ConfigPath, "config", "c", "sqlc.yaml", "Path to sqlc.yaml configuration file")
+	cmd.Flags().StringVar(&opts.Preset, "preset", lint.PresetDefault,
+		"Safety rule preset: default, development or production")
+
+	return cmd
+}
+
+func runLint(opts *LintOptions) error {
+	rules, err := lint.RulesForPreset(opts.Preset)
+	if err != nil {
+		return err
+	}
+
+	linter := lint.NewLinter(rules)
+
+	var result *lint.Result
+
+	if len(opts.Files) > 0 {
+		result, err = linter.LintFiles(opts.Files)
+	} else {
+		var cfg *config.SqlcConfig
+
+		cfg, err = config.ParseFile(opts.ConfigPath)
+		if err != nil {
+			return fmt.Errorf("failed to parse config: %w", err)
+		}
+
+		result, err = linter.LintConfig(cfg, filepath.Dir(opts.ConfigPath))
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to lint queries: %w", err)
+	}
+
+	if opts.Format.IsText() {
+		displayLintResults(result)
+	} else {
+		report := diagnostics.NewReport("lint")
+		report.Add(diagnostics.FromLint(result)...)
+
+		if err := writeReport(opts.Format, report); err != nil {
+			return err
+		}
+	}
+
+	if result.HasErrors() {
+		return fmt.Errorf("lint failed with %d error(s)", result.ErrorCount())
+	}
+
+	return nil
+}
+
+func displayLintResults(result *lint.Result) {
+	errorStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("9"))
+
+	warningStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("11"))
+
+	for _, diagnostic := range result.Diagnostics {
+		style := warningStyle
+		if diagnostic.Severity == apperrors.ErrorSeverityError {
+			style = errorStyle
+		}
+
+		fmt.Println(style.Render(diagnostic.String()))
+	}
+
+	if len(result.Diagnostics) > 0 {
+		fmt.Println()
+	}
+
+	summary := fmt.Sprintf("Checked %d quer(ies) in %d file(s): %d error(s), %d warning(s)",
+		result.QueriesChecked, result.FilesChecked, result.ErrorCount(), result.WarningCount())
+
+	if result.HasErrors() {
+		PrintError(summary)
+
+		return
+	}
+
+	PrintSuccess(summary)
+}
@@ -0,0 +1,73 @@
+package commands_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/commands"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewLintCommand", func() {
+	var tempDir string
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+
+		return path
+	}
+
+	runLint := func(args ...string) error {
+		cmd := commands.NewLintCommand()
+		cmd.SetArgs(args)
+		cmd.SilenceUsage = true
+
+		return cmd.Execute()
+	}
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+	})
+
+	It("should have the expected flags", func() {
+		cmd := commands.NewLintCommand()
+
+		Expect(cmd.Use).To(Equal("lint [files...]"))
+		Expect(cmd.Flags().Lookup("config").DefValue).To(Equal("sqlc.yaml"))
+		Expect(cmd.Flags().Lookup("preset").DefValue).To(Equal("default"))
+	})
+
+	It("should lint the queries referenced by sqlc.yaml", func() {
+		writeFile("queries/users.sql", "-- name: ListUsers :many\nSELECT * FROM users;\n")
+		configPath := writeFile("sqlc.yaml", `version: "2"
+sql:
+  - engine: postgresql
+    queries: queries
+    schema: schema.sql
+    gen:
+      go:
+        package: db
+        out: db
+`)
+
+		err := runLint("--config", configPath)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("1 error(s)"))
+	})
+
+	It("should pass clean files given as arguments", func() {
+		path := writeFile("ok.sql", "-- name: GetUser :one\nSELECT id FROM users WHERE id = $1;\n")
+
+		Expect(runLint(path)).To(Succeed())
+	})
+
+	It("should apply the selected preset", func() {
+		path := writeFile("dev.sql", "-- name: ListUsers :many\nSELECT * FROM users;\n")
+
+		Expect(runLint("--preset", "development", path)).To(Succeed())
+		Expect(runLint("--preset", "unknown", path)).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,324 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/adapters"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// MigrationTarget identifies the migrations and the database a migrate subcommand runs against.
+// Source and Database default to the schema path and database.uri of the first sql[] entry in sqlc.yaml.
+type MigrationTarget struct {
+	ConfigPath string
+	Source     string
+	Database   string
+}
+
+// addMigrationTargetFlags registers the flags shared by the subcommands that touch a database.
+func addMigrationTargetFlags(cmd *cobra.Command, target *MigrationTarget) {
+	cmd.Flags().
+		StringVarP(&target.ConfigPath, "config", "c", "sqlc.yaml", "Path to sqlc.yaml configuration file")
+	cmd.Flags().
+		StringVarP(&target.Source, "source", "s", "", "Migration source path or URL (default: schema path from sqlc.yaml)")
+	cmd.Flags().
+		StringVarP(&target.Database, "database", "d", "", "Database connection URL (default: database.uri from sqlc.yaml)")
+}
+
+// Resolve fills Source and Database from sqlc.yaml when they were not given and
+// turns a plain source directory into a file:// URL.
+func (t *MigrationTarget) Resolve() error {
+	return t.resolve(true)
+}
+
+// ResolveSource is Resolve for commands that only read the migration files.
+func (t *MigrationTarget) ResolveSource() error {
+	return t.resolve(false)
+}
+
+func (t *MigrationTarget) resolve(needDatabase bool) error {
+	if t.Source == "" || (needDatabase && t.Database == "") {
+		cfg, err := config.ParseFile(t.ConfigPath)
+		if err != nil {
+			return &MigrationError{
+				Code:    "MISSING_CONFIG",
+				Message: fmt.Sprintf("Please specify --source and --database or provide a readable %s: %v", t.ConfigPath, err),
+			}
+		}
+
+		if len(cfg.SQL) == 0 {
+			return &MigrationError{
+				Code:    "MISSING_CONFIG",
+				Message: fmt.Sprintf("%s has no sql entries", t.ConfigPath),
+			}
+		}
+
+		sql := cfg.SQL[0]
+
+		if t.Source == "" && !sql.Schema.IsEmpty() {
+			t.Source = config.ResolvePath(filepath.Dir(t.ConfigPath), sql.Schema.First())
+		}
+
+		if t.Database == "" && sql.Database != nil {
+			t.Database = migrationDatabaseURL(sql.Engine, os.ExpandEnv(sql.Database.URI))
+		}
+	}
+
+	if t.Source == "" {
+		return &MigrationError{
+			Code:    "MISSING_SOURCE",
+			Message: "Please specify migration source path",
+		}
+	}
+
+	if needDatabase && t.Database == "" {
+		return &MigrationError{
+			Code:    "MISSING_DATABASE",
+			Message: "Please specify database URL (--database or database.uri in sqlc.yaml)",
+		}
+	}
+
+	if !strings.Contains(t.Source, "://") {
+		source, err := migrationSourceURL(t.Source)
+		if err != nil {
+			return err
+		}
+
+		t.Source = source
+	}
+
+	return nil
+}
+
+// migrationDatabaseURL turns a sqlc database.uri into a golang-migrate URL.
+// sqlc accepts plain file paths and file: URIs for SQLite and go-sql-driver DSNs
+// (user:pass@tcp(host:3306)/db) for MySQL; golang-migrate needs sqlite:// and mysql://.
+func migrationDatabaseURL(engine, uri string) string {
+	if uri == "" || strings.Contains(uri, "://") {
+		return uri
+	}
+
+	switch engine {
+	case config.EngineSQLite:
+		return "sqlite://" + strings.TrimPrefix(uri, "file:")
+	case config.EngineMySQL:
+		return "mysql://" + uri
+	default:
+		return uri
+	}
+}
+
+// migrationSourceURL converts a migrations directory into the file:// URL golang-migrate expects.
+func migrationSourceURL(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve migration source %s: %w", dir, err)
+	}
+
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", &MigrationError{
+			Code:    "MISSING_SOURCE",
+			Message: fmt.Sprintf("Migration source %s is not accessible: %v", abs, err),
+		}
+	}
+
+	if !info.IsDir() {
+		return "", &MigrationError{
+			Code:    "INVALID_SOURCE",
+			Message: fmt.Sprintf("Migration source %s is a file; point --source at the migrations directory", abs),
+		}
+	}
+
+	return "file://" + filepath.ToSlash(abs), nil
+}
+
+// newMigrateUpCommand creates the migrate up command.
+func newMigrateUpCommand() *cobra.Command {
+	target := &MigrationTarget{}
+
+	cmd := &cobra.Command{
+		Use:   "up [N]",
+		Short: "Apply pending migrations",
+		Long:  "Apply all pending migrations, or only the next N when a count is given.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			steps, err := stepsArg(args, 0)
+			if err != nil {
+				return err
+			}
+
+			return runMigrationAction(target, "⬆️  Applying migrations", func(ctx context.Context, m adapters.MigrationAdapter) error {
+				if steps == 0 {
+					return m.Migrate(ctx, target.Source, target.Database)
+				}
+
+				return m.MigrateSteps(ctx, target.Source, target.Database, steps)
+			})
+		},
+	}
+
+	addMigrationTargetFlags(cmd, target)
+
+	return cmd
+}
+
+// newMigrateDownCommand creates the migrate down command.
+func newMigrateDownCommand() *cobra.Command {
+	target := &MigrationTarget{}
+
+	cmd := &cobra.Command{
+		Use:   "down [N]",
+		Short: "Roll back applied migrations",
+		Long:  "Roll back the last applied migration, or the last N when a count is given.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			steps, err := stepsArg(args, 1)
+			if err != nil {
+				return err
+			}
+
+			return runMigrationAction(target, "⬇️  Rolling back migrations", func(ctx context.Context, m adapters.MigrationAdapter) error {
+				return m.Rollback(ctx, target.Source, target.Database, steps)
+			})
+		},
+	}
+
+	addMigrationTargetFlags(cmd, target)
+
+	return cmd
+}
+
+// newMigrateGotoCommand creates the migrate goto command.
+func newMigrateGotoCommand() *cobra.Command {
+	target := &MigrationTarget{}
+
+	cmd := &cobra.Command{
+		Use:   "goto VERSION",
+		Short: "Migrate up or down to a version",
+		Long:  "Apply or roll back migrations until the database is at the given version.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := strconv.ParseUint(args[0], 10, 0)
+			if err != nil {
+				return &MigrationError{
+					Code:    "INVALID_VERSION",
+					Message: fmt.Sprintf("Invalid version %q: must be a non-negative number", args[0]),
+				}
+			}
+
+			return runMigrationAction(target, "🎯 Migrating to version "+args[0], func(ctx context.Context, m adapters.MigrationAdapter) error {
+				return m.Goto(ctx, target.Source, target.Database, uint(version))
+			})
+		},
+	}
+
+	addMigrationTargetFlags(cmd, target)
+
+	return cmd
+}
+
+// newMigrateForceCommand creates the migrate force command.
+func newMigrateForceCommand() *cobra.Command {
+	target := &MigrationTarget{}
+
+	cmd := &cobra.Command{
+		Use:   "force VERSION",
+		Short: "Set the migration version and clear the dirty state",
+		Long: `Record VERSION as the current migration version without running any migration.
+Use it after fixing a failed migration by hand to clear the dirty state.
+A version of -1 marks the database as having no migrations applied; pass it
+after -- so it is not read as a flag: migrate force -- -1`,
+		Example: `  sqlc-wizard migrate force 3
+  sqlc-wizard migrate force --config sqlc.yaml -- -1`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := strconv.Atoi(args[0])
+			if err != nil {
+				return &MigrationError{
+					Code:    "INVALID_VERSION",
+					Message: fmt.Sprintf("Invalid version %q: must be a number", args[0]),
+				}
+			}
+
+			return runMigrationAction(target, "🔧 Forcing version "+args[0], func(ctx context.Context, m adapters.MigrationAdapter) error {
+				return m.Force(ctx, target.Source, target.Database, version)
+			})
+		},
+	}
+
+	addMigrationTargetFlags(cmd, target)
+
+	return cmd
+}
+
+// newMigrateRedoCommand creates the migrate redo command.
+func newMigrateRedoCommand() *cobra.Command {
+	target := &MigrationTarget{}
+
+	cmd := &cobra.Command{
+		Use:   "redo",
+		Short: "Roll back and reapply the last migration",
+		Long:  "Roll back the last applied migration and apply it again, useful while iterating on a migration.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMigrationAction(target, "🔁 Redoing last migration", func(ctx context.Context, m adapters.MigrationAdapter) error {
+				return m.Redo(ctx, target.Source, target.Database)
+			})
+		},
+	}
+
+	addMigrationTargetFlags(cmd, target)
+
+	return cmd
+}
+
+// stepsArg parses the optional step count argument of up and down.
+func stepsArg(args []string, fallback int) (int, error) {
+	if len(args) == 0 {
+		return fallback, nil
+	}
+
+	steps, err := strconv.Atoi(args[0])
+	if err != nil || steps <= 0 {
+		return 0, &MigrationError{
+			Code:    "INVALID_STEPS",
+			Message: fmt.Sprintf("Invalid step count %q: must be a positive number", args[0]),
+		}
+	}
+
+	return steps, nil
+}
+
+// runMigrationAction resolves the target and runs a migration operation against it.
+func runMigrationAction(
+	target *MigrationTarget,
+	title string,
+	action func(ctx context.Context, m adapters.MigrationAdapter) error,
+) error {
+	err := target.Resolve()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(title)
+	fmt.Printf("  Source: %s\n", target.Source)
+
+	err = action(context.Background(), adapters.NewRealMigrationAdapter())
+	if err != nil {
+		return &MigrationError{
+			Code:    "MIGRATION_FAILED",
+			Message: fmt.Sprintf("Migration failed: %v", err),
+		}
+	}
+
+	fmt.Println("✅ Done")
+
+	return nil
+}
@@ -0,0 +1,273 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/adapters"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/migration"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/schema"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// Schema states migrate diff can compare the desired schema with.
+const (
+	DiffFromMigrations = "migrations"
+	DiffFromDatabase   = "database"
+)
+
+// migrationsTable is the version table golang-migrate keeps in the database.
+const migrationsTable = "schema_migrations"
+
+// MigrateDiffOptions contains options for the migrate diff command.
+type MigrateDiffOptions struct {
+	ConfigPath string
+	Name       string
+	Schema     []string
+	Source     string
+	From       string
+	Database   string
+	DryRun     bool
+}
+
+// newMigrateDiffCommand creates the migrate diff command.
+func newMigrateDiffCommand() *cobra.Command {
+	opts := &MigrateDiffOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Write a migration from the changes to the schema files",
+		Long: `Diff compares the schema files of sqlc.yaml (the desired schema) with the
+schema the migrations directory builds up, or with the live database, and
+writes a <version>_<name>.up.sql/.down.sql pair with the DDL between them.
+The version continues the numbering of the existing migrations: the next
+zero-padded number when they are sequential (000001_...), a Unix timestamp
+otherwise.
+
+The migrations directory defaults to "migrations" next to the first schema
+path, e.g. db/migrations for db/schema. It must not be one of the schema
+paths; when sqlc.yaml points at the migrations themselves, pass the desired
+schema with --schema.
+
+With --from database the schema is read from database.uri (or --database)
+instead, ignoring golang-migrate's schema_migrations table.
+
+Example:
+  sqlc-wizard migrate diff --name add_posts
+  sqlc-wizard migrate diff --name add_posts --dry-run
+  sqlc-wizard migrate diff --name sync --from database
+  sqlc-wizard migrate diff --name add_posts --schema schema.sql --source db/migrations`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMigrateDiff(opts)
+		},
+	}
+
+	cmd.Flags().
+		StringVarP(&opts.ConfigPath, "config", "c", "sqlc.yaml", "Path to sqlc.yaml configuration file")
+	cmd.Flags().StringVarP(&opts.Name, "name", "n", "", "Migration name")
+	cmd.Flags().StringSliceVar(&opts.Schema, "schema", nil,
+		"Desired schema files or directories (default: schema paths from sqlc.yaml)")
+	cmd.Flags().StringVarP(&opts.Source, "source", "s", "",
+		"Migrations directory (default: migrations next to the schema path)")
+	cmd.Flags().StringVar(&opts.From, "from", DiffFromMigrations,
+		"Current schema to diff against: migrations or database")
+	cmd.Flags().StringVarP(&opts.Database, "database", "d", "",
+		"Database URI for --from database (default: database.uri from sqlc.yaml)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Print the migration without writing it")
+
+	return cmd
+}
+
+// runMigrateDiff diffs the desired schema with the current one and writes the migration.
+func runMigrateDiff(opts *MigrateDiffOptions) error {
+	if opts.Name == "" {
+		return &MigrationError{
+			Code:    "MISSING_NAME",
+			Message: "Please specify migration name",
+		}
+	}
+
+	if opts.From != DiffFromMigrations && opts.From != DiffFromDatabase {
+		return &MigrationError{
+			Code:    "INVALID_FROM",
+			Message: fmt.Sprintf("Unknown --from %q (must be one of: migrations, database)", opts.From),
+		}
+	}
+
+	cfg, err := config.ParseFile(opts.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	if len(cfg.SQL) == 0 {
+		return &MigrationError{
+			Code:    "MISSING_CONFIG",
+			Message: fmt.Sprintf("%s has no sql entries", opts.ConfigPath),
+		}
+	}
+
+	sql := &cfg.SQL[0]
+	baseDir := filepath.Dir(opts.ConfigPath)
+
+	schemaPaths := config.NewPathOrPaths(opts.Schema)
+	if len(opts.Schema) == 0 {
+		schemaPaths = config.NewPathOrPaths(resolvePaths(baseDir, sql.Schema.Strings()))
+	}
+
+	if schemaPaths.IsEmpty() {
+		return &MigrationError{
+			Code:    "MISSING_SCHEMA",
+			Message: "Please specify the desired schema (--schema or schema in sqlc.yaml)",
+		}
+	}
+
+	dir := opts.Source
+	if dir == "" {
+		dir = filepath.Join(filepath.Dir(filepath.Clean(schemaPaths.First())), "migrations")
+	}
+
+	files, err := schemaPaths.SQLFiles("")
+	if err != nil {
+		return err
+	}
+
+	if slices.ContainsFunc(files, func(file string) bool { return sameDir(filepath.Dir(file), dir) }) {
+		return &MigrationError{
+			Code: "INVALID_SOURCE",
+			Message: fmt.Sprintf("The migrations directory %s is also a schema path; "+
+				"pass the desired schema with --schema or the migrations directory with --source", dir),
+		}
+	}
+
+	desired, err := schema.ParseFiles(sql.Engine, sql.Name, files)
+	if err != nil {
+		return fmt.Errorf("failed to parse the desired schema: %w", err)
+	}
+
+	ctx := context.Background()
+
+	current, err := currentSchema(ctx, opts, sql, dir)
+	if err != nil {
+		return err
+	}
+
+	script, err := schema.GenerateMigration(current, desired, sql.Engine)
+	if err != nil {
+		return fmt.Errorf("failed to diff schemas: %w", err)
+	}
+
+	if script.IsEmpty() {
+		PrintSuccessf("No schema changes: the %s match the schema files", opts.From)
+
+		return nil
+	}
+
+	if opts.DryRun {
+		fmt.Printf("-- %s.up.sql\n%s\n-- %s.down.sql\n%s", opts.Name, script.UpSQL(), opts.Name, script.DownSQL())
+
+		return nil
+	}
+
+	upFile, err := adapters.NewRealMigrationAdapter().GenerateMigration(ctx, opts.Name, dir, current, desired)
+	if err != nil {
+		return &MigrationError{
+			Code:    "CREATION_FAILED",
+			Message: fmt.Sprintf("Migration creation failed: %v", err),
+		}
+	}
+
+	PrintSuccessf("Migration created: %s", upFile)
+	fmt.Println("   Review it and run 'sqlc-wizard migrate lint' before applying it")
+
+	return nil
+}
+
+// currentSchema returns the schema the migrations in dir build up, or the schema of the
+// database with --from database. A missing migrations directory is an empty schema.
+func currentSchema(ctx context.Context, opts *MigrateDiffOptions, sql *config.SQLConfig, dir string) (*schema.Schema, error) {
+	if opts.From == DiffFromDatabase {
+		return databaseSchema(ctx, opts, sql)
+	}
+
+	migrations, err := migration.ScanDirectory(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return schema.ParseFiles(sql.Engine, sql.Name, nil)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+
+	for _, mig := range migrations {
+		if mig.UpFile != "" {
+			files = append(files, mig.UpFile)
+		}
+	}
+
+	current, err := schema.ParseFiles(sql.Engine, sql.Name, files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay the migrations in %s: %w", dir, err)
+	}
+
+	return current, nil
+}
+
+// databaseSchema reads the schema of the live database without golang-migrate's version table.
+func databaseSchema(ctx context.Context, opts *MigrateDiffOptions, sql *config.SQLConfig) (*schema.Schema, error) {
+	uri := opts.Database
+	if uri == "" && sql.Database != nil {
+		uri = sql.Database.URI
+	}
+
+	if uri == "" {
+		return nil, &MigrationError{
+			Code:    "MISSING_DATABASE",
+			Message: "Please specify database URL (--database or database.uri in sqlc.yaml)",
+		}
+	}
+
+	current, err := adapters.NewRealDatabaseAdapter().GetSchema(ctx, &config.DatabaseConfig{URI: uri})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the database schema: %w", err)
+	}
+
+	if current.Metadata.DatabaseEngine != sql.Engine {
+		return nil, &MigrationError{
+			Code: "INVALID_DATABASE",
+			Message: fmt.Sprintf("Database %s is not a %s database",
+				adapters.RedactDatabaseURL(os.ExpandEnv(uri)), sql.Engine),
+		}
+	}
+
+	current.Tables = slices.DeleteFunc(current.Tables, func(table schema.Table) bool {
+		return table.Name == migrationsTable
+	})
+
+	return current, nil
+}
+
+// resolvePaths resolves each path relative to baseDir.
+func resolvePaths(baseDir string, paths []string) []string {
+	resolved := make([]string, len(paths))
+	for i, path := range paths {
+		resolved[i] = config.ResolvePath(baseDir, path)
+	}
+
+	return resolved
+}
+
+// sameDir reports whether two paths name the same directory.
+func sameDir(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+
+	return errA == nil && errB == nil && absA == absB
+}
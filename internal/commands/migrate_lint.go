@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/diagnostics"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/lint"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/migration"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// MigrateLintOptions contains options for the migrate lint command.
+type MigrateLintOptions struct {
+	Target MigrationTarget
+	Preset string
+	Engine string
+	Files  []string
+	Format diagnostics.Format
+}
+
+// newMigrateLintCommand creates the migrate lint command.
+func newMigrateLintCommand() *cobra.Command {
+	opts := &MigrateLintOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "lint [files...]",
+		Short: "Check migrations for destructive and risky DDL",
+		Long: `Lint checks migration files against the destructive operation policy of the
+selected preset and for DDL that locks tables or breaks running code:
+  • DROP TABLE, DROP COLUMN and TRUNCATE (policy: allowed, with_confirmation, forbidden)
+  • column type changes
+  • NOT NULL columns added without a DEFAULT, and SET NOT NULL
+  • CREATE INDEX without CONCURRENTLY on existing PostgreSQL tables
+  • table and column renames
+
+By default every .up.sql file in the migrations directory is checked.
+Acknowledge an operation in a migration file with a pragma comment:
+  -- sqlc-wizard:allow drop_table, rename
+
+Example:
+  sqlc-wizard migrate lint
+  sqlc-wizard migrate lint --preset production db/migrations/0005_drop_legacy.up.sql`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := outputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			opts.Files = args
+			opts.Format = format
+
+			return runMigrateLint(opts)
+		},
+	}
+
+	cmd.Flags().
+		StringVarP(&opts.Target.ConfigPath, "config", "c", "sqlc.yaml", "Path to sqlc.yaml configuration file")
+	cmd.Flags().
+		StringVarP(&opts.Target.Source, "source", "s", "", "Migrations directory (default: schema path from sqlc.yaml)")
+	cmd.Flags().StringVar(&opts.Preset, "preset", lint.PresetDefault,
+		"Safety rule preset: default, development or production")
+	cmd.Flags().StringVar(&opts.Engine, "engine", "",
+		"Database engine for dialect-specific checks (default: engine from sqlc.yaml)")
+
+	return cmd
+}
+
+// runMigrateLint lints the migration files and prints the findings.
+func runMigrateLint(opts *MigrateLintOptions) error {
+	rules, err := lint.RulesForPreset(opts.Preset)
+	if err != nil {
+		return err
+	}
+
+	engine := opts.Engine
+	if engine == "" {
+		if cfg, err := config.ParseFile(opts.Target.ConfigPath); err == nil && len(cfg.SQL) > 0 {
+			engine = cfg.SQL[0].Engine
+		}
+	}
+
+	files := opts.Files
+	if len(files) == 0 {
+		files, err = upMigrationFiles(&opts.Target)
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := lint.NewLinter(rules).LintMigrationFiles(files, engine)
+	if err != nil {
+		return fmt.Errorf("failed to lint migrations: %w", err)
+	}
+
+	if opts.Format.IsText() {
+		displayLintResults(result)
+	} else {
+		report := diagnostics.NewReport("migrate lint")
+		report.Add(diagnostics.FromLint(result)...)
+
+		if err := writeReport(opts.Format, report); err != nil {
+			return err
+		}
+	}
+
+	if result.HasErrors() {
+		return fmt.Errorf("migration lint failed with %d error(s)", result.ErrorCount())
+	}
+
+	return nil
+}
+
+// upMigrationFiles returns the up files of the migrations directory in version order.
+func upMigrationFiles(target *MigrationTarget) ([]string, error) {
+	err := target.ResolveSource()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, ok := strings.CutPrefix(target.Source, "file://")
+	if !ok {
+		return nil, &MigrationError{
+			Code:    "INVALID_SOURCE",
+			Message: "Only file:// migration sources can be linted, got " + target.Source,
+		}
+	}
+
+	migrations, err := migration.ScanDirectory(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+
+	for _, mig := range migrations {
+		if mig.UpFile != "" {
+			files = append(files, mig.UpFile)
+		}
+	}
+
+	return files, nil
+}
@@ -32,7 +32,14 @@ func runMigrationList() {
 	fmt.Println("  sqlite -> mysql")
 	fmt.Println("\n📝 Usage Examples:")
 	fmt.Println("  sqlc-wizard migrate -s old.sqlc.yaml -d new.sqlc.yaml -b postgresql")
-	fmt.Println("  sqlc-wizard migrate -s config.yaml -d config.yaml -v 2.0")
+	fmt.Println("  sqlc-wizard migrate -s v1.sqlc.yaml -d sqlc.yaml -v 2 --dry-run")
 	fmt.Println("  sqlc-wizard migrate status -s ./migrations -d sqlite://test.db")
 	fmt.Println("  sqlc-wizard migrate create -n add_users_table")
+	fmt.Println("  sqlc-wizard migrate up")
+	fmt.Println("  sqlc-wizard migrate down 2")
+	fmt.Println("  sqlc-wizard migrate goto 20240101120000")
+	fmt.Println("  sqlc-wizard migrate force 20240101120000")
+	fmt.Println("  sqlc-wizard migrate redo")
+	fmt.Println("  sqlc-wizard migrate validate")
+	fmt.Println("  sqlc-wizard migrate lint --preset production")
 }
@@ -1,7 +1,17 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+
+	"charm.land/log/v2"
+	"github.com/LarsArtmann/SQLC-Wizzard/generated"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/adapters"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/utils"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
 
 	"github.com/spf13/cobra"
 )
@@ -13,6 +23,7 @@ func NewMigrateCommand() *cobra.Command {
 		destination string
 		database    string
 		sqlcVersion string
+		report      string
 		force       bool
 		dryRun      bool
 	)
@@ -22,39 +33,63 @@ func NewMigrateCommand() *cobra.Command {
 		Short: "Migrate existing SQLC configurations",
 		Long: `Migrate existing SQLC configurations from one version to another,
 or from one database type to another. This tool helps upgrade your
-existing SQLC projects to latest version.`,
-		Run: func(cmd *cobra.Command, args []string) {
+existing SQLC projects to latest version.
+
+The source sqlc.yaml is read, upgraded from version 1 to version 2 and, with
+--database, converted to another engine: engine, sql_package, build tags,
+db_type overrides and database.uri are adjusted. The destination defaults to
+the source; an existing file is only overwritten with --force. Use --dry-run
+to preview the changes as a diff.
+
+Changing the engine also translates the schema and query files of each sql[]
+entry in place: placeholders, auto-increment columns, column types, RETURNING,
+NOW() and boolean literals. Constructs that need a manual change are listed,
+and written to --report when given.
+
+The up, down, goto, force and redo subcommands apply golang-migrate
+migrations from the sqlc.yaml schema directory to database.uri; diff writes
+a new migration from the changes to the schema files.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if source == "" && destination == "" {
+				return cmd.Help()
+			}
+
 			migrationConfig := &MigrationConfig{
 				Source:      source,
 				Destination: destination,
 				Database:    database,
 				SQLCVersion: sqlcVersion,
+				Report:      report,
 				Force:       force,
 				DryRun:      dryRun,
 			}
 
-			err := runMigration(migrationConfig)
-			if err != nil {
-				fmt.Printf("❌ Migration failed: %v\n", err)
-
-				return
-			}
+			return runMigration(migrationConfig)
 		},
 	}
 
 	// Add flags
 	cmd.Flags().StringVarP(&source, "source", "s", "", "Source configuration file")
-	cmd.Flags().StringVarP(&destination, "destination", "d", "", "Destination configuration file")
+	cmd.Flags().StringVarP(&destination, "destination", "d", "", "Destination configuration file (default: source)")
 	cmd.Flags().
 		StringVarP(&database, "database", "b", "", "Target database type (mysql, postgresql, sqlite)")
-	cmd.Flags().StringVarP(&sqlcVersion, "version", "v", "", "Target SQLC version")
+	cmd.Flags().StringVarP(&sqlcVersion, "version", "v", "", "Target SQLC config version (2)")
+	cmd.Flags().StringVar(&report, "report", "", "Write the constructs that need a manual change to this file")
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force overwrite existing files")
-	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without making changes")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the changes as a diff without writing them")
 
 	// Add subcommands
 	cmd.AddCommand(newMigrateStatusCommand())
 	cmd.AddCommand(newMigrateDBCreateCommand())
 	cmd.AddCommand(newMigrateListCommand())
+	cmd.AddCommand(newMigrateUpCommand())
+	cmd.AddCommand(newMigrateDownCommand())
+	cmd.AddCommand(newMigrateGotoCommand())
+	cmd.AddCommand(newMigrateForceCommand())
+	cmd.AddCommand(newMigrateRedoCommand())
+	cmd.AddCommand(newMigrateValidateCommand())
+	cmd.AddCommand(newMigrateLintCommand())
+	cmd.AddCommand(newMigrateDiffCommand())
 
 	return cmd
 }
@@ -65,69 +100,174 @@ type MigrationConfig struct {
 	Destination string
 	Database    string
 	SQLCVersion string
+	Report      string
 	Force       bool
 	DryRun      bool
 }
 
-// runMigration executes the migration process.
-func runMigration(config *MigrationConfig) error {
-	if config.DryRun {
+// runMigration converts the source configuration and writes it to the destination.
+func runMigration(cfg *MigrationConfig) error {
+	if cfg.Source == "" {
+		return &MigrationError{
+			Code:    "MISSING_SOURCE",
+			Message: "Please specify source configuration file",
+		}
+	}
+
+	destination := cfg.Destination
+	if destination == "" {
+		destination = cfg.Source
+	}
+
+	targetDatabase := generated.DatabaseType(cfg.Database)
+	if cfg.Database != "" && !targetDatabase.IsValid() {
+		return &MigrationError{
+			Code:    "INVALID_DATABASE",
+			Message: "Invalid database type: " + cfg.Database + " (must be one of: mysql, postgresql, sqlite)",
+		}
+	}
+
+	if cfg.SQLCVersion != "" && cfg.SQLCVersion != "2" {
+		return &MigrationError{
+			Code:    "INVALID_VERSION",
+			Message: fmt.Sprintf("Unsupported SQLC config version %q: only version 2 can be targeted", cfg.SQLCVersion),
+		}
+	}
+
+	if cfg.DryRun {
 		fmt.Println("🔍 DRY RUN MODE - No changes will be made")
 	}
 
 	fmt.Println("🔄 SQLC Configuration Migration Tool")
-	fmt.Printf("Source: %s\n", config.Source)
-	fmt.Printf("Destination: %s\n", config.Destination)
-	fmt.Printf("Database: %s\n", config.Database)
-	fmt.Printf("SQLC Version: %s\n", config.SQLCVersion)
+	fmt.Printf("Source: %s\n", cfg.Source)
+	fmt.Printf("Destination: %s\n", destination)
+
+	original, err := os.ReadFile(cfg.Source)
+	if err != nil {
+		return apperrors.FileReadError(cfg.Source, err)
+	}
 
-	if config.Source == "" {
+	doc, err := loadMigrationSource(original)
+	if err != nil {
+		return apperrors.ConfigParseError(cfg.Source, err)
+	}
+
+	migrated, err := adapters.NewRealMigrationAdapter().
+		MigrateSQLCConfig(context.Background(), doc.Config, targetDatabase, cfg.SQLCVersion)
+	if err != nil {
 		return &MigrationError{
-			Code:    "MISSING_SOURCE",
-			Message: "Please specify source configuration file",
+			Code:    "MIGRATION_FAILED",
+			Message: fmt.Sprintf("Failed to migrate configuration: %v", err),
 		}
 	}
 
-	if config.Destination == "" {
+	translations, err := translateSQLFiles(filepath.Dir(cfg.Source), doc.Config, migrated)
+	if err != nil {
 		return &MigrationError{
-			Code:    "MISSING_DESTINATION",
-			Message: "Please specify destination file",
+			Code:    "TRANSLATION_FAILED",
+			Message: fmt.Sprintf("Failed to translate SQL files: %v", err),
 		}
 	}
 
-	// Parse database type (validation only for now)
-	if config.Database != "" {
-		// Simple database type validation for now
-		switch config.Database {
-		case "mysql", "postgresql", "sqlite":
-			// Valid database types
-		default:
-			return &MigrationError{
-				Code:    "INVALID_DATABASE",
-				Message: "Invalid database type: " + config.Database,
-			}
-		}
+	doc.Config = migrated
+
+	updated, err := doc.Bytes()
+	if err != nil {
+		return err
 	}
 
-	// For now, just show what would be migrated
-	if config.DryRun {
-		fmt.Println("✅ Migration would succeed (dry run)")
-		fmt.Printf("New configuration would be written to: %s\n", config.Destination)
+	current := ""
+	exists := false
+
+	if data, err := os.ReadFile(destination); err == nil {
+		current = string(data)
+		exists = true
+	}
+
+	diff := utils.UnifiedDiff(destination, destination, current, string(updated))
+	if diff == "" {
+		fmt.Println("✅ Configuration is already up to date")
 
 		return nil
 	}
 
-	// Basic migration simulation for demo
-	fmt.Println("✅ Migration completed successfully!")
-	fmt.Printf("Source: %s -> Destination: %s\n", config.Source, config.Destination)
+	if cfg.DryRun {
+		fmt.Println()
+		fmt.Print(diff)
+		printTranslationDiffs(translations)
+
+		if (exists || len(translations) > 0) && !cfg.Force {
+			fmt.Printf("\n⚠️  %s exists or SQL files would be rewritten; writing requires --force\n", destination)
+		}
+
+		return reportTranslations(translations, cfg.Report)
+	}
+
+	if len(translations) > 0 && !cfg.Force {
+		return &MigrationError{
+			Code:    "FORCE_REQUIRED",
+			Message: fmt.Sprintf("%d SQL file(s) would be rewritten in place; use --force to translate them or --dry-run to preview the changes", len(translations)),
+		}
+	}
+
+	if exists && !cfg.Force {
+		return &MigrationError{
+			Code:    "DESTINATION_EXISTS",
+			Message: fmt.Sprintf("%s already exists; use --force to overwrite it or --dry-run to preview the changes", destination),
+		}
+	}
+
+	err = os.WriteFile(destination, updated, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write config file to %s: %w", destination, err)
+	}
 
-	if config.Database != "" {
-		fmt.Printf("Database migration: -> %s\n", config.Database)
+	err = writeTranslations(translations)
+	if err != nil {
+		return err
 	}
 
-	if config.SQLCVersion != "" {
-		fmt.Printf("SQLC Version upgrade: -> %s\n", config.SQLCVersion)
+	if len(translations) > 0 {
+		fmt.Printf("🔁 Translated %d SQL file(s) to %s\n", len(translations), cfg.Database)
 	}
 
+	err = reportTranslations(translations, cfg.Report)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("✅ Migration completed successfully!")
+	fmt.Println("Run 'sqlc-wizard validate " + destination + "' to check the result")
+
 	return nil
 }
+
+// loadMigrationSource opens a configuration for conversion. Version 2 files keep their
+// comments and layout; version 1 files are upgraded and rendered from scratch.
+func loadMigrationSource(data []byte) (*config.Document, error) {
+	doc, err := config.ParseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.Config.Version != "1" {
+		return doc, nil
+	}
+
+	upgraded, err := config.UpgradeV1(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Logged here: the adapter only sees the upgraded config.
+	log.Info("Updated SQLC version", "from", "1", "to", upgraded.Version)
+
+	doc, err = config.ParseDocument(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	doc.Config = upgraded
+
+	return doc, nil
+}
@@ -2,70 +2,80 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
 
 	"github.com/LarsArtmann/SQLC-Wizzard/internal/adapters"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/diagnostics"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/migration"
 	"github.com/spf13/cobra"
 )
 
 // newMigrateStatusCommand creates migrate status command.
 func newMigrateStatusCommand() *cobra.Command {
-	var (
-		source   string
-		database string
-	)
+	target := &MigrationTarget{}
 
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Check migration status",
-		Long:  "Check the status of database migrations and show the current version.",
-		Run: func(cmd *cobra.Command, args []string) {
-			migrationConfig := &StatusConfig{
-				Source:   source,
-				Database: database,
-			}
+		Long: `Check the status of database migrations: the current version, every migration
+in the source with whether it is applied, missing down files and the pending list.
 
-			err := runStatusCheck(migrationConfig)
-			if err != nil {
-				fmt.Printf("❌ Status check failed: %v\n", err)
+The command exits non-zero when the database is dirty or at a version that is not
+in the migration source, so deploy scripts can gate on it.
 
-				return
+Example:
+  sqlc-wizard migrate status
+  sqlc-wizard migrate status --format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := outputFormat(cmd)
+			if err != nil {
+				return err
 			}
+
+			return runStatusCheck(target, format)
 		},
 	}
 
-	cmd.Flags().StringVarP(&source, "source", "s", "", "Migration source path")
-	cmd.Flags().StringVarP(&database, "database", "d", "", "Database connection URL")
+	addMigrationTargetFlags(cmd, target)
 
 	return cmd
 }
 
-// StatusConfig represents status check configuration.
-type StatusConfig struct {
-	Source   string
-	Database string
+// StatusReport is the JSON form of migrate status.
+type StatusReport struct {
+	*migration.MigrationStatus
+
+	Pending          []uint `json:"pending"`
+	MissingDownFiles []uint `json:"missing_down_files"`
+	OutOfOrder       bool   `json:"out_of_order"`
 }
 
 // runStatusCheck executes migration status check.
-func runStatusCheck(config *StatusConfig) error {
-	if config.Source == "" {
+func runStatusCheck(target *MigrationTarget, format diagnostics.Format) error {
+	if format != diagnostics.FormatText && format != diagnostics.FormatJSON {
 		return &MigrationError{
-			Code:    "MISSING_SOURCE",
-			Message: "Please specify migration source path",
+			Code:    "UNSUPPORTED_FORMAT",
+			Message: fmt.Sprintf("migrate status supports text and json output, not %s", format),
 		}
 	}
 
-	if config.Database == "" {
-		return &MigrationError{
-			Code:    "MISSING_DATABASE",
-			Message: "Please specify database URL",
-		}
+	err := target.Resolve()
+	if err != nil {
+		return err
 	}
 
 	// Create migration adapter and check status
 	migrationAdapter := adapters.NewRealMigrationAdapter()
 
-	status, err := migrationAdapter.Status(context.Background(), config.Source, config.Database)
+	status, err := migrationAdapter.Status(context.Background(), target.Source, target.Database)
 	if err != nil {
 		return &MigrationError{
 			Code:    "STATUS_CHECK_FAILED",
@@ -73,10 +83,148 @@ func runStatusCheck(config *StatusConfig) error {
 		}
 	}
 
-	fmt.Printf("📊 Migration Status:\n")
-	fmt.Printf("  Current Version: %v\n", status.GetCurrentVersion())
-	fmt.Printf("  Dirty State: %v\n", status.IsDirty())
-	fmt.Printf("  Total Migrations: %v\n", status.GetMigrationCount())
+	status.DatabaseURL = adapters.RedactDatabaseURL(status.DatabaseURL)
+
+	if format == diagnostics.FormatJSON {
+		err = writeStatusJSON(os.Stdout, status)
+	} else {
+		printStatusTable(os.Stdout, status)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return statusError(status)
+}
+
+// statusError returns an error for the states deployments must not proceed from.
+func statusError(status *migration.MigrationStatus) error {
+	switch {
+	case status.IsDirty():
+		return &MigrationError{
+			Code: "DIRTY",
+			Message: fmt.Sprintf(
+				"database is dirty at version %d; fix it by hand and run migrate force",
+				*status.GetCurrentVersion(),
+			),
+		}
+	case status.IsOutOfOrder():
+		return &MigrationError{
+			Code: "OUT_OF_ORDER",
+			Message: fmt.Sprintf(
+				"database is at version %d, which is not in %s",
+				*status.GetCurrentVersion(), status.Source,
+			),
+		}
+	default:
+		return nil
+	}
+}
+
+// writeStatusJSON prints the status as an indented JSON document.
+func writeStatusJSON(w io.Writer, status *migration.MigrationStatus) error {
+	report := StatusReport{
+		MigrationStatus:  status,
+		Pending:          versions(status.Pending()),
+		MissingDownFiles: versions(status.MissingDownFiles()),
+		OutOfOrder:       status.IsOutOfOrder(),
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode migration status: %w", err)
+	}
 
 	return nil
 }
+
+// printStatusTable prints the status as a human-readable table.
+func printStatusTable(w io.Writer, status *migration.MigrationStatus) {
+	current := "none"
+	if version := status.GetCurrentVersion(); version != nil {
+		current = strconv.FormatUint(uint64(*version), 10)
+	}
+
+	fmt.Fprintf(w, "📊 Migration Status\n")
+	fmt.Fprintf(w, "  Source:          %s\n", status.Source)
+	fmt.Fprintf(w, "  Current Version: %s\n", current)
+	fmt.Fprintf(w, "  Dirty State:     %v\n", status.IsDirty())
+	fmt.Fprintf(w, "  Applied:         %d of %d\n\n", status.GetAppliedMigrations(), status.GetMigrationCount())
+
+	if status.GetMigrationCount() > 0 {
+		table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "  VERSION\tNAME\tSTATE\tAPPLIED AT\tDOWN FILE")
+
+		for _, mig := range status.Migrations {
+			fmt.Fprintf(table, "  %d\t%s\t%s\t%s\t%s\n",
+				mig.Version, mig.Name, migrationState(mig), appliedAt(mig), downFile(mig))
+		}
+
+		_ = table.Flush()
+		fmt.Fprintln(w)
+	}
+
+	if pending := status.Pending(); len(pending) > 0 {
+		fmt.Fprintf(w, "⏳ %d pending migration(s):\n", len(pending))
+
+		for _, mig := range pending {
+			fmt.Fprintf(w, "  %d_%s\n", mig.Version, mig.Name)
+		}
+	} else {
+		fmt.Fprintln(w, "✅ No pending migrations")
+	}
+
+	if missing := status.MissingDownFiles(); len(missing) > 0 {
+		fmt.Fprintf(w, "⚠️  %d migration(s) cannot be rolled back (no down file)\n", len(missing))
+	}
+
+	if status.IsOutOfOrder() {
+		fmt.Fprintf(w, "❌ Database version %s is not in the migration source\n", current)
+	}
+
+	if status.IsDirty() {
+		fmt.Fprintf(w, "❌ Database is dirty at version %s\n", current)
+	}
+}
+
+func migrationState(mig migration.Migration) string {
+	switch {
+	case mig.Dirty:
+		return "dirty"
+	case mig.UpFile == "":
+		return "no up file"
+	case mig.Applied:
+		return "applied"
+	default:
+		return "pending"
+	}
+}
+
+func appliedAt(mig migration.Migration) string {
+	if mig.AppliedAt == nil {
+		return "-"
+	}
+
+	return mig.AppliedAt.Format(time.RFC3339)
+}
+
+func downFile(mig migration.Migration) string {
+	if mig.DownFile == "" {
+		return "missing"
+	}
+
+	return filepath.Base(mig.DownFile)
+}
+
+func versions(migrations []migration.Migration) []uint {
+	result := make([]uint, 0, len(migrations))
+	for _, mig := range migrations {
+		result = append(result, mig.Version)
+	}
+
+	return result
+}
@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"charm.land/log/v2"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/dialect"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/utils"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+)
+
+// sqlTranslation is a schema or query file rewritten for another engine.
+type sqlTranslation struct {
+	Path     string
+	Original string
+	SQL      string
+	Notes    []dialect.Note
+}
+
+// translateSQLFiles translates the schema and query files of every sql[] entry whose
+// engine differs between the source and the migrated configuration. Paths are resolved
+// relative to baseDir; missing paths are skipped with a warning.
+func translateSQLFiles(baseDir string, source, migrated *config.SqlcConfig) ([]sqlTranslation, error) {
+	var translations []sqlTranslation
+
+	seen := map[string]bool{}
+
+	for i := range min(len(source.SQL), len(migrated.SQL)) {
+		from, to := source.SQL[i].Engine, migrated.SQL[i].Engine
+		if from == to {
+			continue
+		}
+
+		translator, err := dialect.NewTranslator(from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		paths := slices.Concat(source.SQL[i].Schema.Strings(), source.SQL[i].Queries.Strings())
+
+		for _, path := range paths {
+			files, err := config.NewSinglePath(path).SQLFiles(baseDir)
+			if err != nil {
+				log.Warn("Skipped SQL path", "path", path, "error", err)
+
+				continue
+			}
+
+			for _, file := range files {
+				if seen[file] {
+					continue
+				}
+
+				seen[file] = true
+
+				translation, err := translateSQLFile(translator, file)
+				if err != nil {
+					return nil, err
+				}
+
+				if translation.SQL != translation.Original || len(translation.Notes) > 0 {
+					translations = append(translations, *translation)
+				}
+			}
+		}
+	}
+
+	return translations, nil
+}
+
+// translateSQLFile reads and translates a single SQL file.
+func translateSQLFile(translator *dialect.Translator, path string) (*sqlTranslation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	result, err := translator.Translate(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate %s: %w", path, err)
+	}
+
+	return &sqlTranslation{Path: path, Original: string(data), SQL: result.SQL, Notes: result.Notes}, nil
+}
+
+// printTranslationDiffs prints the changes of every translated file.
+func printTranslationDiffs(translations []sqlTranslation) {
+	for _, translation := range translations {
+		if diff := utils.UnifiedDiff(translation.Path, translation.Path, translation.Original, translation.SQL); diff != "" {
+			fmt.Println()
+			fmt.Print(diff)
+		}
+	}
+}
+
+// writeTranslations rewrites the translated files in place.
+func writeTranslations(translations []sqlTranslation) error {
+	for _, translation := range translations {
+		if translation.SQL == translation.Original {
+			continue
+		}
+
+		err := os.WriteFile(translation.Path, []byte(translation.SQL), 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", translation.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// translationReport lists the constructs that need a manual change, one per line
+// as file:line:column: message.
+func translationReport(translations []sqlTranslation) string {
+	var b strings.Builder
+
+	for _, translation := range translations {
+		for _, note := range translation.Notes {
+			fmt.Fprintf(&b, "%s:%s\n", translation.Path, note)
+		}
+	}
+
+	return b.String()
+}
+
+// reportTranslations prints the translation report and writes it to reportPath when set.
+func reportTranslations(translations []sqlTranslation, reportPath string) error {
+	report := translationReport(translations)
+
+	if report != "" {
+		fmt.Printf("\n⚠️  %d construct(s) could not be translated automatically:\n", strings.Count(report, "\n"))
+
+		for line := range strings.Lines(report) {
+			fmt.Print("  " + line)
+		}
+	}
+
+	if reportPath == "" {
+		return nil
+	}
+
+	err := os.WriteFile(reportPath, []byte(report), 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write translation report to %s: %w", reportPath, err)
+	}
+
+	fmt.Printf("📝 Translation report written to %s\n", reportPath)
+
+	return nil
+}
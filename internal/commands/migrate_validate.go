@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"charm.land/lipgloss/v2"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/adapters"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/diagnostics"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/migration"
+	"github.com/spf13/cobra"
+)
+
+// newMigrateValidateCommand creates the migrate validate command.
+func newMigrateValidateCommand() *cobra.Command {
+	target := &MigrationTarget{}
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the migration files for mistakes",
+		Long: `Validate checks the migrations directory without touching a database:
+  • file names that do not match the golang-migrate pattern
+  • duplicate version numbers
+  • up files without a down file, and down files without an up file
+  • gaps in sequential versions
+  • mixed timestamp and sequential versions
+  • files without any SQL statement, such as untouched migrate create templates
+
+Example:
+  sqlc-wizard migrate validate
+  sqlc-wizard migrate validate --source db/migrations --format github`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := outputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			return runMigrationValidate(target, format)
+		},
+	}
+
+	cmd.Flags().
+		StringVarP(&target.ConfigPath, "config", "c", "sqlc.yaml", "Path to sqlc.yaml configuration file")
+	cmd.Flags().
+		StringVarP(&target.Source, "source", "s", "", "Migrations directory (default: schema path from sqlc.yaml)")
+
+	return cmd
+}
+
+// runMigrationValidate validates the migrations directory and prints the findings.
+func runMigrationValidate(target *MigrationTarget, format diagnostics.Format) error {
+	err := target.ResolveSource()
+	if err != nil {
+		return err
+	}
+
+	migrationAdapter := adapters.NewRealMigrationAdapter()
+
+	result, err := migrationAdapter.Validate(context.Background(), target.Source)
+	if err != nil {
+		return &MigrationError{
+			Code:    "VALIDATION_FAILED",
+			Message: fmt.Sprintf("Failed to validate migrations: %v", err),
+		}
+	}
+
+	report := diagnostics.NewReport("migrate validate")
+	report.Add(diagnostics.FromMigrationDirectory(result)...)
+
+	if format.IsText() {
+		displayMigrationValidation(result, report)
+	} else if err := writeReport(format, report); err != nil {
+		return err
+	}
+
+	if result.HasErrors() {
+		return fmt.Errorf("migration validation failed with %d error(s)", report.ErrorCount())
+	}
+
+	return nil
+}
+
+func displayMigrationValidation(result *migration.DirectoryReport, report *diagnostics.Report) {
+	errorStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("9"))
+
+	warningStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("11"))
+
+	report.Sort()
+
+	for _, diagnostic := range report.Diagnostics {
+		style := warningStyle
+		if diagnostic.IsError() {
+			style = errorStyle
+		}
+
+		fmt.Println(style.Render(diagnostic.String()))
+	}
+
+	if len(report.Diagnostics) > 0 {
+		fmt.Println()
+	}
+
+	summary := fmt.Sprintf("Checked %d migration file(s) in %s: %d error(s), %d warning(s)",
+		len(result.Files), result.Dir, report.ErrorCount(), report.WarningCount())
+
+	if result.HasErrors() {
+		PrintError(summary)
+
+		return
+	}
+
+	PrintSuccess(summary)
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/diagnostics"
+	"github.com/spf13/cobra"
+)
+
+// formatFlag is the global flag selecting how diagnostics are printed.
+const formatFlag = "format"
+
+// AddGlobalFlags registers the flags shared by every command on the root command.
+func AddGlobalFlags(root *cobra.Command) {
+	root.PersistentFlags().String(formatFlag, string(diagnostics.FormatText),
+		"Output format for diagnostics: text, json, sarif or github")
+}
+
+// outputFormat returns the --format value of cmd. Commands used without the
+// root command (e.g. in tests) have no such flag and default to text.
+func outputFormat(cmd *cobra.Command) (diagnostics.Format, error) {
+	flag := cmd.Flags().Lookup(formatFlag)
+	if flag == nil {
+		return diagnostics.FormatText, nil
+	}
+
+	return diagnostics.ParseFormat(flag.Value.String())
+}
+
+// writeReport prints a report in a machine-readable format on stdout.
+func writeReport(format diagnostics.Format, report *diagnostics.Report) error {
+	report.Sort()
+
+	return diagnostics.Write(os.Stdout, format, report)
+}
@@ -2,8 +2,15 @@ package commands
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 
 	"charm.land/lipgloss/v2"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/diagnostics"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/queries"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/utils"
 	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
 	"github.com/spf13/cobra"
 )
@@ -12,7 +19,9 @@ import (
 type ValidateOptions struct {
 	ConfigPath string
 	Fix        bool
+	DryRun     bool
 	Strict     bool
+	Format     diagnostics.Format
 }
 
 // NewValidateCommand creates the validate command.
@@ -27,13 +36,17 @@ func NewValidateCommand() *cobra.Command {
 The validator checks for:
   • Required fields and valid values
   • Database engine compatibility
-  • Path configurations
+  • Paths: queries/schema must exist and contain .sql files, output
+    directories must not overlap or live inside SQL directories
+  • Query annotations (missing "-- name:" headers, unknown or unsupported
+    :commands, duplicate query names)
   • Best practice recommendations
 
 Example:
   sqlc-wizard validate
   sqlc-wizard validate sqlc.yaml
-  sqlc-wizard validate --strict`,
+  sqlc-wizard validate --strict
+  sqlc-wizard validate --fix --dry-run`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Determine config path
@@ -43,29 +56,56 @@ Example:
 				opts.ConfigPath = "sqlc.yaml"
 			}
 
+			format, err := outputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			opts.Format = format
+
 			return runValidate(opts)
 		},
 	}
 
 	// Add flags
-	cmd.Flags().BoolVar(&opts.Fix, "fix", false, "Attempt to auto-fix common issues")
-	cmd.Flags().BoolVar(&opts.Strict, "strict", false, "Enable strict validation mode")
+	cmd.Flags().BoolVar(&opts.Fix, "fix", false, "Apply automatic fixes to the config file")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "With --fix, show the changes without writing them")
+	cmd.Flags().BoolVar(&opts.Strict, "strict", false, "Treat warnings as errors")
 
 	return cmd
 }
 
 func runValidate(opts *ValidateOptions) error {
+	if opts.DryRun && !opts.Fix {
+		return apperrors.NewError(apperrors.ErrorCodeInvalidValue, "--dry-run can only be used together with --fix")
+	}
+
 	// Parse config file
 	cfg, err := config.ParseFile(opts.ConfigPath)
 	if err != nil {
 		return fmt.Errorf("failed to parse config: %w", err)
 	}
 
-	// Validate
-	result := config.Validate(cfg)
+	result := validateConfig(cfg, opts)
+
+	if opts.Fix {
+		fixed, err := applyValidationFixes(result, opts)
+		if err != nil {
+			return err
+		}
+
+		// Report what is left after the fixes were written
+		if fixed != nil {
+			result = validateConfig(fixed, opts)
+		}
+	}
 
 	// Display results
-	displayValidationResults(result, opts)
+	if opts.Format.IsText() {
+		displayValidationResults(result, opts)
+	} else if err := writeReport(opts.Format, validationReport(result, opts.ConfigPath)); err != nil {
+		return err
+	}
 
 	// Return error if validation failed
 	if !result.IsValid() {
@@ -75,6 +115,104 @@ func runValidate(opts *ValidateOptions) error {
 	return nil
 }
 
+// validateConfig validates the config, the paths it references, the query files they
+// contain and the shape of the rules in the file.
+func validateConfig(cfg *config.SqlcConfig, opts *ValidateOptions) *config.ValidationResult {
+	baseDir := filepath.Dir(opts.ConfigPath)
+
+	result := config.Validate(cfg)
+	result.Merge(config.ValidatePaths(cfg, baseDir))
+	result.Merge(queries.ValidateConfig(cfg, baseDir))
+
+	// Legacy rule objects only show in the file: the parsed config is already upgraded
+	if data, err := os.ReadFile(opts.ConfigPath); err == nil {
+		result.Merge(config.ValidateLegacyRules(data))
+	}
+
+	if opts.Strict {
+		result.PromoteWarnings()
+	}
+
+	return result
+}
+
+// validationReport converts a validation result into diagnostics positioned in the config file.
+func validationReport(result *config.ValidationResult, configPath string) *diagnostics.Report {
+	var locate diagnostics.Locator
+
+	if doc, err := config.LoadDocument(configPath); err == nil {
+		locate = doc.Position
+	}
+
+	report := diagnostics.NewReport("validate")
+	report.Add(diagnostics.FromValidation(result, configPath, locate)...)
+
+	return report
+}
+
+// applyValidationFixes applies the automatic fixes of result to the config file and prints
+// a diff of the changes. It returns the fixed config, or nil when nothing was written.
+func applyValidationFixes(result *config.ValidationResult, opts *ValidateOptions) (*config.SqlcConfig, error) {
+	// Keep stdout clean for machine-readable formats
+	out := io.Writer(os.Stdout)
+	if !opts.Format.IsText() {
+		out = os.Stderr
+	}
+
+	fixes := result.Fixes()
+	if len(fixes) == 0 {
+		fmt.Fprintln(out, "No automatic fixes available.")
+		fmt.Fprintln(out)
+
+		return nil, nil
+	}
+
+	original, err := os.ReadFile(opts.ConfigPath)
+	if err != nil {
+		return nil, apperrors.FileReadError(opts.ConfigPath, err)
+	}
+
+	doc, err := config.ParseDocument(original)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	for _, fix := range fixes {
+		fix.Apply(doc.Config)
+	}
+
+	updated, err := doc.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true)
+
+	if opts.DryRun {
+		fmt.Fprintln(out, headerStyle.Render(fmt.Sprintf("Would apply %d fix(es) (dry run):", len(fixes))))
+	} else {
+		fmt.Fprintln(out, headerStyle.Render(fmt.Sprintf("Applied %d fix(es):", len(fixes))))
+	}
+
+	for _, fix := range fixes {
+		fmt.Fprintf(out, "  • %s\n", fix.Description)
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprint(out, utils.UnifiedDiff(opts.ConfigPath, opts.ConfigPath, string(original), string(updated)))
+	fmt.Fprintln(out)
+
+	if opts.DryRun {
+		return nil, nil
+	}
+
+	if err := os.WriteFile(opts.ConfigPath, updated, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write config file to %s: %w", opts.ConfigPath, err)
+	}
+
+	return doc.Config, nil
+}
+
 func displayValidationResults(result *config.ValidationResult, opts *ValidateOptions) {
 	successStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -106,9 +244,9 @@ func displayValidationResults(result *config.ValidationResult, opts *ValidateOpt
 		}
 	}
 
-	// Show fix suggestion if applicable
-	if !result.IsValid() && opts.Fix {
-		fmt.Println("Note: Auto-fix is not yet implemented. Please fix errors manually.")
+	// Point at --fix when some findings can be corrected automatically
+	if fixable := len(result.Fixes()); fixable > 0 && (!opts.Fix || opts.DryRun) {
+		fmt.Printf("%d issue(s) can be fixed automatically with --fix.\n", fixable)
 	}
 }
 
@@ -120,7 +258,11 @@ func printValidationItems(
 	fmt.Println(style.Render(fmt.Sprintf("%s Found %d %s(s):", emoji, len(items), itemType)))
 
 	for _, item := range items {
-		fmt.Printf("  • %s: %s\n", item.Field, item.Message)
+		if item.Fix != nil {
+			fmt.Printf("  • %s: %s (fixable: %s)\n", item.Field, item.Message, item.Fix.Description)
+		} else {
+			fmt.Printf("  • %s: %s\n", item.Field, item.Message)
+		}
 	}
 
 	fmt.Println()
@@ -0,0 +1,173 @@
+package creators
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/generated"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+)
+
+// goVersion is the go directive of generated go.mod files.
+const goVersion = "1.24"
+
+// goDriver is the Go module a generated project reaches its database with.
+type goDriver struct {
+	// Module and Version are the go.mod requirement.
+	Module  string
+	Version string
+	// Import is the package main.go imports: a database/sql driver registered by a
+	// blank import, or a pgx pool package.
+	Import string
+	// Name is the database/sql driver name; empty for pgx pools.
+	Name string
+	// Connect is the pgxpool function opening a pool; empty for database/sql.
+	Connect string
+	// DSN is the connection string used when DATABASE_URL is not set.
+	DSN string
+}
+
+// goDriverFor returns the driver for an engine and the sql_package of its gen.go block.
+func goDriverFor(engine generated.DatabaseType, sqlPackage, projectName string) (goDriver, error) {
+	if sqlPackage == "" {
+		sqlPackage = config.SQLPackageDatabaseSQL
+	}
+
+	switch {
+	case engine == generated.DatabaseTypePostgreSQL && sqlPackage == config.SQLPackagePgxV5:
+		return goDriver{
+			Module: "github.com/jackc/pgx/v5", Version: "v5.7.4",
+			Import: "github.com/jackc/pgx/v5/pgxpool", Connect: "New",
+			DSN: "postgres://localhost:5432/" + projectName + "?sslmode=disable",
+		}, nil
+	case engine == generated.DatabaseTypePostgreSQL && sqlPackage == config.SQLPackagePgxV4:
+		return goDriver{
+			Module: "github.com/jackc/pgx/v4", Version: "v4.18.3",
+			Import: "github.com/jackc/pgx/v4/pgxpool", Connect: "Connect",
+			DSN: "postgres://localhost:5432/" + projectName + "?sslmode=disable",
+		}, nil
+	case engine == generated.DatabaseTypePostgreSQL && sqlPackage == config.SQLPackageDatabaseSQL:
+		return goDriver{
+			Module: "github.com/jackc/pgx/v5", Version: "v5.7.4",
+			Import: "github.com/jackc/pgx/v5/stdlib", Name: "pgx",
+			DSN: "postgres://localhost:5432/" + projectName + "?sslmode=disable",
+		}, nil
+	case engine == generated.DatabaseTypeMySQL && sqlPackage == config.SQLPackageDatabaseSQL:
+		// parseTime makes the driver scan DATETIME and TIMESTAMP columns into time.Time.
+		return goDriver{
+			Module: "github.com/go-sql-driver/mysql", Version: "v1.9.2",
+			Import: "github.com/go-sql-driver/mysql", Name: "mysql",
+			DSN: "root@tcp(localhost:3306)/" + projectName + "?parseTime=true",
+		}, nil
+	case engine == generated.DatabaseTypeSQLite && sqlPackage == config.SQLPackageDatabaseSQL:
+		return goDriver{
+			Module: "modernc.org/sqlite", Version: "v1.37.0",
+			Import: "modernc.org/sqlite", Name: "sqlite",
+			DSN: projectName + ".db",
+		}, nil
+	default:
+		return goDriver{}, apperrors.Newf(apperrors.ErrorCodeInvalidValue,
+			"no Go driver for engine %q with sql_package %q", engine, sqlPackage)
+	}
+}
+
+// buildGoMod renders the go.mod of a generated project.
+func buildGoMod(modulePath string, driver goDriver) string {
+	return fmt.Sprintf("module %s\n\ngo %s\n\nrequire %s %s\n", modulePath, goVersion, driver.Module, driver.Version)
+}
+
+// buildMainGo renders cmd/server/main.go, which opens the database and constructs the
+// Queries generated into dbImport.
+func buildMainGo(projectName, dbImport, dbPackage string, driver goDriver) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "// Command server connects to the database of %s and constructs its queries.\n", projectName)
+	b.WriteString("package main\n\nimport (\n\t\"context\"\n")
+
+	if driver.Connect == "" {
+		b.WriteString("\t\"database/sql\"\n")
+	}
+
+	b.WriteString("\t\"fmt\"\n\t\"log\"\n\t\"os\"\n\n")
+
+	if driver.Connect == "" {
+		fmt.Fprintf(&b, "\t_ %q\n", driver.Import)
+	} else {
+		fmt.Fprintf(&b, "\t%q\n", driver.Import)
+	}
+
+	if path.Base(dbImport) == dbPackage {
+		fmt.Fprintf(&b, "\n\t%q\n)\n\n", dbImport)
+	} else {
+		fmt.Fprintf(&b, "\n\t%s %q\n)\n\n", dbPackage, dbImport)
+	}
+
+	fmt.Fprintf(&b, "const defaultDatabaseURL = %q\n\n", driver.DSN)
+	b.WriteString(`func main() {
+	if err := run(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context) error {
+	url := os.Getenv("DATABASE_URL")
+	if url == "" {
+		url = defaultDatabaseURL
+	}
+
+`)
+
+	if driver.Connect == "" {
+		fmt.Fprintf(&b, `	conn, err := sql.Open(%q, url)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %%w", err)
+	}
+	defer conn.Close()
+
+	if err := conn.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to connect to database: %%w", err)
+	}
+`, driver.Name)
+	} else {
+		fmt.Fprintf(&b, `	conn, err := pgxpool.%s(ctx, url)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %%w", err)
+	}
+	defer conn.Close()
+
+	if err := conn.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to connect to database: %%w", err)
+	}
+`, driver.Connect)
+	}
+
+	fmt.Fprintf(&b, `
+	queries := %s.New(conn)
+	log.Printf("connected to the database, queries ready: %%T", queries)
+
+	return nil
+}
+`, dbPackage)
+
+	return b.String()
+}
+
+// buildDBDoc renders the doc.go of the package sqlc generates into. Its go:generate
+// directive runs sqlc with the sqlc.yaml at the project root.
+func buildDBDoc(projectName, dbPackage, dbDir string) string {
+	root, err := filepath.Rel(dbDir, ".")
+	if err != nil {
+		root = "."
+	}
+
+	return fmt.Sprintf(`// Package %s holds the database code of %s. Everything but this file is
+// generated by sqlc from the schema and queries configured in sqlc.yaml; run
+// go generate ./... after changing them.
+package %s
+
+//go:generate sqlc generate -f %s
+`, dbPackage, projectName, dbPackage, path.Join(filepath.ToSlash(root), "sqlc.yaml"))
+}
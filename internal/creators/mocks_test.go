@@ -5,6 +5,7 @@ import (
 	"io/fs"
 
 	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	. "github.com/onsi/ginkgo/v2"
 )
 
 // MockFileSystemAdapter captures file system operations for testing.
@@ -59,6 +60,19 @@ func (m *MockFileSystemAdapter) WriteFile(
 	return nil
 }
 
+// written returns the content last written to path.
+func (m *MockFileSystemAdapter) written(path string) string {
+	for i := len(m.writeFileCalls) - 1; i >= 0; i-- {
+		if m.writeFileCalls[i].Path == path {
+			return string(m.writeFileCalls[i].Content)
+		}
+	}
+
+	Fail("no file was written to " + path)
+
+	return ""
+}
+
 func (m *MockFileSystemAdapter) ReadFile(ctx context.Context, path string) ([]byte, error) {
 	return nil, apperrors.NewError(apperrors.ErrorCodeInternalServer, "not implemented")
 }
@@ -3,10 +3,14 @@ package creators
 import (
 	"context"
 	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
 
 	"github.com/LarsArtmann/SQLC-Wizzard/generated"
 	"github.com/LarsArtmann/SQLC-Wizzard/internal/adapters"
 	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/generators"
 	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
 )
 
@@ -58,12 +62,19 @@ func (pc *ProjectCreator) CreateProject(ctx context.Context, config *CreateConfi
 		return fmt.Errorf("failed to generate database schema: %w", err)
 	}
 
+	// Generate Go module
+	err = pc.generateGoModule(ctx, config)
+	if err != nil {
+		return fmt.Errorf("failed to generate Go module: %w", err)
+	}
+
+	// go.mod only requires the driver: go.sum and the indirect requirements are left
+	// to go mod tidy, which needs the code sqlc generates first.
+	_ = pc.cli.Println(ctx, "👉 Next steps: go generate ./... && go mod tidy")
+
 	// TODO: Full project scaffolding is not yet implemented
 	// See GitHub issues for roadmap:
-	// - Database schema generation
-	// - Query file generation
 	// - Migration file generation
-	// - Go module structure
 	// - Docker configuration
 	// - Makefile generation
 	// - Development scripts
@@ -73,6 +84,8 @@ func (pc *ProjectCreator) CreateProject(ctx context.Context, config *CreateConfi
 	// For now, ProjectCreator only generates:
 	// 1. Directory structure
 	// 2. sqlc.yaml configuration file
+	// 3. Example schema and queries for the project type
+	// 4. go.mod, cmd/server/main.go and the doc.go of the db package
 	//
 	// Additional scaffolding will be added based on user feedback and demand.
 
@@ -121,6 +134,23 @@ func (pc *ProjectCreator) createDirectoryStructure(
 		return fmt.Errorf("unsupported project type: %s", config.ProjectType)
 	}
 
+	// The example schema and queries go where sqlc.yaml looks for them
+	if data, ok := exampleTemplateData(config); ok {
+		queriesPath, schemaPath := generators.NewGenerator("").ExamplePaths(data)
+		for _, dir := range []string{filepath.Dir(schemaPath), filepath.Dir(queriesPath)} {
+			if !slices.Contains(dirs, dir) {
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+
+	// So does the db package sqlc generates into
+	if gen, ok := goGenConfig(config); ok {
+		if dir := filepath.Clean(gen.Out); !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
 	for _, dir := range dirs {
 		err := pc.fs.MkdirAll(ctx, dir, 0o755)
 		if err != nil {
@@ -157,276 +187,116 @@ func (pc *ProjectCreator) generateSQLCConfig(ctx context.Context, cfg *CreateCon
 	return nil
 }
 
-// generateDatabaseSchema creates database schema file.
+// generateDatabaseSchema writes the example schema and queries of the project type into
+// the schema and queries paths of the first sql[] entry, so sqlc generate works out of the box.
 func (pc *ProjectCreator) generateDatabaseSchema(ctx context.Context, cfg *CreateConfig) error {
 	_ = pc.cli.Println(ctx, "🗄️  Generating database schema...")
 
-	// Build schema content based on project configuration
-	// Note: TemplateData must be properly populated from CreateConfig
-	templateData := generated.TemplateData{
-		ProjectName: cfg.ProjectName,
-		ProjectType: cfg.ProjectType,
+	data, ok := exampleTemplateData(cfg)
+	if !ok {
+		return apperrors.NewError(
+			apperrors.ErrorCodeInternalServer,
+			"sqlc config has no sql entries: cannot place the example schema and queries",
+		)
 	}
-	schemaContent := pc.buildSchemaSQL(templateData)
-
-	writeErr := pc.fs.WriteFile(
-		ctx,
-		"schema.sql",
-		[]byte(schemaContent),
-		0o644,
-	)
-	if writeErr != nil {
-		return fmt.Errorf("failed to write schema.sql: %w", writeErr)
+
+	schemaContent, err := generators.ExampleSchema(cfg.ProjectType, cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to build schema: %w", err)
 	}
 
-	return nil
-}
+	queriesContent, err := generators.ExampleQueries(cfg.ProjectType, cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to build queries: %w", err)
+	}
 
-// buildSchemaSQL creates SQL schema content.
-func (pc *ProjectCreator) buildSchemaSQL(data generated.TemplateData) string {
-	schema := "-- Database schema for " + data.ProjectName + "\n"
-	schema += "-- Generated by SQLC-Wizard\n\n"
+	queriesPath, schemaPath := generators.NewGenerator("").ExamplePaths(data)
 
-	// Basic user table (common in most projects)
-	schema += pc.createUserTable(data)
+	return pc.writeFiles(ctx, []projectFile{
+		{schemaPath, "-- Database schema for " + cfg.ProjectName + "\n-- Generated by SQLC-Wizard\n\n" + schemaContent},
+		{queriesPath, "-- Queries for " + cfg.ProjectName + "\n-- Generated by SQLC-Wizard\n\n" + queriesContent},
+	})
+}
 
-	// Add project-specific tables based on project type
-	switch data.ProjectType {
-	case generated.ProjectTypeMicroservice:
-		schema += pc.createMicroserviceTables(data)
-	case generated.ProjectTypeEnterprise:
-		schema += pc.createEnterpriseTables(data)
-	case generated.ProjectTypeAPIFirst:
-		schema += pc.createAPIFirstTables(data)
-	case generated.ProjectTypeHobby:
-		schema += pc.createHobbyTables(data)
-	case generated.ProjectTypeAnalytics:
-		schema += pc.createAnalyticsTables(data)
-	case generated.ProjectTypeTesting:
-		schema += pc.createTestingTables(data)
-	case generated.ProjectTypeMultiTenant:
-		schema += pc.createMultiTenantTables(data)
-	case generated.ProjectTypeLibrary:
-		schema += pc.createLibraryTables(data)
-	default:
-		panic(fmt.Sprintf("unsupported project type: %s", data.ProjectType))
+// generateGoModule writes go.mod with the driver of the engine and sql_package, a doc.go
+// whose go:generate directive runs sqlc for the db package, and a cmd/server/main.go
+// that opens the connection and constructs the generated Queries.
+func (pc *ProjectCreator) generateGoModule(ctx context.Context, cfg *CreateConfig) error {
+	_ = pc.cli.Println(ctx, "🐹 Generating Go module...")
+
+	gen, ok := goGenConfig(cfg)
+	if !ok {
+		return apperrors.NewError(
+			apperrors.ErrorCodeInternalServer,
+			"sqlc config has no gen.go output: cannot place the generated db package",
+		)
 	}
 
-	schema += "\n-- Indexes for performance\n"
-	schema += pc.createBasicIndexes(data)
+	driver, err := goDriverFor(cfg.Database, gen.SQLPackage, cfg.ProjectName)
+	if err != nil {
+		return err
+	}
 
-	return schema
-}
+	modulePath := cfg.ProjectName
+	dbDir := path.Clean(filepath.ToSlash(gen.Out))
+	dbImport := path.Join(modulePath, dbDir)
 
-// createUserTable creates users table.
-func (pc *ProjectCreator) createUserTable(data generated.TemplateData) string {
-	return `
--- Users table for authentication and user management
-CREATE TABLE users (
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    email VARCHAR(255) UNIQUE NOT NULL,
-    password_hash VARCHAR(255) NOT NULL,
-    full_name VARCHAR(255),
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-);
-
--- Add basic indexes for users
-CREATE INDEX idx_users_email ON users(email);
-CREATE INDEX idx_users_created_at ON users(created_at);
-`
+	return pc.writeFiles(ctx, []projectFile{
+		{"go.mod", buildGoMod(modulePath, driver)},
+		{path.Join(dbDir, "doc.go"), buildDBDoc(cfg.ProjectName, gen.Package, dbDir)},
+		{"cmd/server/main.go", buildMainGo(cfg.ProjectName, dbImport, gen.Package, driver)},
+	})
 }
 
-// createMicroserviceTables creates tables for microservice projects.
-func (pc *ProjectCreator) createMicroserviceTables(data generated.TemplateData) string {
-	return `
--- API tokens table for microservice authentication
-CREATE TABLE api_tokens (
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-    token_hash VARCHAR(255) NOT NULL,
-    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-    
-    CONSTRAINT fk_api_tokens_user FOREIGN KEY (user_id) REFERENCES users(id)
-);
-
-CREATE INDEX idx_api_tokens_user_id ON api_tokens(user_id);
-CREATE INDEX idx_api_tokens_expires_at ON api_tokens(expires_at);
-`
+// projectFile is a file of the generated project, relative to its root.
+type projectFile struct {
+	path    string
+	content string
 }
 
-// createEnterpriseTables creates tables for enterprise projects.
-func (pc *ProjectCreator) createEnterpriseTables(data generated.TemplateData) string {
-	return `
--- Audit log table for enterprise compliance
-CREATE TABLE audit_logs (
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
-    action VARCHAR(100) NOT NULL,
-    resource_type VARCHAR(100) NOT NULL,
-    resource_id VARCHAR(255),
-    old_values JSONB,
-    new_values JSONB,
-    ip_address INET,
-    user_agent TEXT,
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-    
-    CONSTRAINT fk_audit_logs_user FOREIGN KEY (user_id) REFERENCES users(id)
-);
-
-CREATE INDEX idx_audit_logs_user_id ON audit_logs(user_id);
-CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
-CREATE INDEX idx_audit_logs_action ON audit_logs(action);
-`
-}
+// writeFiles writes the files of the project in order.
+func (pc *ProjectCreator) writeFiles(ctx context.Context, files []projectFile) error {
+	for _, file := range files {
+		writeErr := pc.fs.WriteFile(ctx, file.path, []byte(file.content), 0o644)
+		if writeErr != nil {
+			return fmt.Errorf("failed to write %s: %w", file.path, writeErr)
+		}
+	}
 
-// createAPIFirstTables creates tables for API-first projects.
-func (pc *ProjectCreator) createAPIFirstTables(data generated.TemplateData) string {
-	return `
--- Rate limiting table for API-first projects
-CREATE TABLE rate_limits (
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    client_identifier VARCHAR(255) NOT NULL,
-    endpoint VARCHAR(255) NOT NULL,
-    max_requests INTEGER NOT NULL DEFAULT 100,
-    window_seconds INTEGER NOT NULL DEFAULT 60,
-    current_requests INTEGER DEFAULT 0,
-    window_start TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-    
-    UNIQUE(client_identifier, endpoint)
-);
-
-CREATE INDEX idx_rate_limits_client ON rate_limits(client_identifier);
-CREATE INDEX idx_rate_limits_window ON rate_limits(window_start);
-`
+	return nil
 }
 
-// createBasicIndexes creates basic performance indexes.
-func (pc *ProjectCreator) createBasicIndexes(data generated.TemplateData) string {
-	return `
--- Basic performance indexes
-CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_users_email ON users(email);
-CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_users_created_at ON users(created_at);
-`
-}
+// goGenConfig returns the gen.go block of the first sql[] entry when it names an output
+// directory and package.
+func goGenConfig(cfg *CreateConfig) (*config.GoGenConfig, bool) {
+	if cfg.Config == nil || len(cfg.Config.SQL) == 0 {
+		return nil, false
+	}
 
-// NOTE: Additional scaffolding methods will be implemented based on demand
-// See the TODO in CreateProject for planned features
-
-// createHobbyTables creates tables for hobby projects.
-func (pc *ProjectCreator) createHobbyTables(data generated.TemplateData) string {
-	return `
--- Settings table for hobby project configuration
-CREATE TABLE settings (
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-    key VARCHAR(255) NOT NULL,
-    value TEXT,
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-    UNIQUE(user_id, key)
-);
-
-CREATE INDEX idx_settings_user_id ON settings(user_id);
-`
-}
+	gen := cfg.Config.SQL[0].Gen.Go
+	if gen == nil || gen.Out == "" || gen.Package == "" {
+		return nil, false
+	}
 
-// createAnalyticsTables creates tables for analytics projects.
-func (pc *ProjectCreator) createAnalyticsTables(data generated.TemplateData) string {
-	return `
--- Events table for analytics data
-CREATE TABLE events (
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    event_type VARCHAR(255) NOT NULL,
-    payload JSONB,
-    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-);
-
-CREATE INDEX idx_events_type ON events(event_type);
-CREATE INDEX idx_events_user_id ON events(user_id);
-CREATE INDEX idx_events_created_at ON events(created_at);
-
--- Aggregations table for pre-computed analytics
-CREATE TABLE aggregations (
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    metric_name VARCHAR(255) NOT NULL,
-    metric_value NUMERIC NOT NULL,
-    aggregation_period VARCHAR(50) NOT NULL,
-    computed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-);
-
-CREATE INDEX idx_aggregations_metric ON aggregations(metric_name);
-`
+	return gen, true
 }
 
-// createTestingTables creates tables for testing projects.
-func (pc *ProjectCreator) createTestingTables(data generated.TemplateData) string {
-	return `
--- Fixtures table for test data
-CREATE TABLE fixtures (
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    name VARCHAR(255) NOT NULL UNIQUE,
-    data JSONB NOT NULL,
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-);
-
--- Test runs table for tracking test execution
-CREATE TABLE test_runs (
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    name VARCHAR(255) NOT NULL,
-    status VARCHAR(50) NOT NULL,
-    duration_ms INTEGER,
-    started_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-    completed_at TIMESTAMP WITH TIME ZONE
-);
-
-CREATE INDEX idx_test_runs_status ON test_runs(status);
-CREATE INDEX idx_test_runs_started_at ON test_runs(started_at);
-`
-}
+// exampleTemplateData describes the examples of the project for the first sql[] entry
+// of its config. It reports false when the config has no sql entries.
+func exampleTemplateData(cfg *CreateConfig) (generated.TemplateData, bool) {
+	if cfg.Config == nil || len(cfg.Config.SQL) == 0 {
+		return generated.TemplateData{}, false
+	}
 
-// createMultiTenantTables creates tables for multi-tenant projects.
-func (pc *ProjectCreator) createMultiTenantTables(data generated.TemplateData) string {
-	return `
--- Tenants table for multi-tenancy support
-CREATE TABLE tenants (
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    name VARCHAR(255) NOT NULL,
-    domain VARCHAR(255) NOT NULL UNIQUE,
-    settings JSONB,
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-);
-
-CREATE INDEX idx_tenants_domain ON tenants(domain);
-
--- Add tenant_id column to users
-ALTER TABLE users ADD COLUMN tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE;
-
-CREATE INDEX idx_users_tenant_id ON users(tenant_id);
-`
-}
+	sql := cfg.Config.SQL[0]
 
-// createLibraryTables creates tables for library projects.
-func (pc *ProjectCreator) createLibraryTables(data generated.TemplateData) string {
-	return `
--- Examples table for library examples
-CREATE TABLE examples (
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    title VARCHAR(255) NOT NULL,
-    description TEXT,
-    code_snippet TEXT NOT NULL,
-    language VARCHAR(50),
-    difficulty VARCHAR(50),
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-);
-
-CREATE INDEX idx_examples_language ON examples(language);
-CREATE INDEX idx_examples_difficulty ON examples(difficulty);
-`
+	return generated.TemplateData{
+		ProjectName: cfg.ProjectName,
+		ProjectType: cfg.ProjectType,
+		Database:    generated.DatabaseConfig{Engine: cfg.Database},
+		Output: generated.OutputConfig{
+			QueriesDir: sql.Queries.First(),
+			SchemaDir:  sql.Schema.First(),
+		},
+	}, true
 }
-
-// NOTE: Additional scaffolding methods will be implemented based on demand
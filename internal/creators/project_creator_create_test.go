@@ -3,6 +3,11 @@ package creators_test
 import (
 	"context"
 	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/LarsArtmann/SQLC-Wizzard/generated"
 	"github.com/LarsArtmann/SQLC-Wizzard/internal/creators"
@@ -46,10 +51,14 @@ var _ = Describe("ProjectCreator", func() {
 			// Verify directories were created
 			Expect(mockFS.mkdirAllCalls).NotTo(BeEmpty())
 
-			// Verify sqlc.yaml and schema.sql were written
-			Expect(mockFS.writeFileCalls).To(HaveLen(2))
+			// Verify sqlc.yaml, the schema, the queries and the Go module were written
+			Expect(mockFS.writeFileCalls).To(HaveLen(6))
 			Expect(mockFS.writeFileCalls[0].Path).To(Equal("sqlc.yaml"))
-			Expect(mockFS.writeFileCalls[1].Path).To(Equal("schema.sql"))
+			Expect(mockFS.writeFileCalls[1].Path).To(Equal("schema/001_users_table.sql"))
+			Expect(mockFS.writeFileCalls[2].Path).To(Equal("queries/users.sql"))
+			Expect(mockFS.writeFileCalls[3].Path).To(Equal("go.mod"))
+			Expect(mockFS.writeFileCalls[4].Path).To(Equal("internal/db/doc.go"))
+			Expect(mockFS.writeFileCalls[5].Path).To(Equal("cmd/server/main.go"))
 
 			// Verify CLI output
 			Expect(mockCLI.printedLines).NotTo(BeEmpty())
@@ -114,10 +123,11 @@ var _ = Describe("ProjectCreator", func() {
 
 			Expect(err).NotTo(HaveOccurred())
 
-			// Verify sqlc.yaml and schema.sql use 0644 permissions
-			Expect(mockFS.writeFileCalls).To(HaveLen(2))
-			Expect(mockFS.writeFileCalls[0].Perm).To(Equal(fs.FileMode(0o644)))
-			Expect(mockFS.writeFileCalls[1].Perm).To(Equal(fs.FileMode(0o644)))
+			// Verify all files use 0644 permissions
+			Expect(mockFS.writeFileCalls).To(HaveLen(6))
+			for _, call := range mockFS.writeFileCalls {
+				Expect(call.Perm).To(Equal(fs.FileMode(0o644)))
+			}
 		})
 
 		It("should write valid YAML config", func() {
@@ -126,7 +136,7 @@ var _ = Describe("ProjectCreator", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			// Verify YAML and schema were written
-			Expect(mockFS.writeFileCalls).To(HaveLen(2))
+			Expect(mockFS.writeFileCalls).To(HaveLen(6))
 			yamlContent := string(mockFS.writeFileCalls[0].Content)
 			schemaContent := string(mockFS.writeFileCalls[1].Content)
 
@@ -135,10 +145,157 @@ var _ = Describe("ProjectCreator", func() {
 			Expect(yamlContent).To(ContainSubstring("sql:"))
 
 			// Schema validation
-			Expect(schemaContent).To(ContainSubstring("CREATE TABLE users"))
+			Expect(schemaContent).To(ContainSubstring("CREATE TABLE IF NOT EXISTS users"))
 			Expect(schemaContent).To(ContainSubstring("Database schema for"))
 		})
 
+		It("should create the schema and queries directories of sqlc.yaml", func() {
+			err := creator.CreateProject(ctx, cfg)
+
+			Expect(err).NotTo(HaveOccurred())
+
+			dirPaths := make([]string, len(mockFS.mkdirAllCalls))
+			for i, call := range mockFS.mkdirAllCalls {
+				dirPaths[i] = call.Path
+			}
+
+			Expect(dirPaths).To(ContainElement("schema"))
+			Expect(dirPaths).To(ContainElement("queries"))
+		})
+
+		It("should write the example schema and queries of the project type", func() {
+			cfg.ProjectType = generated.ProjectTypeAnalytics
+			cfg.Database = generated.DatabaseTypeMySQL
+
+			err := creator.CreateProject(ctx, cfg)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockFS.writeFileCalls).To(HaveLen(6))
+			Expect(mockFS.writeFileCalls[1].Path).To(Equal("schema/001_events_schema.sql"))
+			Expect(mockFS.writeFileCalls[2].Path).To(Equal("queries/events.sql"))
+
+			schemaContent := string(mockFS.writeFileCalls[1].Content)
+			Expect(schemaContent).To(ContainSubstring("CREATE TABLE IF NOT EXISTS events"))
+			Expect(schemaContent).To(ContainSubstring("AUTO_INCREMENT"))
+			Expect(schemaContent).NotTo(ContainSubstring("CONCURRENTLY"))
+
+			queriesContent := string(mockFS.writeFileCalls[2].Content)
+			Expect(queriesContent).To(ContainSubstring("-- name: CountEventsByHour :many"))
+			Expect(queriesContent).To(ContainSubstring("DATE_FORMAT(occurred_at"))
+		})
+
+		DescribeTable("should require the driver of the engine and sql_package in go.mod",
+			func(database generated.DatabaseType, sqlPackage, require, driverImport string) {
+				cfg.Database = database
+				cfg.Config.SQL[0].Engine = string(database)
+				cfg.Config.SQL[0].Gen.Go.SQLPackage = sqlPackage
+
+				Expect(creator.CreateProject(ctx, cfg)).To(Succeed())
+
+				Expect(mockFS.written("go.mod")).To(HavePrefix("module test-project\n"))
+				Expect(mockFS.written("go.mod")).To(ContainSubstring("require " + require))
+				Expect(mockFS.written("cmd/server/main.go")).To(ContainSubstring(driverImport))
+			},
+			Entry("PostgreSQL with pgx/v5", generated.DatabaseTypePostgreSQL, "pgx/v5",
+				"github.com/jackc/pgx/v5 ", `"github.com/jackc/pgx/v5/pgxpool"`),
+			Entry("PostgreSQL with pgx/v4", generated.DatabaseTypePostgreSQL, "pgx/v4",
+				"github.com/jackc/pgx/v4 ", `"github.com/jackc/pgx/v4/pgxpool"`),
+			Entry("PostgreSQL with database/sql", generated.DatabaseTypePostgreSQL, "",
+				"github.com/jackc/pgx/v5 ", `_ "github.com/jackc/pgx/v5/stdlib"`),
+			Entry("MySQL", generated.DatabaseTypeMySQL, "database/sql",
+				"github.com/go-sql-driver/mysql ", `_ "github.com/go-sql-driver/mysql"`),
+			Entry("SQLite", generated.DatabaseTypeSQLite, "database/sql",
+				"modernc.org/sqlite ", `_ "modernc.org/sqlite"`),
+		)
+
+		It("should construct the generated queries in cmd/server/main.go", func() {
+			cfg.Config.SQL[0].Gen.Go.Package = "store"
+
+			Expect(creator.CreateProject(ctx, cfg)).To(Succeed())
+
+			main := mockFS.written("cmd/server/main.go")
+			Expect(main).To(HavePrefix("// Command server"))
+			Expect(main).To(ContainSubstring(`store "test-project/internal/db"`))
+			Expect(main).To(ContainSubstring(`sql.Open("pgx", url)`))
+			Expect(main).To(ContainSubstring("queries := store.New(conn)"))
+
+			doc := mockFS.written("internal/db/doc.go")
+			Expect(doc).To(ContainSubstring("\npackage store\n"))
+			Expect(doc).To(ContainSubstring("//go:generate sqlc generate -f ../../sqlc.yaml"))
+		})
+
+		DescribeTable("should render a main.go that compiles against the generated package",
+			func(database generated.DatabaseType, module, driverImport string) {
+				goBin, err := exec.LookPath("go")
+				if err != nil {
+					Skip("go toolchain not found")
+				}
+
+				cfg.Database = database
+				cfg.Config.SQL[0].Engine = string(database)
+				cfg.Config.SQL[0].Gen.Go.SQLPackage = "database/sql"
+
+				Expect(creator.CreateProject(ctx, cfg)).To(Succeed())
+				Expect(mockCLI.printedLines).To(ContainElement(ContainSubstring("go generate ./... && go mod tidy")))
+
+				root := GinkgoT().TempDir()
+				for _, call := range mockFS.writeFileCalls {
+					if strings.HasSuffix(call.Path, ".go") {
+						writeProjectFile(root, call.Path, string(call.Content))
+					}
+				}
+
+				// Stand-ins for the driver module and the package sqlc generates, so the
+				// build needs no network and no sqlc.
+				driverDir := filepath.Join(root, "stub", "driver")
+				writeProjectFile(driverDir, "go.mod", "module "+module+"\n\ngo 1.24\n")
+				writeProjectFile(driverDir, filepath.Join(strings.TrimPrefix(driverImport, module), "driver.go"),
+					"package "+path.Base(driverImport)+"\n")
+				writeProjectFile(root, "go.mod",
+					mockFS.written("go.mod")+"\nreplace "+module+" => ./stub/driver\n")
+				writeProjectFile(root, "internal/db/db.go", `package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+type Queries struct {
+	db DBTX
+}
+`)
+
+				build := exec.Command(goBin, "build", "-o", os.DevNull, "./...")
+				build.Dir = root
+				build.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off", "GOTOOLCHAIN=local")
+				out, err := build.CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), string(out))
+			},
+			Entry("PostgreSQL", generated.DatabaseTypePostgreSQL, "github.com/jackc/pgx/v5", "github.com/jackc/pgx/v5/stdlib"),
+			Entry("MySQL", generated.DatabaseTypeMySQL, "github.com/go-sql-driver/mysql", "github.com/go-sql-driver/mysql"),
+			Entry("SQLite", generated.DatabaseTypeSQLite, "modernc.org/sqlite", "modernc.org/sqlite"),
+		)
+
+		It("should reject a sql_package the engine has no driver for", func() {
+			cfg.Database = generated.DatabaseTypeMySQL
+			cfg.Config.SQL[0].Gen.Go.SQLPackage = "pgx/v5"
+
+			err := creator.CreateProject(ctx, cfg)
+
+			Expect(err).To(MatchError(ContainSubstring(`no Go driver for engine "mysql" with sql_package "pgx/v5"`)))
+		})
+
 		It("should fail when directory creation fails", func() {
 			mockFS.shouldFailMkdir = true
 
@@ -203,3 +360,10 @@ var _ = Describe("ProjectCreator", func() {
 			func(cfg *creators.CreateConfig) generated.DatabaseType { return cfg.Database })
 	})
 })
+
+// writeProjectFile writes content to name below root, creating its directories.
+func writeProjectFile(root, name, content string) {
+	file := filepath.Join(root, filepath.FromSlash(name))
+	Expect(os.MkdirAll(filepath.Dir(file), 0o755)).To(Succeed())
+	Expect(os.WriteFile(file, []byte(content), 0o644)).To(Succeed())
+}
@@ -37,7 +37,7 @@ var _ = Describe("ProjectCreator Integration and Error Handling", func() {
 
 			// Verify order: directories first, then files
 			Expect(mockFS.mkdirAllCalls).NotTo(BeEmpty())
-			Expect(mockFS.writeFileCalls).To(HaveLen(2))
+			Expect(mockFS.writeFileCalls).To(HaveLen(6))
 
 			// Verify that all mkdir calls occur before any write calls
 			// by checking that no "write:" entries appear before any "mkdir:" entries
@@ -0,0 +1,104 @@
+package diagnostics
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/lint"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/migration"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+)
+
+// configCodePrefix prefixes codes derived from config field paths, e.g. "config/sql.engine".
+const configCodePrefix = "config/"
+
+var (
+	// fileLineField matches validation fields that point into a file ("queries/users.sql:12").
+	fileLineField = regexp.MustCompile(`^(.+):(\d+)$`)
+	// fieldIndex matches the sequence indexes of a field path ("sql[0]").
+	fieldIndex = regexp.MustCompile(`\[\d+\]`)
+)
+
+// Locator returns the position of a config field in the config file; 0, 0 means unknown.
+type Locator func(field string) (line, column int)
+
+// FromValidation converts a validation result. Config field findings are attributed
+// to configFile and positioned with locate when it is non-nil; findings whose field is
+// "file:line" keep that location. Fields without a check code get one derived from the
+// field path with indexes removed, e.g. "config/sql.gen.go.package".
+func FromValidation(result *config.ValidationResult, configFile string, locate Locator) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0, len(result.Errors)+len(result.Warnings))
+
+	convert := func(items []config.ValidationError, severity apperrors.ErrorSeverity) {
+		for _, item := range items {
+			d := Diagnostic{Code: item.Code, Severity: severity, Message: item.Message}
+
+			if match := fileLineField.FindStringSubmatch(item.Field); match != nil {
+				d.File = match[1]
+				d.Line, _ = strconv.Atoi(match[2])
+			} else {
+				d.File = configFile
+				d.Message = item.Field + ": " + item.Message
+
+				if locate != nil {
+					d.Line, d.Column = locate(item.Field)
+				}
+
+				if d.Code == "" {
+					d.Code = configCodePrefix + fieldIndex.ReplaceAllString(item.Field, "")
+				}
+			}
+
+			if item.Fix != nil {
+				d.Hint = "run validate --fix to " + item.Fix.Description
+			}
+
+			diagnostics = append(diagnostics, d)
+		}
+	}
+
+	convert(result.Errors, apperrors.ErrorSeverityError)
+	convert(result.Warnings, apperrors.ErrorSeverityWarning)
+
+	return diagnostics
+}
+
+// FromLint converts lint findings; the rule name becomes the code.
+func FromLint(result *lint.Result) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0, len(result.Diagnostics))
+
+	for _, finding := range result.Diagnostics {
+		message := finding.Message
+		if finding.Query != "" {
+			message = finding.Query + ": " + message
+		}
+
+		diagnostics = append(diagnostics, Diagnostic{
+			Code:     finding.Rule,
+			Severity: finding.Severity,
+			File:     finding.File,
+			Line:     finding.Line,
+			Column:   finding.Column,
+			Message:  message,
+		})
+	}
+
+	return diagnostics
+}
+
+// FromMigrationDirectory converts the issues of a migrations directory check.
+func FromMigrationDirectory(report *migration.DirectoryReport) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0, len(report.Issues))
+
+	for _, issue := range report.Issues {
+		diagnostics = append(diagnostics, Diagnostic{
+			Code:     issue.Code,
+			Severity: issue.Severity,
+			File:     issue.File,
+			Message:  issue.Message,
+		})
+	}
+
+	return diagnostics
+}
@@ -0,0 +1,170 @@
+package diagnostics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+)
+
+// Format selects how diagnostics are rendered.
+type Format string
+
+const (
+	FormatText   Format = "text"
+	FormatJSON   Format = "json"
+	FormatSARIF  Format = "sarif"
+	FormatGitHub Format = "github"
+)
+
+// AllFormats returns every supported output format.
+func AllFormats() []Format {
+	return []Format{FormatText, FormatJSON, FormatSARIF, FormatGitHub}
+}
+
+// IsValid returns true if the format is supported.
+func (f Format) IsValid() bool {
+	switch f {
+	case FormatText, FormatJSON, FormatSARIF, FormatGitHub:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsText returns true for the human-readable format.
+func (f Format) IsText() bool {
+	return f == FormatText || f == ""
+}
+
+// ParseFormat converts a --format value into a Format.
+func ParseFormat(value string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(value)))
+	if format == "" {
+		return FormatText, nil
+	}
+
+	if !format.IsValid() {
+		names := make([]string, 0, len(AllFormats()))
+		for _, f := range AllFormats() {
+			names = append(names, string(f))
+		}
+
+		return "", apperrors.Newf(
+			apperrors.ErrorCodeInvalidValue,
+			"unknown output format %q (must be one of: %s)",
+			value, strings.Join(names, ", "),
+		)
+	}
+
+	return format, nil
+}
+
+// Diagnostic is a single finding with a stable code and an optional location.
+type Diagnostic struct {
+	// Code identifies the check, e.g. "no-select-star" or "config/sql.gen.go.package".
+	Code     string                  `json:"code"`
+	Severity apperrors.ErrorSeverity `json:"severity"`
+	File     string                  `json:"file,omitempty"`
+	Line     int                     `json:"line,omitempty"`
+	Column   int                     `json:"column,omitempty"`
+	Message  string                  `json:"message"`
+	// Hint describes how to resolve the finding, if known.
+	Hint string `json:"hint,omitempty"`
+}
+
+// IsError returns true for error and critical findings.
+func (d Diagnostic) IsError() bool {
+	return d.Severity == apperrors.ErrorSeverityError || d.Severity == apperrors.ErrorSeverityCritical
+}
+
+// Location formats the position as "file:line:col", omitting unknown parts.
+func (d Diagnostic) Location() string {
+	switch {
+	case d.File == "":
+		return ""
+	case d.Line == 0:
+		return d.File
+	case d.Column == 0:
+		return fmt.Sprintf("%s:%d", d.File, d.Line)
+	default:
+		return fmt.Sprintf("%s:%d:%d", d.File, d.Line, d.Column)
+	}
+}
+
+// String formats the diagnostic as "file:line:col: severity [code] message".
+func (d Diagnostic) String() string {
+	var b strings.Builder
+
+	if location := d.Location(); location != "" {
+		b.WriteString(location + ": ")
+	}
+
+	fmt.Fprintf(&b, "%s [%s] %s", d.Severity, d.Code, d.Message)
+
+	if d.Hint != "" {
+		b.WriteString(" (hint: " + d.Hint + ")")
+	}
+
+	return b.String()
+}
+
+// Report is the output of one command run.
+type Report struct {
+	// Tool is the command that produced the report, e.g. "validate".
+	Tool        string       `json:"tool"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// NewReport creates an empty report for a command.
+func NewReport(tool string) *Report {
+	return &Report{Tool: tool, Diagnostics: []Diagnostic{}}
+}
+
+// Add appends diagnostics to the report.
+func (r *Report) Add(diagnostics ...Diagnostic) {
+	r.Diagnostics = append(r.Diagnostics, diagnostics...)
+}
+
+// ErrorCount returns the number of error and critical findings.
+func (r *Report) ErrorCount() int {
+	n := 0
+
+	for _, d := range r.Diagnostics {
+		if d.IsError() {
+			n++
+		}
+	}
+
+	return n
+}
+
+// WarningCount returns the number of warnings.
+func (r *Report) WarningCount() int {
+	n := 0
+
+	for _, d := range r.Diagnostics {
+		if d.Severity == apperrors.ErrorSeverityWarning {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Sort orders diagnostics by file, line and column; findings without a file come first.
+func (r *Report) Sort() {
+	sort.SliceStable(r.Diagnostics, func(i, j int) bool {
+		a, b := r.Diagnostics[i], r.Diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+
+		return a.Column < b.Column
+	})
+}
@@ -0,0 +1,229 @@
+package diagnostics_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/diagnostics"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/lint"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/migration"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDiagnostics(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Diagnostics Suite")
+}
+
+func sampleReport() *diagnostics.Report {
+	report := diagnostics.NewReport("validate")
+	report.Add(
+		diagnostics.Diagnostic{
+			Code:     "no-select-star",
+			Severity: apperrors.ErrorSeverityWarning,
+			File:     "queries/users.sql",
+			Line:     4,
+			Column:   1,
+			Message:  "GetUser: avoid SELECT *",
+		},
+		diagnostics.Diagnostic{
+			Code:     "config/sql.gen.go.package",
+			Severity: apperrors.ErrorSeverityError,
+			File:     "sqlc.yaml",
+			Line:     9,
+			Column:   9,
+			Message:  "sql[0].gen.go.package: package name is required",
+			Hint:     "run validate --fix to set package from out",
+		},
+	)
+
+	return report
+}
+
+func render(format diagnostics.Format, report *diagnostics.Report) string {
+	var buf bytes.Buffer
+	Expect(diagnostics.Write(&buf, format, report)).To(Succeed())
+
+	return buf.String()
+}
+
+var _ = Describe("ParseFormat", func() {
+	It("should accept every supported format case-insensitively", func() {
+		for _, format := range diagnostics.AllFormats() {
+			parsed, err := diagnostics.ParseFormat(string(format))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(format))
+		}
+
+		parsed, err := diagnostics.ParseFormat(" SARIF ")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(diagnostics.FormatSARIF))
+	})
+
+	It("should default to text and reject unknown formats", func() {
+		parsed, err := diagnostics.ParseFormat("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.IsText()).To(BeTrue())
+
+		_, err = diagnostics.ParseFormat("xml")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("text, json, sarif, github"))
+	})
+})
+
+var _ = Describe("Report", func() {
+	It("should count and sort diagnostics by file and line", func() {
+		report := sampleReport()
+		report.Sort()
+
+		Expect(report.ErrorCount()).To(Equal(1))
+		Expect(report.WarningCount()).To(Equal(1))
+		Expect(report.Diagnostics[0].File).To(Equal("queries/users.sql"))
+		Expect(report.Diagnostics[1].File).To(Equal("sqlc.yaml"))
+	})
+})
+
+var _ = Describe("Write", func() {
+	It("should write JSON with a summary", func() {
+		var decoded struct {
+			Tool        string                   `json:"tool"`
+			Diagnostics []diagnostics.Diagnostic `json:"diagnostics"`
+			Summary     struct {
+				Errors   int `json:"errors"`
+				Warnings int `json:"warnings"`
+			} `json:"summary"`
+		}
+
+		Expect(json.Unmarshal([]byte(render(diagnostics.FormatJSON, sampleReport())), &decoded)).To(Succeed())
+		Expect(decoded.Tool).To(Equal("validate"))
+		Expect(decoded.Diagnostics).To(HaveLen(2))
+		Expect(decoded.Diagnostics[1].Hint).To(ContainSubstring("validate --fix"))
+		Expect(decoded.Summary.Errors).To(Equal(1))
+		Expect(decoded.Summary.Warnings).To(Equal(1))
+	})
+
+	It("should write an empty diagnostics array rather than null", func() {
+		out := render(diagnostics.FormatJSON, diagnostics.NewReport("lint"))
+		Expect(out).To(ContainSubstring(`"diagnostics": []`))
+	})
+
+	It("should write SARIF 2.1.0 with rules, levels and regions", func() {
+		var log map[string]any
+		Expect(json.Unmarshal([]byte(render(diagnostics.FormatSARIF, sampleReport())), &log)).To(Succeed())
+		Expect(log["version"]).To(Equal("2.1.0"))
+
+		run := log["runs"].([]any)[0].(map[string]any)
+		rules := run["tool"].(map[string]any)["driver"].(map[string]any)["rules"].([]any)
+		Expect(rules).To(HaveLen(2))
+
+		results := run["results"].([]any)
+		Expect(results).To(HaveLen(2))
+
+		first := results[0].(map[string]any)
+		Expect(first["ruleId"]).To(Equal("no-select-star"))
+		Expect(first["level"]).To(Equal("warning"))
+
+		location := first["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)
+		Expect(location["artifactLocation"].(map[string]any)["uri"]).To(Equal("queries/users.sql"))
+		Expect(location["region"].(map[string]any)["startLine"]).To(BeNumerically("==", 4))
+
+		second := results[1].(map[string]any)
+		Expect(second["level"]).To(Equal("error"))
+		Expect(second["properties"].(map[string]any)["hint"]).To(ContainSubstring("validate --fix"))
+	})
+
+	It("should write GitHub workflow commands with escaped data", func() {
+		out := render(diagnostics.FormatGitHub, sampleReport())
+
+		Expect(out).To(ContainSubstring(
+			"::warning file=queries/users.sql,line=4,col=1,title=no-select-star::GetUser: avoid SELECT *\n",
+		))
+		Expect(out).To(ContainSubstring(
+			"::error file=sqlc.yaml,line=9,col=9,title=config/sql.gen.go.package::" +
+				"sql[0].gen.go.package: package name is required%0AHint: run validate --fix to set package from out\n",
+		))
+	})
+})
+
+var _ = Describe("FromValidation", func() {
+	It("should position config fields and keep file:line fields", func() {
+		result := &config.ValidationResult{}
+		result.AddError("sql[0].engine", "engine is required")
+		result.AddWarningWithCode(config.CheckNoSQLFiles, "sql[0].schema", "directory schema contains no .sql files")
+		result.AddErrorWithCode("duplicate-query-name", "queries/users.sql:7", "duplicate query name GetUser")
+
+		locate := func(field string) (int, int) {
+			if field == "sql[0].engine" {
+				return 3, 5
+			}
+
+			return 0, 0
+		}
+
+		items := diagnostics.FromValidation(result, "sqlc.yaml", locate)
+		Expect(items).To(HaveLen(3))
+
+		Expect(items[0].Code).To(Equal("config/sql.engine"))
+		Expect(items[0].File).To(Equal("sqlc.yaml"))
+		Expect(items[0].Line).To(Equal(3))
+		Expect(items[0].Column).To(Equal(5))
+		Expect(items[0].Message).To(Equal("sql[0].engine: engine is required"))
+
+		Expect(items[1].File).To(Equal("queries/users.sql"))
+		Expect(items[1].Line).To(Equal(7))
+		Expect(items[1].Code).To(Equal("duplicate-query-name"))
+
+		Expect(items[2].Code).To(Equal(config.CheckNoSQLFiles))
+		Expect(items[2].Severity).To(Equal(apperrors.ErrorSeverityWarning))
+	})
+
+	It("should turn fixes into hints", func() {
+		result := &config.ValidationResult{}
+		result.AddFixableError("version", "version is required", &config.Fix{Description: "set version to 2"})
+
+		items := diagnostics.FromValidation(result, "sqlc.yaml", nil)
+		Expect(items).To(HaveLen(1))
+		Expect(items[0].Hint).To(Equal("run validate --fix to set version to 2"))
+		Expect(items[0].Line).To(BeZero())
+	})
+})
+
+var _ = Describe("FromLint", func() {
+	It("should use the rule as code and prefix the query name", func() {
+		result := &lint.Result{Diagnostics: []lint.Diagnostic{{
+			Rule:     "no-select-star",
+			Severity: apperrors.ErrorSeverityWarning,
+			File:     "queries/users.sql",
+			Line:     2,
+			Query:    "ListUsers",
+			Message:  "avoid SELECT *",
+		}}}
+
+		items := diagnostics.FromLint(result)
+		Expect(items).To(HaveLen(1))
+		Expect(items[0].Code).To(Equal("no-select-star"))
+		Expect(items[0].Message).To(Equal("ListUsers: avoid SELECT *"))
+		Expect(items[0].Line).To(Equal(2))
+	})
+})
+
+var _ = Describe("FromMigrationDirectory", func() {
+	It("should keep the check code, severity and file", func() {
+		report := &migration.DirectoryReport{Issues: []migration.Issue{{
+			Code:     migration.CheckMissingDown,
+			Severity: apperrors.ErrorSeverityWarning,
+			File:     "db/migrations/1_users.up.sql",
+			Message:  "migration 1 has no down file and cannot be rolled back",
+		}}}
+
+		items := diagnostics.FromMigrationDirectory(report)
+		Expect(items).To(HaveLen(1))
+		Expect(items[0].Code).To(Equal("migration-missing-down"))
+		Expect(items[0].IsError()).To(BeFalse())
+		Expect(items[0].Location()).To(Equal("db/migrations/1_users.up.sql"))
+	})
+})
@@ -0,0 +1,4 @@
+// Package diagnostics provides the shared finding model of validate, lint and doctor
+// and renders it as text, JSON, SARIF 2.1.0 or GitHub Actions workflow commands,
+// so CI pipelines can annotate pull requests and gate merges on rule codes.
+package diagnostics
@@ -0,0 +1,227 @@
+package diagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+)
+
+// SARIF identifiers for the tool that produced the results.
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "sqlc-wizard"
+	toolURI      = "https://github.com/LarsArtmann/SQLC-Wizzard"
+)
+
+// Write renders the report in the given format.
+func Write(w io.Writer, format Format, report *Report) error {
+	switch format {
+	case FormatText, "":
+		return writeText(w, report)
+	case FormatJSON:
+		return writeJSON(w, jsonReport{
+			Report:  report,
+			Summary: jsonSummary{Errors: report.ErrorCount(), Warnings: report.WarningCount()},
+		})
+	case FormatSARIF:
+		return writeJSON(w, sarifLog(report))
+	case FormatGitHub:
+		return writeGitHub(w, report)
+	default:
+		return apperrors.Newf(apperrors.ErrorCodeInvalidValue, "unknown output format %q", format)
+	}
+}
+
+func writeText(w io.Writer, report *Report) error {
+	for _, d := range report.Diagnostics {
+		if _, err := fmt.Fprintln(w, d.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode diagnostics: %w", err)
+	}
+
+	return nil
+}
+
+type jsonReport struct {
+	*Report
+
+	Summary jsonSummary `json:"summary"`
+}
+
+type jsonSummary struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+}
+
+// writeGitHub emits GitHub Actions workflow commands (::error file=...::message),
+// which the runner turns into pull request annotations.
+func writeGitHub(w io.Writer, report *Report) error {
+	for _, d := range report.Diagnostics {
+		var props []string
+
+		if d.File != "" {
+			props = append(props, "file="+escapeGitHubProperty(filepath.ToSlash(d.File)))
+		}
+
+		if d.Line > 0 {
+			props = append(props, fmt.Sprintf("line=%d", d.Line))
+		}
+
+		if d.Column > 0 {
+			props = append(props, fmt.Sprintf("col=%d", d.Column))
+		}
+
+		props = append(props, "title="+escapeGitHubProperty(d.Code))
+
+		message := d.Message
+		if d.Hint != "" {
+			message += "\nHint: " + d.Hint
+		}
+
+		_, err := fmt.Fprintf(w, "::%s %s::%s\n",
+			githubLevel(d.Severity), strings.Join(props, ","), escapeGitHubData(message))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func githubLevel(severity apperrors.ErrorSeverity) string {
+	switch severity {
+	case apperrors.ErrorSeverityError, apperrors.ErrorSeverityCritical:
+		return "error"
+	case apperrors.ErrorSeverityWarning:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// SARIF 2.1.0 subset understood by GitHub code scanning.
+type (
+	sarifRoot struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+	}
+	sarifResult struct {
+		RuleID     string            `json:"ruleId"`
+		Level      string            `json:"level"`
+		Message    sarifMessage      `json:"message"`
+		Locations  []sarifLocation   `json:"locations,omitempty"`
+		Properties map[string]string `json:"properties,omitempty"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifact `json:"artifactLocation"`
+		Region           *sarifRegion  `json:"region,omitempty"`
+	}
+	sarifArtifact struct {
+		URI string `json:"uri"`
+	}
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+)
+
+func sarifLog(report *Report) sarifRoot {
+	driver := sarifDriver{Name: toolName, InformationURI: toolURI, Rules: []sarifRule{}}
+	seen := map[string]bool{}
+	results := make([]sarifResult, 0, len(report.Diagnostics))
+
+	for _, d := range report.Diagnostics {
+		if !seen[d.Code] {
+			seen[d.Code] = true
+			driver.Rules = append(driver.Rules, sarifRule{ID: d.Code, ShortDescription: sarifMessage{Text: d.Code}})
+		}
+
+		result := sarifResult{
+			RuleID:  d.Code,
+			Level:   sarifLevel(d.Severity),
+			Message: sarifMessage{Text: d.Message},
+		}
+
+		if d.File != "" {
+			location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifact{URI: filepath.ToSlash(d.File)},
+			}}
+
+			if d.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: d.Line, StartColumn: d.Column}
+			}
+
+			result.Locations = []sarifLocation{location}
+		}
+
+		if d.Hint != "" {
+			result.Properties = map[string]string{"hint": d.Hint}
+		}
+
+		results = append(results, result)
+	}
+
+	return sarifRoot{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+}
+
+func sarifLevel(severity apperrors.ErrorSeverity) string {
+	switch severity {
+	case apperrors.ErrorSeverityError, apperrors.ErrorSeverityCritical:
+		return "error"
+	case apperrors.ErrorSeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
@@ -0,0 +1,546 @@
+package dialect
+
+import (
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+)
+
+// columnConstraints are the keywords that end the type of a column definition.
+var columnConstraints = map[string]bool{
+	"NOT": true, "NULL": true, "DEFAULT": true, "PRIMARY": true, "UNIQUE": true,
+	"REFERENCES": true, "CHECK": true, "CONSTRAINT": true, "GENERATED": true,
+	"AUTO_INCREMENT": true, "AUTOINCREMENT": true, "COLLATE": true, "ON": true,
+	"COMMENT": true, "AS": true, "CHARSET": true,
+}
+
+// tableConstraints start a table element that is not a column.
+var tableConstraints = map[string]bool{
+	"CONSTRAINT": true, "PRIMARY": true, "UNIQUE": true, "FOREIGN": true, "CHECK": true,
+	"INDEX": true, "KEY": true, "FULLTEXT": true, "SPATIAL": true, "EXCLUDE": true,
+}
+
+// unsupportedIndexMethods are the PostgreSQL index methods without a MySQL or SQLite equivalent.
+var unsupportedIndexMethods = map[string]bool{"gin": true, "gist": true, "brin": true, "spgist": true}
+
+// createTable translates the column types, inline indexes and table options of CREATE TABLE.
+func (t *translation) createTable(tokens []sqlparse.Token) {
+	open := -1
+
+	for i, tok := range tokens {
+		if tok.Is("AS") {
+			return // CREATE TABLE ... AS SELECT
+		}
+
+		if tok.IsPunct("(") {
+			open = i
+
+			break
+		}
+	}
+
+	if open < 0 {
+		return
+	}
+
+	closing := closingParen(tokens, open)
+	if closing < 0 {
+		return
+	}
+
+	name := 1
+	for !tokens[name].Is("TABLE") {
+		name++
+	}
+
+	name++
+	if matchWords(tokens, name, "IF", "NOT", "EXISTS") {
+		name += 3
+	}
+
+	table := tokens[name:open]
+	elements := sqlparse.SplitTopLevel(tokens[open+1 : closing])
+	keyed := keyedColumns(elements)
+
+	var indexes []string
+
+	for k, element := range elements {
+		if len(element) == 0 {
+			continue
+		}
+
+		if isTableConstraint(element) {
+			if index := t.tableConstraint(elements, k, table); index != "" {
+				indexes = append(indexes, index)
+			}
+
+			continue
+		}
+
+		t.columnDefinition(element, keyed[strings.ToLower(element[0].Value)])
+	}
+
+	if closing+1 < len(tokens) {
+		t.tableOptions(tokens, closing+1)
+	}
+
+	if len(indexes) > 0 {
+		t.insert(tokens[len(tokens)-1].End, ";\n\n"+strings.Join(indexes, ";\n\n"))
+	}
+}
+
+// tableOptions handles the options after the column list, such as ENGINE=InnoDB or WITHOUT ROWID.
+func (t *translation) tableOptions(tokens []sqlparse.Token, start int) {
+	if t.from == EnginePostgreSQL {
+		t.note(tokens[start], "table-options", "table options %q have no %s equivalent and were kept",
+			sqlparse.SourceText(t.src, tokens[start:]), t.to)
+
+		return
+	}
+
+	t.remove(tokens, start, len(tokens))
+}
+
+// keyedColumns returns the lower-cased names of columns used in table-level keys.
+func keyedColumns(elements [][]sqlparse.Token) map[string]bool {
+	keyed := map[string]bool{}
+
+	for _, element := range elements {
+		if len(element) == 0 || !isTableConstraint(element) {
+			continue
+		}
+
+		if topLevelWord(element, "KEY") < 0 && topLevelWord(element, "UNIQUE") < 0 {
+			continue
+		}
+
+		for i, tok := range element {
+			if !tok.IsPunct("(") {
+				continue
+			}
+
+			for _, column := range element[i+1 : max(closingParen(element, i), i+1)] {
+				if column.IsIdent() {
+					keyed[strings.ToLower(column.Value)] = true
+				}
+			}
+
+			break
+		}
+	}
+
+	return keyed
+}
+
+// isTableConstraint reports whether a table element is a constraint or index rather than a column.
+func isTableConstraint(element []sqlparse.Token) bool {
+	if element[0].Kind != sqlparse.TokenWord || !tableConstraints[element[0].Upper()] {
+		return false
+	}
+
+	// "key TEXT" and "index INT" are columns; MySQL's KEY and INDEX are followed by a name or columns.
+	if element[0].Is("KEY") || element[0].Is("INDEX") {
+		return len(element) > 1 && (element[1].IsPunct("(") || len(element) > 2 && element[2].IsPunct("("))
+	}
+
+	return true
+}
+
+// tableConstraint translates a table-level constraint. Inline MySQL indexes are removed
+// and returned as a CREATE INDEX statement to add after the table.
+func (t *translation) tableConstraint(elements [][]sqlparse.Token, k int, table []sqlparse.Token) string {
+	element := elements[k]
+
+	switch {
+	case element[0].Is("EXCLUDE") && t.to != EnginePostgreSQL:
+		t.note(element[0], "constraint", "EXCLUDE constraints have no %s equivalent", t.to)
+	case t.to == EngineMySQL:
+	case element[0].Is("FULLTEXT") || element[0].Is("SPATIAL"):
+		t.removeElement(elements, k)
+		t.note(element[0], "index", "%s indexes have no %s equivalent and were removed", element[0].Upper(), t.to)
+	case element[0].Is("INDEX") || element[0].Is("KEY"):
+		name, columns := t.indexParts(element[1:])
+		if columns == nil {
+			return ""
+		}
+
+		if name == "" {
+			name = indexName(table, columns)
+		}
+
+		t.removeElement(elements, k)
+
+		return "CREATE INDEX " + name + " ON " + t.spanText(table) + " " + t.spanText(columns)
+	case element[0].Is("UNIQUE") && len(element) > 1 && (element[1].Is("INDEX") || element[1].Is("KEY")):
+		name, columns := t.indexParts(element[2:])
+		if columns == nil {
+			return ""
+		}
+
+		text := "UNIQUE " + t.spanText(columns)
+		if name != "" {
+			text = "CONSTRAINT " + name + " " + text
+		}
+
+		t.consume(element, text)
+	}
+
+	return ""
+}
+
+// indexParts splits "[name] (columns)" of a MySQL inline index.
+func (t *translation) indexParts(tokens []sqlparse.Token) (string, []sqlparse.Token) {
+	var name string
+
+	if len(tokens) > 1 && tokens[0].IsIdent() && tokens[1].IsPunct("(") {
+		name = t.text(tokens[0])
+		tokens = tokens[1:]
+	}
+
+	if len(tokens) == 0 || !tokens[0].IsPunct("(") {
+		return "", nil
+	}
+
+	closing := closingParen(tokens, 0)
+	if closing < 0 {
+		return "", nil
+	}
+
+	columns := tokens[:closing+1]
+	for _, tok := range columns[1:closing] {
+		if tok.IsPunct("(") {
+			t.note(tok, "index", "index prefix lengths are MySQL-only; remove the length from this index")
+
+			break
+		}
+	}
+
+	return name, columns
+}
+
+// indexName derives a name for an unnamed inline index, e.g. idx_users_email.
+func indexName(table, columns []sqlparse.Token) string {
+	parts := []string{"idx", table[len(table)-1].Value}
+
+	for _, tok := range columns {
+		if tok.IsIdent() {
+			parts = append(parts, tok.Value)
+		}
+	}
+
+	return strings.Join(parts, "_")
+}
+
+// removeElement deletes a table element together with its separating comma.
+func (t *translation) removeElement(elements [][]sqlparse.Token, k int) {
+	element := elements[k]
+	start, end := element[0].Pos.Offset, element[len(element)-1].End
+
+	switch {
+	case k > 0:
+		previous := elements[k-1]
+		start = previous[len(previous)-1].End
+	case len(elements) > 1:
+		end = elements[1][0].Pos.Offset
+	}
+
+	for _, tok := range element {
+		t.handled[tok.Pos.Offset] = true
+	}
+
+	t.edits = append(t.edits, edit{start: start, end: end})
+}
+
+// columnDefinition translates the type and attributes of a column definition.
+// keyed marks columns used in a table-level key.
+func (t *translation) columnDefinition(element []sqlparse.Token, keyed bool) {
+	if !element[0].IsIdent() {
+		return
+	}
+
+	end := 1
+	for end < len(element) && !isColumnConstraint(element, end) {
+		end++
+	}
+
+	var (
+		autoIncrement bool
+		primaryKey    = -1
+		defaultValue  = -1
+	)
+
+	for k := end; k < len(element); k++ {
+		tok := element[k]
+
+		switch {
+		case tok.Is("AUTO_INCREMENT") || tok.Is("AUTOINCREMENT"):
+			autoIncrement = true
+
+			t.remove(element, k, k+1)
+		case tok.Is("GENERATED"):
+			if identity := identityEnd(element, k); identity > 0 {
+				autoIncrement = true
+
+				t.remove(element, k, identity)
+				k = identity - 1
+			}
+		case matchWords(element, k, "PRIMARY", "KEY"):
+			primaryKey = k + 1
+			k++
+		case tok.Is("UNIQUE") || tok.Is("REFERENCES"):
+			keyed = true
+		case tok.Is("DEFAULT") && k+1 < len(element):
+			defaultValue = k + 1
+		case isOnUpdateTimestamp(element, k):
+			stop := k + 3
+			if stop < len(element) && element[stop].IsPunct("(") {
+				stop = closingParen(element, stop) + 1
+			}
+
+			if t.to != EngineMySQL {
+				t.remove(element, k, stop)
+				t.note(tok, "on-update",
+					"ON UPDATE CURRENT_TIMESTAMP is MySQL-only and was removed; set the column in UPDATE queries")
+			}
+
+			k = stop - 1
+		case tok.Is("COLLATE") && k+1 < len(element):
+			t.remove(element, k, k+2)
+			t.note(tok, "collation", "collation %s was removed; check the %s collation of this column",
+				element[k+1].Text, t.to)
+			k++
+		case matchWords(element, k, "CHARACTER", "SET") && k+2 < len(element):
+			if t.to != EngineMySQL {
+				t.remove(element, k, k+3)
+			}
+
+			k += 2
+		case (tok.Is("CHARSET") || tok.Is("COMMENT")) && k+1 < len(element):
+			if t.to != EngineMySQL {
+				t.remove(element, k, k+2)
+			}
+
+			k++
+		}
+	}
+
+	if end == 1 {
+		if t.to != EngineSQLite {
+			t.insert(element[0].End, " TEXT")
+			t.note(element[0], "type", "column %s has no type; declared as TEXT", element[0].Text)
+		}
+
+		return
+	}
+
+	typeTokens := element[1:end]
+	typeText := sqlparse.SourceText(t.src, typeTokens)
+	column := t.translateType(typeText)
+
+	// An INTEGER PRIMARY KEY is SQLite's auto-assigned rowid.
+	if t.from == EngineSQLite && primaryKey > 0 && strings.EqualFold(typeText, "INTEGER") {
+		autoIncrement = true
+	}
+
+	sql := column.SQL
+
+	switch {
+	case autoIncrement || column.Serial:
+		sql = t.autoIncrement(element, column.Kind, primaryKey, keyed)
+	case t.to == EngineMySQL && column.Kind == kindText && (primaryKey > 0 || keyed):
+		sql = "VARCHAR(255)"
+
+		t.note(typeTokens[0], "type", "MySQL cannot index TEXT without a prefix length; declared as VARCHAR(255)")
+	}
+
+	if sql != typeText {
+		t.consume(typeTokens, sql)
+	}
+
+	if column.Note != "" {
+		t.note(typeTokens[0], "type", "%s", column.Note)
+	}
+
+	if defaultValue > 0 {
+		t.defaultValue(element[defaultValue], column.Kind)
+	}
+}
+
+// autoIncrement renders an auto-incrementing integer column for the target engine.
+// keyed marks a column that is part of a table-level key.
+func (t *translation) autoIncrement(element []sqlparse.Token, kind typeKind, primaryKey int, keyed bool) string {
+	if _, ok := serialTypes[kind]; !ok {
+		kind = kindInt
+	}
+
+	switch t.to {
+	case EnginePostgreSQL:
+		return serialTypes[kind]
+	case EngineMySQL:
+		return integerTypes[EngineMySQL][kind] + " AUTO_INCREMENT"
+	default:
+		switch {
+		case primaryKey > 0:
+			t.insert(element[primaryKey].End, " AUTOINCREMENT")
+		case keyed:
+			// An INTEGER column in a table-level PRIMARY KEY is the rowid as well.
+		default:
+			t.note(element[0], "auto-increment",
+				"SQLite only auto-increments an INTEGER PRIMARY KEY; declare %s as the primary key", element[0].Text)
+		}
+
+		return "INTEGER"
+	}
+}
+
+// defaultValue adjusts a literal DEFAULT to the column type on the target engine.
+func (t *translation) defaultValue(tok sqlparse.Token, kind typeKind) {
+	switch {
+	case t.to == EnginePostgreSQL && kind == kindBoolean && tok.Kind == sqlparse.TokenNumber:
+		t.substitute(tok, map[bool]string{true: "false", false: "true"}[tok.Text == "0"])
+	case t.to == EngineMySQL && (kind == kindText || kind == kindJSON || kind == kindBlob) &&
+		(tok.Kind == sqlparse.TokenString || tok.Kind == sqlparse.TokenNumber):
+		// MySQL only accepts expression defaults, in parentheses, on TEXT, JSON and BLOB columns.
+		t.consume([]sqlparse.Token{tok}, "("+t.text(tok)+")")
+	}
+}
+
+// isColumnConstraint reports whether element[k] starts a column constraint.
+func isColumnConstraint(element []sqlparse.Token, k int) bool {
+	tok := element[k]
+
+	return tok.Kind == sqlparse.TokenWord &&
+		(columnConstraints[tok.Upper()] || matchWords(element, k, "CHARACTER", "SET"))
+}
+
+// identityEnd returns the index after "GENERATED ... AS IDENTITY [(options)]", or -1.
+func identityEnd(element []sqlparse.Token, k int) int {
+	var end int
+
+	switch {
+	case matchWords(element, k, "GENERATED", "ALWAYS", "AS", "IDENTITY"):
+		end = k + 4
+	case matchWords(element, k, "GENERATED", "BY", "DEFAULT", "AS", "IDENTITY"):
+		end = k + 5
+	default:
+		return -1
+	}
+
+	if end < len(element) && element[end].IsPunct("(") {
+		if closing := closingParen(element, end); closing > 0 {
+			end = closing + 1
+		}
+	}
+
+	return end
+}
+
+// isOnUpdateTimestamp matches MySQL's "ON UPDATE CURRENT_TIMESTAMP", not a foreign key action.
+func isOnUpdateTimestamp(element []sqlparse.Token, k int) bool {
+	return matchWords(element, k, "ON", "UPDATE") && k+2 < len(element) &&
+		(element[k+2].Is("CURRENT_TIMESTAMP") || element[k+2].Is("NOW"))
+}
+
+// alterTable translates the actions of ALTER TABLE.
+func (t *translation) alterTable(tokens []sqlparse.Token) {
+	i := 2
+	if matchWords(tokens, i, "IF", "EXISTS") {
+		i += 2
+	}
+
+	if matchWords(tokens, i, "ONLY") {
+		i++
+	}
+
+	i++ // table name
+	for i+1 < len(tokens) && tokens[i].IsPunct(".") {
+		i += 2
+	}
+
+	if i >= len(tokens) {
+		return
+	}
+
+	for _, action := range sqlparse.SplitTopLevel(tokens[i:]) {
+		if len(action) > 0 {
+			t.alterAction(action)
+		}
+	}
+}
+
+// alterAction translates a single ALTER TABLE action.
+func (t *translation) alterAction(action []sqlparse.Token) {
+	switch {
+	case action[0].Is("ADD"):
+		k := 1
+		if matchWords(action, k, "COLUMN") {
+			k++
+		}
+
+		if matchWords(action, k, "IF", "NOT", "EXISTS") {
+			if t.to == EngineMySQL {
+				t.remove(action, k, k+3)
+				t.note(action[k], "alter", "MySQL has no ADD COLUMN IF NOT EXISTS; the condition was removed")
+			}
+
+			k += 3
+		}
+
+		switch {
+		case k >= len(action):
+		case !isTableConstraint(action[k:]):
+			t.columnDefinition(action[k:], false)
+		case t.to == EngineSQLite:
+			t.note(action[0], "alter", "SQLite cannot add constraints to an existing table; recreate the table")
+		}
+	case action[0].Is("ALTER") || action[0].Is("MODIFY") || action[0].Is("CHANGE"):
+		switch {
+		case t.to == EngineSQLite:
+			t.note(action[0], "alter", "SQLite cannot change a column definition; recreate the table")
+		case t.to == EngineMySQL && (topLevelWord(action, "TYPE") > 0 || topLevelWord(action, "NOT") > 0):
+			t.note(action[0], "alter", "use MODIFY COLUMN with the full column definition in MySQL")
+		case t.to == EnginePostgreSQL && !action[0].Is("ALTER"):
+			t.note(action[0], "alter", "use ALTER COLUMN ... TYPE or RENAME COLUMN in PostgreSQL")
+		}
+	case matchWords(action, 0, "DROP", "CONSTRAINT") && t.to == EngineSQLite:
+		t.note(action[0], "alter", "SQLite cannot drop a constraint; recreate the table")
+	}
+}
+
+// createIndex translates CREATE INDEX options.
+func (t *translation) createIndex(tokens []sqlparse.Token) {
+	k := 2
+	if tokens[1].Is("UNIQUE") {
+		k = 3
+	}
+
+	if matchWords(tokens, k, "CONCURRENTLY") {
+		if t.to != EnginePostgreSQL {
+			t.remove(tokens, k, k+1)
+		}
+
+		k++
+	}
+
+	if matchWords(tokens, k, "IF", "NOT", "EXISTS") && t.to == EngineMySQL {
+		t.remove(tokens, k, k+3)
+		t.note(tokens[k], "index", "MySQL has no CREATE INDEX IF NOT EXISTS; the condition was removed")
+	}
+
+	if using := topLevelWord(tokens, "USING"); using > 0 && using+1 < len(tokens) {
+		method := strings.ToLower(tokens[using+1].Text)
+
+		switch {
+		case t.from == EngineMySQL:
+			t.remove(tokens, using, using+2)
+		case t.to != EnginePostgreSQL && unsupportedIndexMethods[method]:
+			t.remove(tokens, using, using+2)
+			t.note(tokens[using], "index", "%s indexes have no %s equivalent; created as a regular index", method, t.to)
+		}
+	}
+
+	if where := topLevelWord(tokens, "WHERE"); where > 0 && t.to == EngineMySQL {
+		t.remove(tokens, where, len(tokens))
+		t.note(tokens[where], "index", "MySQL has no partial indexes; the WHERE clause was removed")
+	}
+}
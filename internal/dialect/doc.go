@@ -0,0 +1,3 @@
+// Package dialect translates schema and query files between the sqlc engines
+// (PostgreSQL, MySQL and SQLite), reporting the constructs it cannot translate.
+package dialect
@@ -0,0 +1,552 @@
+package dialect
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+)
+
+// queryNamePattern matches the sqlc query annotation "-- name: GetUser :one".
+var queryNamePattern = regexp.MustCompile(`name:\s*\w+\s+(:\w+)`)
+
+// randomUUID is the expression generating a random UUID on each engine.
+var randomUUID = map[string]string{
+	EnginePostgreSQL: "gen_random_uuid()",
+	EngineMySQL:      "UUID()",
+	EngineSQLite:     "lower(hex(randomblob(16)))",
+}
+
+// dateTruncFormats truncate a timestamp to a date_trunc unit with DATE_FORMAT in MySQL
+// and strftime in SQLite.
+var dateTruncFormats = map[string]map[string]string{
+	EngineMySQL: {
+		"minute": "%Y-%m-%d %H:%i:00", "hour": "%Y-%m-%d %H:00:00", "day": "%Y-%m-%d 00:00:00",
+		"month": "%Y-%m-01 00:00:00", "year": "%Y-01-01 00:00:00",
+	},
+	EngineSQLite: {
+		"minute": "%Y-%m-%d %H:%M:00", "hour": "%Y-%m-%d %H:00:00", "day": "%Y-%m-%d 00:00:00",
+		"month": "%Y-%m-01 00:00:00", "year": "%Y-01-01 00:00:00",
+	},
+}
+
+// intervalPattern matches a single-unit PostgreSQL interval such as '1 day' or '-2 hours'.
+var intervalPattern = regexp.MustCompile(`^\s*([+-]?\d+)\s*(second|minute|hour|day|week|month|year)s?\s*$`)
+
+// castOperandWords continue a multi-word type name after "::", as in "::double precision".
+var castOperandWords = map[string]bool{
+	"precision": true, "varying": true, "with": true, "without": true, "time": true, "zone": true,
+}
+
+// substitute records a single-token rewrite. It is applied when the statement is done,
+// unless an enclosing rewrite consumed the token first.
+func (t *translation) substitute(tok sqlparse.Token, text string) {
+	t.subst[tok.Pos.Offset] = text
+}
+
+// text returns the translated text of a token.
+func (t *translation) text(tok sqlparse.Token) string {
+	if text, ok := t.subst[tok.Pos.Offset]; ok {
+		return text
+	}
+
+	return tok.Text
+}
+
+// spanText returns the source spanned by tokens with single-token rewrites applied.
+func (t *translation) spanText(tokens []sqlparse.Token) string {
+	var b strings.Builder
+
+	last := tokens[0].Pos.Offset
+
+	for _, tok := range tokens {
+		b.WriteString(t.src[last:tok.Pos.Offset])
+		b.WriteString(t.text(tok))
+		last = tok.End
+	}
+
+	return b.String()
+}
+
+// consume rewrites tokens as one span; the rewrites of the tokens inside are dropped.
+func (t *translation) consume(tokens []sqlparse.Token, text string) {
+	for _, tok := range tokens {
+		t.handled[tok.Pos.Offset] = true
+	}
+
+	t.replace(tokens[0], tokens[len(tokens)-1], text)
+}
+
+// flushSubstitutions emits the pending single-token rewrites of a statement.
+func (t *translation) flushSubstitutions(tokens []sqlparse.Token) {
+	for _, tok := range tokens {
+		if text, ok := t.subst[tok.Pos.Offset]; ok && !t.handled[tok.Pos.Offset] && text != tok.Text {
+			t.replaceToken(tok, text)
+		}
+	}
+}
+
+// placeholders translates $1 placeholders to ? and back.
+func (t *translation) placeholders(tokens []sqlparse.Token) {
+	var (
+		seen              = map[string]bool{}
+		next              = 1
+		reused, reordered bool
+	)
+
+	for _, tok := range tokens {
+		if tok.Kind != sqlparse.TokenParam {
+			continue
+		}
+
+		// $N and SQLite's ?NNN are numbered; a plain ? takes the next number.
+		number, numbered := strings.CutPrefix(tok.Text, "$")
+		if !numbered && len(tok.Text) > 1 {
+			number, numbered = strings.CutPrefix(tok.Text, "?")
+		}
+
+		switch {
+		case numbered && t.to == EnginePostgreSQL:
+			t.substitute(tok, "$"+number)
+		case numbered:
+			switch {
+			case seen[number] && !reused:
+				reused = true
+				t.note(tok, "placeholder",
+					"parameter %s is used more than once; each ? is a separate argument, use sqlc.arg(name) to share one",
+					tok.Text)
+			case !seen[number] && number != strconv.Itoa(next) && !reordered:
+				reordered = true
+				t.note(tok, "placeholder",
+					"parameters are numbered in order of appearance with ?, which changes the argument order")
+			}
+
+			if !seen[number] {
+				seen[number] = true
+				next++
+			}
+
+			t.substitute(tok, "?")
+		case tok.Text == "?" && t.to == EnginePostgreSQL:
+			t.substitute(tok, "$"+strconv.Itoa(next))
+			next++
+		}
+	}
+}
+
+// expressions rewrites functions, literals, operators and identifiers.
+func (t *translation) expressions(tokens []sqlparse.Token) {
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+
+		switch tok.Kind {
+		case sqlparse.TokenQuotedIdent:
+			t.quotedIdent(tok)
+		case sqlparse.TokenWord:
+			i = t.word(tokens, i)
+		case sqlparse.TokenPunct:
+			t.operator(tokens, i)
+		}
+	}
+}
+
+// quotedIdent converts between MySQL backticks and standard double quotes.
+func (t *translation) quotedIdent(tok sqlparse.Token) {
+	switch {
+	case strings.HasPrefix(tok.Text, "`") && t.to != EngineMySQL:
+		t.substitute(tok, `"`+strings.ReplaceAll(tok.Value, `"`, `""`)+`"`)
+	case strings.HasPrefix(tok.Text, `"`) && t.to == EngineMySQL:
+		t.substitute(tok, "`"+strings.ReplaceAll(tok.Value, "`", "``")+"`")
+	}
+}
+
+// word rewrites a function call or keyword starting at tokens[i] and returns the
+// index of the last token it consumed.
+func (t *translation) word(tokens []sqlparse.Token, i int) int {
+	tok := tokens[i]
+	prev := previous(tokens, i)
+
+	switch {
+	case matchWords(tokens, i, "NOW", "(", ")") && t.to != EnginePostgreSQL:
+		t.consume(tokens[i:i+3], "CURRENT_TIMESTAMP")
+
+		return i + 2
+	case matchWords(tokens, i, "datetime", "(", "now", ")") && tokens[i+2].Kind == sqlparse.TokenString:
+		now := "CURRENT_TIMESTAMP"
+		if t.to == EnginePostgreSQL {
+			now = "NOW()"
+		}
+
+		t.consume(tokens[i:i+4], now)
+
+		return i + 3
+	case matchWords(tokens, i, "gen_random_uuid", "(", ")"),
+		matchWords(tokens, i, "uuid_generate_v4", "(", ")"),
+		matchWords(tokens, i, "UUID", "(", ")"):
+		t.consume(tokens[i:i+3], t.defaultExpression(prev, randomUUID[t.to]))
+
+		return i + 2
+	case matchWords(tokens, i, "lower", "(", "hex", "(", "randomblob", "(", "16", ")", ")", ")"):
+		t.consume(tokens[i:i+10], t.defaultExpression(prev, randomUUID[t.to]))
+
+		return i + 9
+	case matchWords(tokens, i, "date_trunc", "(") && t.to != EnginePostgreSQL:
+		return t.dateTrunc(tokens, i)
+	case matchWords(tokens, i, "INTERVAL") && i+1 < len(tokens) && tokens[i+1].Kind == sqlparse.TokenString &&
+		t.to != EnginePostgreSQL:
+		return t.interval(tokens, i)
+	case matchWords(tokens, i, "RANDOM", "(", ")") && t.to == EngineMySQL:
+		t.substitute(tok, "RAND")
+	case matchWords(tokens, i, "RAND", "(", ")") && t.to != EngineMySQL:
+		t.substitute(tok, "RANDOM")
+	case (tok.Is("TRUE") || tok.Is("FALSE")) && t.to != EnginePostgreSQL && !prev.Is("IS") && !prev.Is("NOT"):
+		t.substitute(tok, map[bool]string{true: "1", false: "0"}[tok.Is("TRUE")])
+	case tok.Is("ILIKE") && t.to != EnginePostgreSQL:
+		t.substitute(tok, "LIKE")
+	case tok.Is("IFNULL") && t.to == EnginePostgreSQL:
+		t.substitute(tok, "COALESCE")
+	case matchWords(tokens, i, "LIMIT") && t.to == EnginePostgreSQL && i+3 < len(tokens) && tokens[i+2].IsPunct(","):
+		// MySQL's LIMIT offset, count.
+		offset, count := tokens[i+1], tokens[i+3]
+		t.consume(tokens[i+1:i+4], t.text(count)+" OFFSET "+t.text(offset))
+
+		return i + 3
+	case t.to != EnginePostgreSQL:
+		t.postgresWord(tokens, i)
+	}
+
+	return i
+}
+
+// dateTrunc rewrites date_trunc('unit', value) by formatting value with the unit's
+// precision. Units without a fixed format, such as week, are reported instead.
+func (t *translation) dateTrunc(tokens []sqlparse.Token, i int) int {
+	closing := closingParen(tokens, i+1)
+	if closing < i+5 || tokens[i+2].Kind != sqlparse.TokenString || !tokens[i+3].IsPunct(",") {
+		t.note(tokens[i], "function", "date_trunc has no %s equivalent; truncate the value with a date function", t.to)
+
+		return i
+	}
+
+	format, ok := dateTruncFormats[t.to][strings.ToLower(tokens[i+2].Value)]
+	if !ok {
+		t.note(tokens[i], "function", "date_trunc('%s') has no %s equivalent", tokens[i+2].Value, t.to)
+
+		return i
+	}
+
+	value := t.spanText(tokens[i+4 : closing])
+
+	if t.to == EngineMySQL {
+		t.consume(tokens[i:closing+1], "CAST(DATE_FORMAT("+value+", '"+format+"') AS DATETIME)")
+	} else {
+		t.consume(tokens[i:closing+1], "strftime('"+format+"', "+value+")")
+	}
+
+	return closing
+}
+
+// interval rewrites a PostgreSQL interval literal: INTERVAL n UNIT in MySQL, and a
+// datetime() modifier in SQLite, where it must be added to or subtracted from a value.
+func (t *translation) interval(tokens []sqlparse.Token, i int) int {
+	match := intervalPattern.FindStringSubmatch(strings.ToLower(tokens[i+1].Value))
+	if match == nil {
+		t.note(tokens[i], "interval", "interval '%s' has no %s equivalent; use a single unit such as '1 day'",
+			tokens[i+1].Value, t.to)
+
+		return i + 1
+	}
+
+	amount, _ := strconv.Atoi(match[1])
+	unit := match[2]
+
+	if t.to == EngineMySQL {
+		t.consume(tokens[i:i+2], "INTERVAL "+strconv.Itoa(amount)+" "+strings.ToUpper(unit))
+
+		return i + 1
+	}
+
+	// SQLite has no week modifier.
+	if unit == "week" {
+		amount, unit = amount*7, "day"
+	}
+
+	operator := previous(tokens, i)
+	start := -1
+
+	if operator.IsPunct("+") || operator.IsPunct("-") {
+		start = castOperandStart(tokens, i-1)
+	}
+
+	if start < 0 || previous(tokens, start).IsPunct("::") {
+		t.note(tokens[i], "interval",
+			"SQLite has no intervals; add it to a value with datetime(value, '%+d %s')", amount, unit)
+
+		return i + 1
+	}
+
+	if operator.IsPunct("-") {
+		amount = -amount
+	}
+
+	t.insert(tokens[start].Pos.Offset, "datetime(")
+	t.remove(tokens, i-1, i+2)
+	t.insert(tokens[i+1].End, fmt.Sprintf(", '%+d %s')", amount, unit))
+
+	return i + 1
+}
+
+// postgresWord reports PostgreSQL-only query constructs.
+func (t *translation) postgresWord(tokens []sqlparse.Token, i int) {
+	tok := tokens[i]
+
+	switch {
+	case matchWords(tokens, i, "ARRAY", "["), matchWords(tokens, i, "ARRAY", "("):
+		t.note(tok, "array", "ARRAY constructors have no %s equivalent", t.to)
+	case matchWords(tokens, i, "ANY", "("):
+		t.note(tok, "array", "= ANY(...) has no %s equivalent; use IN (sqlc.slice(name))", t.to)
+	case matchWords(tokens, i, "DISTINCT", "ON"):
+		t.note(tok, "distinct-on", "DISTINCT ON has no %s equivalent; use a window function", t.to)
+	case matchWords(tokens, i, "FOR", "UPDATE") && t.to == EngineSQLite:
+		t.note(tok, "locking", "SQLite has no row locks; FOR UPDATE must be removed")
+	}
+}
+
+// defaultExpression wraps a function call in parentheses where MySQL and SQLite
+// require it: as a column DEFAULT.
+func (t *translation) defaultExpression(prev sqlparse.Token, expr string) string {
+	if prev.Is("DEFAULT") && t.to != EnginePostgreSQL {
+		return "(" + expr + ")"
+	}
+
+	return expr
+}
+
+// operator rewrites PostgreSQL casts and reports operators without an equivalent.
+func (t *translation) operator(tokens []sqlparse.Token, i int) {
+	tok := tokens[i]
+
+	switch {
+	case tok.IsPunct("::") && t.to != EnginePostgreSQL:
+		t.cast(tokens, i)
+	case tok.IsPunct("||") && t.to == EngineMySQL:
+		t.note(tok, "operator", "|| is a logical OR in MySQL; use CONCAT() to join strings")
+	case (tok.IsPunct("@>") || tok.IsPunct("<@") || tok.IsPunct("&&")) && t.to != EnginePostgreSQL:
+		t.note(tok, "operator", "the %s operator has no %s equivalent", tok.Text, t.to)
+	}
+}
+
+// cast rewrites "operand::type" into "CAST(operand AS type)".
+func (t *translation) cast(tokens []sqlparse.Token, i int) {
+	start := castOperandStart(tokens, i)
+	if start < 0 || i+1 >= len(tokens) || tokens[i+1].Kind != sqlparse.TokenWord {
+		t.note(tokens[i], "cast", ":: casts have no %s equivalent; rewrite as CAST(... AS ...)", t.to)
+
+		return
+	}
+
+	end := i + 2
+	for end < len(tokens) && tokens[end].Kind == sqlparse.TokenWord && castOperandWords[strings.ToLower(tokens[end].Text)] {
+		end++
+	}
+
+	if end < len(tokens) && tokens[end].IsPunct("(") {
+		if closing := closingParen(tokens, end); closing > 0 {
+			end = closing + 1
+		}
+	}
+
+	for end+1 < len(tokens) && tokens[end].IsPunct("[") && tokens[end+1].IsPunct("]") {
+		end += 2
+	}
+
+	typeText := sqlparse.SourceText(t.src, tokens[i+1:end])
+	operand := t.spanText(tokens[start:i])
+
+	t.consume(tokens[start:end], "CAST("+operand+" AS "+t.castType(tokens[i+1], typeText)+")")
+}
+
+// castOperandStart returns the index of the first token of the operand before "::",
+// or -1 when the operand is not a simple value, column or function call.
+func castOperandStart(tokens []sqlparse.Token, i int) int {
+	if i == 0 {
+		return -1
+	}
+
+	start := i - 1
+
+	if tokens[start].IsPunct(")") {
+		depth := 0
+
+		for ; start >= 0; start-- {
+			if tokens[start].IsPunct(")") {
+				depth++
+			} else if tokens[start].IsPunct("(") {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+
+		if start <= 0 || !tokens[start-1].IsIdent() {
+			return -1
+		}
+
+		start--
+	} else if tokens[start].Kind == sqlparse.TokenPunct {
+		return -1
+	}
+
+	// Qualified names such as u.id or sqlc.arg(name).
+	for start >= 2 && tokens[start-1].IsPunct(".") && tokens[start-2].IsIdent() {
+		start -= 2
+	}
+
+	return start
+}
+
+// castType returns the CAST target type for a PostgreSQL type.
+func (t *translation) castType(tok sqlparse.Token, typeText string) string {
+	kind := parseSQLType(typeText).kind(t.from)
+
+	if t.to == EngineSQLite {
+		switch kind {
+		case kindSmallInt, kindInt, kindBigInt, kindBoolean:
+			return "INTEGER"
+		case kindReal, kindDouble:
+			return "REAL"
+		case kindNumeric, kindMoney:
+			return "NUMERIC"
+		case kindBlob:
+			return "BLOB"
+		default:
+			return "TEXT"
+		}
+	}
+
+	switch kind {
+	case kindSmallInt, kindInt, kindBigInt, kindBoolean:
+		return "SIGNED"
+	case kindReal, kindDouble:
+		return "DOUBLE"
+	case kindNumeric:
+		return "DECIMAL" + parseSQLType(typeText).args
+	case kindTimestamp, kindTimestampTZ, kindDateTime:
+		return "DATETIME"
+	case kindDate:
+		return "DATE"
+	case kindTime:
+		return "TIME"
+	case kindJSON:
+		return "JSON"
+	case kindBlob:
+		return "BINARY"
+	case kindText, kindVarchar, kindChar, kindUUID:
+		return "CHAR"
+	default:
+		t.note(tok, "cast", "cast to %s has no MySQL equivalent; cast to CHAR instead", typeText)
+
+		return "CHAR"
+	}
+}
+
+// modification handles INSERT, UPDATE, DELETE and REPLACE statements.
+func (t *translation) modification(stmt *sqlparse.Statement) {
+	tokens := stmt.Tokens
+
+	if t.to == EngineMySQL {
+		if returning := topLevelWord(tokens, "RETURNING"); returning > 0 {
+			t.remove(tokens, returning, len(tokens))
+			t.returningRemoved = true
+			t.note(tokens[returning], "returning",
+				"MySQL has no RETURNING; the clause was removed and the query now uses :exec")
+		}
+	}
+
+	switch {
+	case matchWords(tokens, 0, "INSERT", "OR", "IGNORE") && t.to == EngineMySQL:
+		t.consume(tokens[1:3], "IGNORE")
+	case matchWords(tokens, 0, "INSERT", "IGNORE") && t.to == EngineSQLite:
+		t.consume(tokens[1:2], "OR IGNORE")
+	case matchWords(tokens, 0, "INSERT", "OR", "REPLACE") && t.to == EngineMySQL:
+		t.consume(tokens[0:3], "REPLACE")
+	case matchWords(tokens, 0, "REPLACE") && t.to == EngineSQLite:
+		t.consume(tokens[0:1], "INSERT OR REPLACE")
+	case matchWords(tokens, 0, "INSERT", "OR"), matchWords(tokens, 0, "INSERT", "IGNORE"), matchWords(tokens, 0, "REPLACE"):
+		if t.to == EnginePostgreSQL {
+			t.note(tokens[0], "upsert", "use INSERT ... ON CONFLICT DO NOTHING or DO UPDATE in PostgreSQL")
+		}
+	}
+
+	if conflict := topLevelWord(tokens, "CONFLICT"); conflict > 0 && tokens[conflict-1].Is("ON") && t.to == EngineMySQL {
+		t.note(tokens[conflict-1], "upsert", "ON CONFLICT has no MySQL equivalent; use ON DUPLICATE KEY UPDATE")
+	}
+
+	if duplicate := topLevelWord(tokens, "DUPLICATE"); duplicate > 0 && tokens[duplicate-1].Is("ON") && t.to != EngineMySQL {
+		t.note(tokens[duplicate-1], "upsert", "ON DUPLICATE KEY UPDATE has no %s equivalent; use ON CONFLICT (...) DO UPDATE", t.to)
+	}
+}
+
+// queryCommand adjusts the sqlc query annotation of the statement.
+func (t *translation) queryCommand(stmt *sqlparse.Statement) {
+	for _, comment := range stmt.Comments {
+		match := queryNamePattern.FindStringSubmatchIndex(comment.Text)
+		if match == nil {
+			continue
+		}
+
+		command := comment.Text[match[2]:match[3]]
+
+		if t.returningRemoved && (command == ":one" || command == ":many") {
+			t.edits = append(t.edits, edit{
+				start: comment.Pos.Offset + match[2],
+				end:   comment.Pos.Offset + match[3],
+				text:  ":exec",
+			})
+
+			return
+		}
+
+		sqlPackage := config.SQLPackageDatabaseSQL
+		if t.to == EnginePostgreSQL {
+			sqlPackage = config.SQLPackagePgxV5
+		}
+
+		if message, isError := config.CommandCompatibility(command, t.to, sqlPackage); isError {
+			t.note(comment, "command", "%s", message)
+		}
+
+		return
+	}
+}
+
+// topLevelWord returns the index of keyword outside parentheses, or -1.
+func topLevelWord(tokens []sqlparse.Token, keyword string) int {
+	depth := 0
+
+	for i, tok := range tokens {
+		switch {
+		case tok.IsPunct("("):
+			depth++
+		case tok.IsPunct(")"):
+			depth--
+		case depth == 0 && tok.Is(keyword):
+			return i
+		}
+	}
+
+	return -1
+}
+
+// previous returns the token before tokens[i], or an empty token.
+func previous(tokens []sqlparse.Token, i int) sqlparse.Token {
+	if i == 0 {
+		return sqlparse.Token{}
+	}
+
+	return tokens[i-1]
+}
@@ -0,0 +1,266 @@
+package dialect
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+)
+
+// Supported dialects, matching the sqlc engine names.
+const (
+	EnginePostgreSQL = "postgresql"
+	EngineMySQL      = "mysql"
+	EngineSQLite     = "sqlite"
+)
+
+// Note is a construct that could not be translated automatically, or that changed meaning.
+type Note struct {
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	Construct string `json:"construct"`
+	Message   string `json:"message"`
+}
+
+// String formats the note as line:column: message.
+func (n Note) String() string {
+	return fmt.Sprintf("%d:%d: %s", n.Line, n.Column, n.Message)
+}
+
+// Result is a translated SQL text with the notes collected while translating it.
+type Result struct {
+	SQL   string
+	Notes []Note
+}
+
+// Translator rewrites schema and query files written for one sqlc engine into another.
+// It works on tokens, so formatting and comments are kept and only the constructs that
+// differ between the dialects are rewritten.
+type Translator struct {
+	from string
+	to   string
+}
+
+// NewTranslator creates a translator from one engine (postgresql, mysql or sqlite) to another.
+func NewTranslator(from, to string) (*Translator, error) {
+	engines := []string{EnginePostgreSQL, EngineMySQL, EngineSQLite}
+
+	for _, engine := range []string{from, to} {
+		if !slices.Contains(engines, engine) {
+			return nil, apperrors.Newf(
+				apperrors.ErrorCodeDatabaseNotSupported,
+				"unsupported engine %q (must be one of: postgresql, mysql, sqlite)",
+				engine,
+			)
+		}
+	}
+
+	return &Translator{from: from, to: to}, nil
+}
+
+// Translate rewrites src. Constructs that need a manual change are reported as notes.
+func (t *Translator) Translate(src string) (*Result, error) {
+	if t.from == t.to {
+		return &Result{SQL: src}, nil
+	}
+
+	statements, err := sqlparse.Split(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to tokenize SQL: %w", err)
+	}
+
+	tr := &translation{Translator: t, src: src, handled: map[int]bool{}, subst: map[int]string{}}
+
+	for i := range statements {
+		tr.statement(&statements[i])
+	}
+
+	return &Result{SQL: tr.apply(), Notes: tr.notes}, nil
+}
+
+// edit replaces src[start:end] with text; start == end inserts.
+type edit struct {
+	start int
+	end   int
+	text  string
+}
+
+// translation holds the edits and notes for one source text.
+type translation struct {
+	*Translator
+
+	src   string
+	edits []edit
+	notes []Note
+	// handled marks tokens, by offset, that an enclosing rewrite already translated.
+	handled map[int]bool
+	// subst holds the pending single-token rewrites by offset.
+	subst map[int]string
+	// returningRemoved is set when the current statement lost its RETURNING clause.
+	returningRemoved bool
+}
+
+// statement translates a single statement.
+func (t *translation) statement(stmt *sqlparse.Statement) {
+	tokens := stmt.Tokens
+	t.returningRemoved = false
+
+	t.placeholders(tokens)
+	t.expressions(tokens)
+
+	switch {
+	case matchWords(tokens, 0, "CREATE", "TABLE"),
+		matchWords(tokens, 0, "CREATE", "TEMP", "TABLE"),
+		matchWords(tokens, 0, "CREATE", "TEMPORARY", "TABLE"):
+		t.createTable(tokens)
+	case matchWords(tokens, 0, "ALTER", "TABLE"):
+		t.alterTable(tokens)
+	case matchWords(tokens, 0, "CREATE", "INDEX"), matchWords(tokens, 0, "CREATE", "UNIQUE", "INDEX"):
+		t.createIndex(tokens)
+	case matchWords(tokens, 0, "INSERT"), matchWords(tokens, 0, "UPDATE"),
+		matchWords(tokens, 0, "DELETE"), matchWords(tokens, 0, "REPLACE"):
+		t.modification(stmt)
+	default:
+		t.unsupportedStatement(tokens)
+	}
+
+	t.queryCommand(stmt)
+	t.flushSubstitutions(tokens)
+}
+
+// unsupportedStatement reports statements that only exist in the source dialect.
+func (t *translation) unsupportedStatement(tokens []sqlparse.Token) {
+	postgresOnly := [][]string{
+		{"CREATE", "EXTENSION"}, {"CREATE", "TYPE"}, {"CREATE", "DOMAIN"}, {"CREATE", "SEQUENCE"},
+		{"CREATE", "FUNCTION"}, {"CREATE", "OR", "REPLACE", "FUNCTION"}, {"COMMENT", "ON"}, {"DO"},
+	}
+
+	if t.from == EnginePostgreSQL {
+		for _, words := range postgresOnly {
+			if matchWords(tokens, 0, words...) {
+				t.note(tokens[0], "statement", "%s has no %s equivalent and was kept", strings.Join(words, " "), t.to)
+
+				return
+			}
+		}
+	}
+
+	for _, words := range [][]string{{"CREATE", "TRIGGER"}, {"CREATE", "OR", "REPLACE", "TRIGGER"}} {
+		if matchWords(tokens, 0, words...) {
+			t.note(tokens[0], "statement", "trigger syntax differs between engines; rewrite this trigger for %s", t.to)
+		}
+	}
+}
+
+// replace replaces the source spanned by first..last with text.
+func (t *translation) replace(first, last sqlparse.Token, text string) {
+	t.edits = append(t.edits, edit{start: first.Pos.Offset, end: last.End, text: text})
+}
+
+// replaceToken replaces a single token.
+func (t *translation) replaceToken(tok sqlparse.Token, text string) {
+	t.replace(tok, tok, text)
+}
+
+// insert adds text at a source offset.
+func (t *translation) insert(offset int, text string) {
+	t.edits = append(t.edits, edit{start: offset, end: offset, text: text})
+}
+
+// remove deletes tokens[i:j] together with the whitespace before them.
+func (t *translation) remove(tokens []sqlparse.Token, i, j int) {
+	start := tokens[i].Pos.Offset
+	if i > 0 {
+		start = tokens[i-1].End
+	}
+
+	t.edits = append(t.edits, edit{start: start, end: tokens[j-1].End})
+}
+
+// note records a construct at the position of tok.
+func (t *translation) note(tok sqlparse.Token, construct, format string, args ...any) {
+	t.notes = append(t.notes, Note{
+		Line:      tok.Pos.Line,
+		Column:    tok.Pos.Column,
+		Construct: construct,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// apply returns the source with all edits applied. An edit overlapping an earlier one
+// is dropped, so an enclosing rewrite wins over rewrites of the tokens inside it.
+func (t *translation) apply() string {
+	sort.SliceStable(t.edits, func(i, j int) bool {
+		if t.edits[i].start != t.edits[j].start {
+			return t.edits[i].start < t.edits[j].start
+		}
+
+		// Insertions go before a replacement starting at the same offset.
+		return t.edits[i].start == t.edits[i].end && t.edits[j].start != t.edits[j].end
+	})
+
+	sort.SliceStable(t.notes, func(i, j int) bool {
+		if t.notes[i].Line != t.notes[j].Line {
+			return t.notes[i].Line < t.notes[j].Line
+		}
+
+		return t.notes[i].Column < t.notes[j].Column
+	})
+
+	var (
+		b    strings.Builder
+		last int
+	)
+
+	for _, e := range t.edits {
+		if e.start < last {
+			continue
+		}
+
+		b.WriteString(t.src[last:e.start])
+		b.WriteString(e.text)
+		last = e.end
+	}
+
+	b.WriteString(t.src[last:])
+
+	return b.String()
+}
+
+// matchWords reports whether tokens[i:] starts with the given keywords or punctuation.
+func matchWords(tokens []sqlparse.Token, i int, words ...string) bool {
+	if i+len(words) > len(tokens) {
+		return false
+	}
+
+	for n, word := range words {
+		tok := tokens[i+n]
+		if tok.Kind == sqlparse.TokenQuotedIdent || !strings.EqualFold(tok.Value, word) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// closingParen returns the index of the parenthesis closing the one at tokens[open].
+func closingParen(tokens []sqlparse.Token, open int) int {
+	depth := 0
+
+	for i := open; i < len(tokens); i++ {
+		switch {
+		case tokens[i].IsPunct("("):
+			depth++
+		case tokens[i].IsPunct(")"):
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,335 @@
+package dialect
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/schema"
+)
+
+const postgresSchema = `-- Users
+CREATE TABLE IF NOT EXISTS users (
+    id BIGSERIAL PRIMARY KEY,
+    external_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    email TEXT NOT NULL UNIQUE,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    settings JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at);
+`
+
+const mysqlSchema = "CREATE TABLE `users` (\n" +
+	"  `id` BIGINT NOT NULL AUTO_INCREMENT,\n" +
+	"  email VARCHAR(255) NOT NULL,\n" +
+	"  is_active TINYINT(1) NOT NULL DEFAULT 1,\n" +
+	"  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n" +
+	"  PRIMARY KEY (`id`),\n" +
+	"  UNIQUE KEY uq_users_email (email),\n" +
+	"  INDEX idx_users_active (is_active)\n" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n"
+
+const sqliteSchema = `CREATE TABLE users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    email TEXT NOT NULL UNIQUE,
+    is_active INTEGER NOT NULL DEFAULT 1,
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+`
+
+func TestTranslateSchemaParsesOnTarget(t *testing.T) {
+	sources := map[string]string{
+		EnginePostgreSQL: postgresSchema,
+		EngineMySQL:      mysqlSchema,
+		EngineSQLite:     sqliteSchema,
+	}
+
+	for from, src := range sources {
+		for _, to := range []string{EnginePostgreSQL, EngineMySQL, EngineSQLite} {
+			if from == to {
+				continue
+			}
+
+			t.Run(from+" to "+to, func(t *testing.T) {
+				result := translate(t, from, to, src)
+
+				parser, err := schema.NewParser(to)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if err := parser.ParseSQL("schema.sql", result.SQL); err != nil {
+					t.Fatalf("translated schema does not parse: %v\n%s", err, result.SQL)
+				}
+
+				tables := parser.Schema("").Tables
+				if len(tables) != 1 || tables[0].Name != "users" {
+					t.Fatalf("expected the users table, got %+v", tables)
+				}
+
+				id := tables[0].Columns[0]
+				// An INTEGER column in a table-level PRIMARY KEY is SQLite's rowid.
+				rowid := to == EngineSQLite && id.PrimaryKey && id.SQLType == "INTEGER"
+				if id.Name != "id" || !id.AutoIncrement && !rowid {
+					t.Errorf("expected an auto-increment id column, got %+v\n%s", id, result.SQL)
+				}
+			})
+		}
+	}
+}
+
+func TestTranslateColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		src      string
+		contains []string
+		notes    []string
+	}{
+		{
+			name: "serial to MySQL", from: EnginePostgreSQL, to: EngineMySQL,
+			src:      "CREATE TABLE t (id SERIAL PRIMARY KEY, data JSONB, at TIMESTAMPTZ DEFAULT NOW());",
+			contains: []string{"id INT AUTO_INCREMENT PRIMARY KEY", "data JSON", "at TIMESTAMP DEFAULT CURRENT_TIMESTAMP"},
+		},
+		{
+			name: "identity to SQLite", from: EnginePostgreSQL, to: EngineSQLite,
+			src:      "CREATE TABLE t (id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY, ok BOOLEAN DEFAULT false);",
+			contains: []string{"id INTEGER PRIMARY KEY AUTOINCREMENT,", "ok BOOLEAN DEFAULT 0"},
+		},
+		{
+			name: "auto increment to PostgreSQL", from: EngineMySQL, to: EnginePostgreSQL,
+			src:      "CREATE TABLE t (id INT AUTO_INCREMENT PRIMARY KEY, flag TINYINT(1) DEFAULT 1);",
+			contains: []string{"id SERIAL PRIMARY KEY", "flag BOOLEAN DEFAULT true"},
+		},
+		{
+			name: "uuid defaults", from: EnginePostgreSQL, to: EngineSQLite,
+			src:      "CREATE TABLE t (id UUID PRIMARY KEY DEFAULT gen_random_uuid());",
+			contains: []string{"id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(16))))"},
+		},
+		{
+			name: "arrays become JSON", from: EnginePostgreSQL, to: EngineMySQL,
+			src:      "CREATE TABLE t (tags TEXT[]);",
+			contains: []string{"tags JSON"},
+			notes:    []string{"array type TEXT[]"},
+		},
+		{
+			name: "text keys in MySQL", from: EngineSQLite, to: EngineMySQL,
+			src:      "CREATE TABLE t (email TEXT UNIQUE, body TEXT);",
+			contains: []string{"email VARCHAR(255) UNIQUE", "body TEXT"},
+			notes:    []string{"MySQL cannot index TEXT"},
+		},
+		{
+			name: "inline indexes become CREATE INDEX", from: EngineMySQL, to: EngineSQLite,
+			src:      "CREATE TABLE t (a INT, b INT, KEY (a, b)) ENGINE=InnoDB;",
+			contains: []string{"CREATE TABLE t (a INTEGER, b INTEGER);\n\nCREATE INDEX idx_t_a_b ON t (a, b);"},
+		},
+		{
+			name: "ON UPDATE timestamps", from: EngineMySQL, to: EnginePostgreSQL,
+			src:      "CREATE TABLE t (at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP);",
+			contains: []string{"at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP)"},
+			notes:    []string{"ON UPDATE CURRENT_TIMESTAMP is MySQL-only"},
+		},
+		{
+			name: "foreign key actions are kept", from: EngineMySQL, to: EnginePostgreSQL,
+			src:      "CREATE TABLE t (u INT REFERENCES users (id) ON UPDATE CASCADE);",
+			contains: []string{"u INTEGER REFERENCES users (id) ON UPDATE CASCADE"},
+		},
+		{
+			name: "quoted identifiers", from: EngineMySQL, to: EnginePostgreSQL,
+			src:      "CREATE TABLE `order` (`key` VARCHAR(10));",
+			contains: []string{`CREATE TABLE "order" ("key" VARCHAR(10));`},
+		},
+		{
+			name: "index methods", from: EnginePostgreSQL, to: EngineMySQL,
+			src:      "CREATE INDEX CONCURRENTLY IF NOT EXISTS idx ON t USING gin (data);",
+			contains: []string{"CREATE INDEX idx ON t (data);"},
+			notes:    []string{"no CREATE INDEX IF NOT EXISTS", "gin indexes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := translate(t, tt.from, tt.to, tt.src)
+			assertTranslation(t, result, tt.contains, tt.notes)
+		})
+	}
+}
+
+func TestTranslateQueries(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		src      string
+		contains []string
+		notes    []string
+	}{
+		{
+			name: "numbered placeholders to MySQL", from: EnginePostgreSQL, to: EngineMySQL,
+			src:      "-- name: ListUsers :many\nSELECT * FROM users WHERE active = true LIMIT $1 OFFSET $2;",
+			contains: []string{"WHERE active = 1 LIMIT ? OFFSET ?;"},
+		},
+		{
+			name: "placeholders to PostgreSQL", from: EngineSQLite, to: EnginePostgreSQL,
+			src:      "-- name: UpdateUser :exec\nUPDATE users SET name = ?, at = datetime('now') WHERE id = ?;",
+			contains: []string{"SET name = $1, at = NOW() WHERE id = $2;"},
+		},
+		{
+			name: "SQLite numbered placeholders", from: EngineSQLite, to: EnginePostgreSQL,
+			src:      "SELECT * FROM t WHERE a = ?2 AND b = ?1;",
+			contains: []string{"a = $2 AND b = $1"},
+		},
+		{
+			name: "reordered placeholders", from: EnginePostgreSQL, to: EngineSQLite,
+			src:      "UPDATE users SET name = $2 WHERE id = $1;",
+			contains: []string{"SET name = ? WHERE id = ?"},
+			notes:    []string{"changes the argument order"},
+		},
+		{
+			name: "reused placeholders", from: EnginePostgreSQL, to: EngineMySQL,
+			src:   "SELECT * FROM t WHERE a = $1 OR b = $1;",
+			notes: []string{"parameter $1 is used more than once"},
+		},
+		{
+			name: "intervals to MySQL", from: EnginePostgreSQL, to: EngineMySQL,
+			src:      "SELECT * FROM t WHERE at > now() - interval '1 day' AND at < now() + INTERVAL '2 hours';",
+			contains: []string{"at > CURRENT_TIMESTAMP - INTERVAL 1 DAY AND at < CURRENT_TIMESTAMP + INTERVAL 2 HOUR;"},
+		},
+		{
+			name: "intervals to SQLite", from: EnginePostgreSQL, to: EngineSQLite,
+			src:      "SELECT * FROM t WHERE at > now() - interval '1 day' AND t.due < t.at + interval '2 weeks';",
+			contains: []string{"at > datetime(CURRENT_TIMESTAMP, '-1 day') AND t.due < datetime(t.at, '+14 day');"},
+		},
+		{
+			name: "intervals without an equivalent", from: EnginePostgreSQL, to: EngineSQLite,
+			src:      "SELECT interval '1 day', now() - interval '1 day 2 hours' FROM t;",
+			contains: []string{"SELECT interval '1 day', CURRENT_TIMESTAMP - interval '1 day 2 hours' FROM t;"},
+			notes:    []string{"SQLite has no intervals", "interval '1 day 2 hours' has no sqlite equivalent"},
+		},
+		{
+			name: "RETURNING in MySQL", from: EnginePostgreSQL, to: EngineMySQL,
+			src:      "-- name: CreateUser :one\nINSERT INTO users (email) VALUES ($1)\nRETURNING id, email;",
+			contains: []string{"-- name: CreateUser :exec\nINSERT INTO users (email) VALUES (?);"},
+			notes:    []string{"MySQL has no RETURNING"},
+		},
+		{
+			name: "RETURNING kept in SQLite", from: EnginePostgreSQL, to: EngineSQLite,
+			src:      "-- name: CreateUser :one\nINSERT INTO users (email) VALUES ($1) RETURNING id;",
+			contains: []string{":one", "VALUES (?) RETURNING id;"},
+		},
+		{
+			name: "casts", from: EnginePostgreSQL, to: EngineMySQL,
+			src:      "SELECT created_at::date, sqlc.arg(n)::int, (a + b)::text FROM t;",
+			contains: []string{"CAST(created_at AS DATE)", "CAST(sqlc.arg(n) AS SIGNED)", "(a + b)::text"},
+			notes:    []string{":: casts have no mysql equivalent"},
+		},
+		{
+			name: "functions", from: EngineMySQL, to: EnginePostgreSQL,
+			src:      "SELECT IFNULL(a, 0) FROM t ORDER BY RAND() LIMIT ?, ?;",
+			contains: []string{"SELECT COALESCE(a, 0) FROM t ORDER BY RANDOM() LIMIT $2 OFFSET $1;"},
+		},
+		{
+			name: "date_trunc to MySQL", from: EnginePostgreSQL, to: EngineMySQL,
+			src:      "SELECT date_trunc('hour', occurred_at) AS bucket FROM events WHERE occurred_at >= $1;",
+			contains: []string{"CAST(DATE_FORMAT(occurred_at, '%Y-%m-%d %H:00:00') AS DATETIME) AS bucket", ">= ?"},
+		},
+		{
+			name: "date_trunc to SQLite", from: EnginePostgreSQL, to: EngineSQLite,
+			src:      "SELECT date_trunc('MINUTE', e.occurred_at), date_trunc('week', occurred_at) FROM events e;",
+			contains: []string{"strftime('%Y-%m-%d %H:%M:00', e.occurred_at)", "date_trunc('week', occurred_at)"},
+			notes:    []string{"date_trunc('week') has no sqlite equivalent"},
+		},
+		{
+			name: "PostgreSQL operators", from: EnginePostgreSQL, to: EngineSQLite,
+			src:      "SELECT * FROM t WHERE name ILIKE $1 AND id = ANY($2) AND tags @> $3;",
+			contains: []string{"name LIKE ?"},
+			notes:    []string{"= ANY(...)", "the @> operator"},
+		},
+		{
+			name: "upserts", from: EngineSQLite, to: EngineMySQL,
+			src:      "INSERT OR IGNORE INTO t (a) VALUES (?) ON CONFLICT DO NOTHING;",
+			contains: []string{"INSERT IGNORE INTO t"},
+			notes:    []string{"ON CONFLICT has no MySQL equivalent"},
+		},
+		{
+			name: "unsupported statements", from: EnginePostgreSQL, to: EngineSQLite,
+			src:   "CREATE EXTENSION IF NOT EXISTS pgcrypto;",
+			notes: []string{"CREATE EXTENSION has no sqlite equivalent"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := translate(t, tt.from, tt.to, tt.src)
+			assertTranslation(t, result, tt.contains, tt.notes)
+		})
+	}
+}
+
+func TestTranslateSameEngine(t *testing.T) {
+	result := translate(t, EngineMySQL, EngineMySQL, mysqlSchema)
+
+	if result.SQL != mysqlSchema || len(result.Notes) != 0 {
+		t.Errorf("expected the source unchanged, got %q with %v", result.SQL, result.Notes)
+	}
+}
+
+func TestNewTranslatorRejectsUnknownEngine(t *testing.T) {
+	if _, err := NewTranslator(EnginePostgreSQL, "oracle"); err == nil {
+		t.Error("expected an error for an unknown engine")
+	}
+}
+
+func TestNotePositions(t *testing.T) {
+	result := translate(t, EnginePostgreSQL, EngineMySQL, "SELECT 1;\n\nSELECT id FROM t\nWHERE tags @> $1;")
+
+	if len(result.Notes) != 1 {
+		t.Fatalf("expected one note, got %v", result.Notes)
+	}
+
+	if got := result.Notes[0].String(); !strings.HasPrefix(got, "4:12: ") {
+		t.Errorf("expected the note at 4:12, got %q", got)
+	}
+}
+
+func translate(t *testing.T, from, to, src string) *Result {
+	t.Helper()
+
+	translator, err := NewTranslator(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := translator.Translate(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func assertTranslation(t *testing.T, result *Result, contains, notes []string) {
+	t.Helper()
+
+	for _, want := range contains {
+		if !strings.Contains(result.SQL, want) {
+			t.Errorf("expected %q in:\n%s", want, result.SQL)
+		}
+	}
+
+	for _, want := range notes {
+		found := false
+
+		for _, note := range result.Notes {
+			found = found || strings.Contains(note.Message, want)
+		}
+
+		if !found {
+			t.Errorf("expected a note containing %q, got %v", want, result.Notes)
+		}
+	}
+
+	if len(notes) == 0 && len(result.Notes) > 0 {
+		t.Errorf("expected no notes, got %v", result.Notes)
+	}
+}
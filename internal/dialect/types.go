@@ -0,0 +1,267 @@
+package dialect
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// typeArgsPattern captures the length/precision arguments of a type such as "(255)" or "(10, 2)".
+var typeArgsPattern = regexp.MustCompile(`\s*\(([^)]*)\)`)
+
+// typeKind is the engine-neutral family of a declared column type.
+type typeKind int
+
+const (
+	kindUnknown typeKind = iota
+	kindSmallInt
+	kindInt
+	kindBigInt
+	kindBoolean
+	kindText
+	kindVarchar
+	kindChar
+	kindUUID
+	kindJSON
+	kindTimestamp
+	kindTimestampTZ
+	kindDateTime
+	kindDate
+	kindTime
+	kindReal
+	kindDouble
+	kindNumeric
+	kindMoney
+	kindBlob
+	kindEnum
+	kindNetwork
+)
+
+// sqlType is a declared column type split into its parts.
+type sqlType struct {
+	// base is the lower-case type name without arguments, e.g. "timestamp with time zone".
+	base     string
+	args     string
+	array    bool
+	unsigned bool
+}
+
+// parseSQLType splits a declared type such as "VARCHAR(255)", "int unsigned" or "text[]".
+func parseSQLType(declared string) sqlType {
+	text := strings.ToLower(strings.TrimSpace(declared))
+
+	var typ sqlType
+
+	for strings.HasSuffix(text, "[]") {
+		text = strings.TrimSpace(strings.TrimSuffix(text, "[]"))
+		typ.array = true
+	}
+
+	if rest, ok := strings.CutSuffix(text, " array"); ok {
+		text = rest
+		typ.array = true
+	}
+
+	if match := typeArgsPattern.FindStringSubmatchIndex(text); match != nil {
+		typ.args = "(" + strings.TrimSpace(text[match[2]:match[3]]) + ")"
+		text = text[:match[0]] + text[match[1]:]
+	}
+
+	var words []string
+
+	for word := range strings.FieldsSeq(text) {
+		switch word {
+		case "unsigned":
+			typ.unsigned = true
+		case "zerofill", "signed":
+		default:
+			words = append(words, word)
+		}
+	}
+
+	typ.base = strings.Join(words, " ")
+
+	return typ
+}
+
+// kind classifies the type. from decides ambiguous names such as MySQL's 4-byte FLOAT.
+func (s sqlType) kind(from string) typeKind {
+	switch s.base {
+	case "serial", "serial4", "int", "integer", "int4", "mediumint":
+		return kindInt
+	case "smallserial", "serial2", "smallint", "int2", "year":
+		return kindSmallInt
+	case "bigserial", "serial8", "bigint", "int8":
+		return kindBigInt
+	case "tinyint":
+		if s.args == "(1)" {
+			return kindBoolean
+		}
+
+		return kindSmallInt
+	case "bool", "boolean":
+		return kindBoolean
+	case "text", "tinytext", "mediumtext", "longtext", "clob", "citext":
+		return kindText
+	case "varchar", "character varying", "nvarchar", "varying character":
+		return kindVarchar
+	case "char", "character", "nchar", "bpchar":
+		return kindChar
+	case "uuid":
+		return kindUUID
+	case "json", "jsonb":
+		return kindJSON
+	case "timestamp", "timestamp without time zone":
+		// MySQL's TIMESTAMP is stored in UTC and converted to the session time zone.
+		if from == EngineMySQL {
+			return kindTimestampTZ
+		}
+
+		return kindTimestamp
+	case "timestamptz", "timestamp with time zone":
+		return kindTimestampTZ
+	case "datetime":
+		return kindDateTime
+	case "date":
+		return kindDate
+	case "time", "time without time zone", "timetz", "time with time zone":
+		return kindTime
+	case "real", "float4":
+		return kindReal
+	case "float":
+		if from == EngineMySQL {
+			return kindReal
+		}
+
+		return kindDouble
+	case "double", "double precision", "float8":
+		return kindDouble
+	case "numeric", "decimal":
+		return kindNumeric
+	case "money":
+		return kindMoney
+	case "bytea", "blob", "tinyblob", "mediumblob", "longblob", "binary", "varbinary":
+		return kindBlob
+	case "enum":
+		return kindEnum
+	case "inet", "cidr", "macaddr", "macaddr8", "interval", "xml", "tsvector", "hstore":
+		return kindNetwork
+	default:
+		return kindUnknown
+	}
+}
+
+// isSerial reports whether a PostgreSQL type implies an auto-incrementing sequence.
+func (s sqlType) isSerial() bool {
+	switch s.base {
+	case "serial", "serial2", "serial4", "serial8", "smallserial", "bigserial":
+		return true
+	default:
+		return false
+	}
+}
+
+// columnType is a declared type translated to the target engine.
+type columnType struct {
+	SQL    string
+	Kind   typeKind
+	Serial bool
+	Note   string
+}
+
+// integerTypes renders the integer kinds per engine; SQLite only has INTEGER.
+var integerTypes = map[string]map[typeKind]string{
+	EnginePostgreSQL: {kindSmallInt: "SMALLINT", kindInt: "INTEGER", kindBigInt: "BIGINT"},
+	EngineMySQL:      {kindSmallInt: "SMALLINT", kindInt: "INT", kindBigInt: "BIGINT"},
+	EngineSQLite:     {kindSmallInt: "INTEGER", kindInt: "INTEGER", kindBigInt: "INTEGER"},
+}
+
+// serialTypes are the PostgreSQL auto-incrementing integer types.
+var serialTypes = map[typeKind]string{kindSmallInt: "SMALLSERIAL", kindInt: "SERIAL", kindBigInt: "BIGSERIAL"}
+
+// simpleTypes renders the kinds that map one to one onto each engine.
+var simpleTypes = map[string]map[typeKind]string{
+	EnginePostgreSQL: {
+		kindBoolean: "BOOLEAN", kindText: "TEXT", kindUUID: "UUID", kindJSON: "JSONB",
+		kindTimestamp: "TIMESTAMP", kindTimestampTZ: "TIMESTAMPTZ", kindDateTime: "TIMESTAMP",
+		kindDate: "DATE", kindTime: "TIME", kindReal: "REAL", kindDouble: "DOUBLE PRECISION",
+		kindMoney: "MONEY", kindBlob: "BYTEA",
+	},
+	EngineMySQL: {
+		kindBoolean: "BOOLEAN", kindText: "TEXT", kindUUID: "CHAR(36)", kindJSON: "JSON",
+		kindTimestamp: "DATETIME", kindTimestampTZ: "TIMESTAMP", kindDateTime: "DATETIME",
+		kindDate: "DATE", kindTime: "TIME", kindReal: "FLOAT", kindDouble: "DOUBLE",
+		kindMoney: "DECIMAL(19,4)", kindBlob: "BLOB", kindNetwork: "VARCHAR(255)",
+	},
+	EngineSQLite: {
+		kindBoolean: "BOOLEAN", kindText: "TEXT", kindUUID: "TEXT", kindJSON: "TEXT",
+		kindTimestamp: "TIMESTAMP", kindTimestampTZ: "TIMESTAMP", kindDateTime: "DATETIME",
+		kindDate: "DATE", kindTime: "TEXT", kindReal: "REAL", kindDouble: "REAL",
+		kindMoney: "NUMERIC", kindBlob: "BLOB", kindNetwork: "TEXT", kindVarchar: "TEXT",
+		kindChar: "TEXT", kindNumeric: "NUMERIC",
+	},
+}
+
+// translateType renders a declared column type for the target engine.
+func (t *Translator) translateType(declared string) columnType {
+	typ := parseSQLType(declared)
+	kind := typ.kind(t.from)
+	result := columnType{SQL: declared, Kind: kind, Serial: typ.isSerial()}
+
+	if typ.array {
+		if t.to == EnginePostgreSQL {
+			return result
+		}
+
+		result.Kind = kindJSON
+		result.SQL = simpleTypes[t.to][kindJSON]
+		result.Note = fmt.Sprintf("array type %s is stored as %s; queries using array operators need rewriting",
+			declared, result.SQL)
+
+		return result
+	}
+
+	if sql, ok := integerTypes[t.to][kind]; ok {
+		result.SQL = sql
+	} else if sql, ok := simpleTypes[t.to][kind]; ok {
+		result.SQL = sql
+	} else {
+		switch kind {
+		case kindVarchar:
+			result.SQL = "VARCHAR" + typeArgsOr(typ.args, "(255)")
+		case kindChar:
+			result.SQL = "CHAR" + typ.args
+		case kindNumeric:
+			if t.to == EngineMySQL {
+				result.SQL = "DECIMAL" + typ.args
+			} else {
+				result.SQL = "NUMERIC" + typ.args
+			}
+		case kindEnum:
+			result.SQL = "TEXT"
+			result.Note = "inline ENUM" + typ.args + " has no equivalent; use CREATE TYPE ... AS ENUM or a CHECK constraint"
+		default:
+			result.Note = fmt.Sprintf("type %s has no known %s equivalent and was kept", declared, t.to)
+		}
+	}
+
+	switch {
+	case result.Note != "":
+	case typ.base == "citext" && t.to != EnginePostgreSQL:
+		result.Note = "citext compares case-insensitively; check the column collation"
+	case kind == kindNetwork || kind == kindMoney:
+		result.Note = fmt.Sprintf("%s has no %s equivalent and is stored as %s", typ.base, t.to, result.SQL)
+	case typ.unsigned && t.to != EngineMySQL:
+		result.Note = fmt.Sprintf("%s has no unsigned integers; check the range of %s", t.to, declared)
+	}
+
+	return result
+}
+
+func typeArgsOr(args, fallback string) string {
+	if args == "" {
+		return fallback
+	}
+
+	return args
+}
@@ -0,0 +1,552 @@
+package generators
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/domain"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/queries"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/schema"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/utils"
+)
+
+// Pagination selects how generated List queries page through a table.
+type Pagination string
+
+const (
+	// PaginationOffset pages with LIMIT and OFFSET.
+	PaginationOffset Pagination = "offset"
+	// PaginationKeyset pages by comparing an ordering key with the last row seen.
+	PaginationKeyset Pagination = "keyset"
+)
+
+// IsValid returns true if the pagination style is known.
+func (p Pagination) IsValid() bool {
+	return p == PaginationOffset || p == PaginationKeyset
+}
+
+// CRUDOptions selects the dialect and safety rules of generated CRUD queries.
+type CRUDOptions struct {
+	// Engine is the sqlc engine: postgresql, mysql or sqlite.
+	Engine string
+	// Rules decide between SELECT * and explicit columns, and where LIMIT clauses go.
+	Rules domain.TypeSafeSafetyRules
+	// Pagination selects offset or keyset List queries; empty means offset.
+	Pagination Pagination
+}
+
+// CRUDQueries returns a sqlc query file with Get, List, Create, Update, Delete and
+// Count queries for table. Placeholders follow the engine ($1 or ?), and RETURNING
+// is used where the engine supports it. Create and Update leave out auto-increment
+// columns and columns with a default; Update sets an updated_at timestamp to the
+// current time. Tables without a primary key only get List, Create and Count.
+// With keyset pagination List is replaced by first, next and previous page queries
+// when the table has a key to order by; see KeysetColumns. Rules that require WHERE
+// on every SELECT only leave the next and previous page queries, which compare with a
+// cursor, and no List at all when there is no key. Count is left out when the rules
+// require WHERE or LIMIT on it. The queries pass the lint rules they were generated for,
+// and a header comment names the queries that were left out.
+func CRUDQueries(table schema.Table, opts CRUDOptions) string {
+	singular := utils.Singularize(table.Name)
+	w := &crudWriter{
+		table:      table,
+		opts:       opts,
+		singular:   utils.StringToCamelCase(singular),
+		plural:     utils.StringToCamelCase(utils.Pluralize(singular)),
+		noun:       strings.ReplaceAll(strings.ToLower(singular), "_", " "),
+		primaryKey: primaryKeyColumns(table),
+	}
+
+	if len(w.primaryKey) == 0 {
+		fmt.Fprintf(&w.b, "-- %s has no primary key, so only List, Create and Count queries are generated.\n",
+			table.Name)
+	}
+
+	var keyset []schema.Column
+
+	// OFFSET pages have no WHERE clause, which the rules may require on every SELECT.
+	whereAlways := opts.Rules.SafetyRules.WhereRequirement == domain.WhereClauseAlways
+
+	switch {
+	case whereAlways:
+		keyset = KeysetColumns(table)
+		if len(keyset) == 0 {
+			fmt.Fprintf(&w.b, "-- The rules require WHERE on every SELECT and %s has no unique key to page by, "+
+				"so no List query is generated.\n", table.Name)
+		} else {
+			fmt.Fprintf(&w.b, "-- The rules require WHERE on every SELECT, so %s has no first page query: "+
+				"List%sNextPage pages on from a cursor.\n", table.Name, w.plural)
+		}
+	case opts.Pagination == PaginationKeyset:
+		keyset = KeysetColumns(table)
+		if len(keyset) == 0 {
+			fmt.Fprintf(&w.b, "-- %s has no unique key to page by, so List uses LIMIT and OFFSET.\n", table.Name)
+		}
+	}
+
+	// count(*) reads the whole table, so a WHERE or LIMIT the rules require does not fit it.
+	countRequires := ""
+
+	switch {
+	case opts.Rules.SafetyRules.WhereRequirement.RequiresOnSelect():
+		countRequires = "WHERE"
+	case w.limitRequired(false):
+		countRequires = "LIMIT"
+	}
+
+	if countRequires != "" {
+		fmt.Fprintf(&w.b, "-- count(*) reads the whole table but the rules require %s on it, so no Count query is generated.\n",
+			countRequires)
+	}
+
+	if w.b.Len() > 0 {
+		w.line("")
+	}
+
+	if len(w.primaryKey) > 0 {
+		w.get()
+	}
+
+	switch {
+	case len(keyset) > 0:
+		w.keysetPages(keyset, !whereAlways)
+	case !whereAlways:
+		w.list()
+	}
+
+	w.create()
+
+	if len(w.primaryKey) > 0 {
+		w.update()
+		w.remove()
+	}
+
+	if countRequires == "" {
+		w.count()
+	}
+
+	return strings.TrimSuffix(w.b.String(), "\n")
+}
+
+// GenerateCRUDQueries writes CRUDQueries for each table to <table>.sql in the output
+// directory and returns the written paths. Existing files are only replaced with force;
+// otherwise nothing is written.
+func (g *Generator) GenerateCRUDQueries(tables []schema.Table, opts CRUDOptions, force bool) ([]string, error) {
+	paths := make([]string, len(tables))
+	for i, table := range tables {
+		paths[i] = filepath.Join(g.outputDir, table.Name+".sql")
+
+		if _, err := os.Stat(paths[i]); err == nil && !force {
+			return nil, fmt.Errorf("query file %s already exists. Use --force to overwrite", paths[i])
+		}
+	}
+
+	err := os.MkdirAll(g.outputDir, 0o750)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create queries directory %s: %w", g.outputDir, err)
+	}
+
+	for i, table := range tables {
+		err := os.WriteFile(paths[i], []byte(CRUDQueries(table, opts)), 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write queries file to %s: %w", paths[i], err)
+		}
+	}
+
+	return paths, nil
+}
+
+// crudWriter renders the CRUD queries of one table.
+type crudWriter struct {
+	table      schema.Table
+	opts       CRUDOptions
+	b          strings.Builder
+	singular   string
+	plural     string
+	noun       string
+	primaryKey []schema.Column
+}
+
+// primaryKeyColumns returns the primary key columns in key order.
+func primaryKeyColumns(table schema.Table) []schema.Column {
+	var names []string
+
+	if table.PrimaryKey != nil {
+		names = table.PrimaryKey.Columns
+	} else {
+		for _, column := range table.Columns {
+			if column.PrimaryKey {
+				names = append(names, column.Name)
+			}
+		}
+	}
+
+	var columns []schema.Column
+
+	for _, name := range names {
+		for _, column := range table.Columns {
+			if column.Name == name {
+				columns = append(columns, column)
+			}
+		}
+	}
+
+	return columns
+}
+
+func (w *crudWriter) get() {
+	w.query("Get"+w.singular, queries.CommandOne, "Get one "+w.noun+" by primary key")
+	w.line("SELECT " + w.columnList())
+	w.line("FROM " + w.ident(w.table.Name))
+	w.line("WHERE " + w.keyCondition(1))
+
+	if w.limitRequired(true) {
+		w.line("LIMIT 1")
+	}
+
+	w.end()
+}
+
+func (w *crudWriter) list() {
+	safety := w.opts.Rules.SafetyRules
+	paginate := w.limitRequired(false) || safety.MaxRowsWithoutLimit > 0 ||
+		safety.WhereRequirement.RequiresOnSelect()
+
+	description := "List all " + utils.Pluralize(w.noun)
+	if paginate {
+		description = "List " + utils.Pluralize(w.noun) + " with pagination"
+	}
+
+	w.query("List"+w.plural, queries.CommandMany, description)
+	w.line("SELECT " + w.columnList())
+	w.line("FROM " + w.ident(w.table.Name))
+
+	if len(w.primaryKey) > 0 {
+		w.line("ORDER BY " + w.identList(w.primaryKey))
+	}
+
+	if paginate {
+		w.line("LIMIT " + w.param(1))
+		w.line("OFFSET " + w.param(2))
+	}
+
+	w.end()
+}
+
+// KeysetColumns returns the ordering key for keyset pagination of table, or nil.
+// The first index over NOT NULL columns that is unique or ends with the whole
+// primary key, such as (created_at, id), is preferred over the primary key itself.
+// Partial indexes and indexes on expressions or with a sort order are not used.
+func KeysetColumns(table schema.Table) []schema.Column {
+	primaryKey := primaryKeyColumns(table)
+
+	for _, index := range table.Indexes {
+		if index.Where != "" || len(index.Columns) == 0 {
+			continue
+		}
+
+		columns := make([]schema.Column, 0, len(index.Columns))
+
+		for _, name := range index.Columns {
+			i := slices.IndexFunc(table.Columns, func(c schema.Column) bool { return c.Name == name })
+			if i < 0 || table.Columns[i].Nullable {
+				break
+			}
+
+			columns = append(columns, table.Columns[i])
+		}
+
+		if len(columns) == len(index.Columns) && (index.Unique || endsWithKey(columns, primaryKey)) {
+			return columns
+		}
+	}
+
+	return primaryKey
+}
+
+// endsWithKey reports whether columns extend key with leading columns.
+func endsWithKey(columns, key []schema.Column) bool {
+	if len(key) == 0 || len(columns) <= len(key) {
+		return false
+	}
+
+	for i, column := range columns[len(columns)-len(key):] {
+		if column.Name != key[i].Name {
+			return false
+		}
+	}
+
+	return true
+}
+
+// keysetPages writes the next and previous page queries of keyset pagination, preceded
+// by the first page query when firstPage is set. Cursor values are named sqlc.arg
+// parameters, so the expanded comparison can repeat them.
+func (w *crudWriter) keysetPages(key []schema.Column, firstPage bool) {
+	order := w.identList(key)
+	nouns := utils.Pluralize(w.noun)
+
+	if firstPage {
+		w.query("List"+w.plural+"FirstPage", queries.CommandMany, "List the first page of "+nouns+" ordered by "+order)
+		w.line("SELECT " + w.columnList())
+		w.line("FROM " + w.ident(w.table.Name))
+		w.line("ORDER BY " + order)
+		w.line("LIMIT sqlc.arg(page_size)")
+		w.end()
+	}
+
+	w.query("List"+w.plural+"NextPage", queries.CommandMany, "List the "+nouns+" after the last row of a page")
+	w.line("SELECT " + w.columnList())
+	w.line("FROM " + w.ident(w.table.Name))
+	w.line("WHERE " + w.keysetCondition(key, ">", "after"))
+	w.line("ORDER BY " + order)
+	w.line("LIMIT sqlc.arg(page_size)")
+	w.end()
+
+	descending := make([]string, len(key))
+	for i, column := range key {
+		descending[i] = w.ident(column.Name) + " DESC"
+	}
+
+	w.query("List"+w.plural+"PreviousPage", queries.CommandMany,
+		"List the "+nouns+" before the first row of a page, nearest first; reverse them for display")
+	w.line("SELECT " + w.columnList())
+	w.line("FROM " + w.ident(w.table.Name))
+	w.line("WHERE " + w.keysetCondition(key, "<", "before"))
+	w.line("ORDER BY " + strings.Join(descending, ", "))
+	w.line("LIMIT sqlc.arg(page_size)")
+	w.end()
+}
+
+// keysetCondition compares the key with the cursor parameters <prefix>_<column>.
+// PostgreSQL compares row values; MySQL and SQLite get the equivalent expanded
+// form (a > x OR (a = x AND b > y)).
+func (w *crudWriter) keysetCondition(key []schema.Column, op, prefix string) string {
+	columns := make([]string, len(key))
+	params := make([]string, len(key))
+
+	for i, column := range key {
+		columns[i] = w.ident(column.Name)
+		params[i] = "sqlc.arg(" + prefix + "_" + strings.ToLower(column.Name) + ")"
+	}
+
+	if len(key) == 1 {
+		return columns[0] + " " + op + " " + params[0]
+	}
+
+	if w.opts.Engine == schema.EnginePostgreSQL {
+		return "(" + strings.Join(columns, ", ") + ") " + op + " (" + strings.Join(params, ", ") + ")"
+	}
+
+	terms := make([]string, len(key))
+
+	for i := range key {
+		conditions := make([]string, 0, i+1)
+		for j := range i {
+			conditions = append(conditions, columns[j]+" = "+params[j])
+		}
+
+		conditions = append(conditions, columns[i]+" "+op+" "+params[i])
+
+		terms[i] = strings.Join(conditions, " AND ")
+		if i > 0 {
+			terms[i] = "(" + terms[i] + ")"
+		}
+	}
+
+	return "(" + strings.Join(terms, "\n    OR ") + ")"
+}
+
+func (w *crudWriter) create() {
+	var columns []schema.Column
+
+	for _, column := range w.table.Columns {
+		if !column.AutoIncrement && column.Default == nil {
+			columns = append(columns, column)
+		}
+	}
+
+	cmd := queries.CommandOne
+	if !w.returning() {
+		cmd = queries.CommandExec
+		if slices.ContainsFunc(w.primaryKey, func(c schema.Column) bool { return c.AutoIncrement }) {
+			cmd = queries.CommandExecLastID
+		}
+	}
+
+	w.query("Create"+w.singular, cmd, "Insert one "+w.noun)
+
+	switch {
+	case len(columns) > 0:
+		w.line("INSERT INTO " + w.ident(w.table.Name) + " (")
+
+		params := make([]string, len(columns))
+		for i, column := range columns {
+			separator := ","
+			if i == len(columns)-1 {
+				separator = ""
+			}
+
+			w.line("    " + w.ident(column.Name) + separator)
+			params[i] = w.param(i + 1)
+		}
+
+		w.line(") VALUES (")
+		w.line("    " + strings.Join(params, ", "))
+		w.line(")")
+	case w.opts.Engine == schema.EngineMySQL:
+		w.line("INSERT INTO " + w.ident(w.table.Name) + " () VALUES ()")
+	default:
+		w.line("INSERT INTO " + w.ident(w.table.Name) + " DEFAULT VALUES")
+	}
+
+	w.returningClause()
+	w.end()
+}
+
+func (w *crudWriter) update() {
+	var columns, touched []schema.Column
+
+	for _, column := range w.table.Columns {
+		switch {
+		case column.PrimaryKey || column.AutoIncrement:
+		case isUpdatedAt(column):
+			touched = append(touched, column)
+		case column.Default == nil:
+			columns = append(columns, column)
+		}
+	}
+
+	if len(columns) == 0 {
+		return
+	}
+
+	cmd := queries.CommandOne
+	if !w.returning() {
+		cmd = queries.CommandExec
+	}
+
+	w.query("Update"+w.singular, cmd, "Update one "+w.noun+" by primary key")
+	w.line("UPDATE " + w.ident(w.table.Name))
+	w.line("SET")
+
+	assignments := make([]string, 0, len(columns)+len(touched))
+	for i, column := range columns {
+		assignments = append(assignments, w.ident(column.Name)+" = "+w.param(i+1))
+	}
+
+	for _, column := range touched {
+		assignments = append(assignments, w.ident(column.Name)+" = CURRENT_TIMESTAMP")
+	}
+
+	w.line("    " + strings.Join(assignments, ",\n    "))
+	w.line("WHERE " + w.keyCondition(len(columns)+1))
+	w.returningClause()
+	w.end()
+}
+
+// isUpdatedAt reports whether column records the time of the last update.
+func isUpdatedAt(column schema.Column) bool {
+	return strings.EqualFold(column.Name, "updated_at") &&
+		(column.Type == schema.ColumnTypeTimestamp || column.Type == schema.ColumnTypeDateTime)
+}
+
+func (w *crudWriter) remove() {
+	w.query("Delete"+w.singular, queries.CommandExec, "Delete one "+w.noun+" by primary key")
+	w.line("DELETE FROM " + w.ident(w.table.Name))
+	w.line("WHERE " + w.keyCondition(1))
+	w.end()
+}
+
+func (w *crudWriter) count() {
+	w.query("Count"+w.plural, queries.CommandOne, "Count all "+utils.Pluralize(w.noun))
+	w.line("SELECT count(*) AS count")
+	w.line("FROM " + w.ident(w.table.Name))
+	w.end()
+}
+
+// keyCondition matches the primary key with placeholders numbered from first.
+func (w *crudWriter) keyCondition(first int) string {
+	conditions := make([]string, len(w.primaryKey))
+	for i, column := range w.primaryKey {
+		conditions[i] = w.ident(column.Name) + " = " + w.param(first+i)
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+// limitRequired reports whether LimitClauseRequirement asks for a LIMIT on a SELECT.
+func (w *crudWriter) limitRequired(hasWhere bool) bool {
+	requirement := w.opts.Rules.SafetyRules.LimitRequirement
+
+	return requirement.RequiresOnSelect() && !(requirement.RequiresWithoutWhere() && hasWhere)
+}
+
+// selectStar reports whether the style rules allow * in select lists and RETURNING.
+func (w *crudWriter) selectStar() bool {
+	style := w.opts.Rules.StyleRules
+
+	return !style.SelectStarPolicy.ForbidsSelectStar() && !style.ColumnExplicitness.RequiresExplicitColumns()
+}
+
+// returning reports whether the engine supports RETURNING; MySQL does not.
+func (w *crudWriter) returning() bool {
+	return w.opts.Engine != schema.EngineMySQL
+}
+
+func (w *crudWriter) returningClause() {
+	if w.returning() {
+		w.line("RETURNING " + w.columnList())
+	}
+}
+
+func (w *crudWriter) columnList() string {
+	if w.selectStar() {
+		return "*"
+	}
+
+	return w.identList(w.table.Columns)
+}
+
+func (w *crudWriter) identList(columns []schema.Column) string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = w.ident(column.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func (w *crudWriter) ident(name string) string {
+	return schema.QuoteIdentifier(w.opts.Engine, name)
+}
+
+// param returns the n-th placeholder of the engine.
+func (w *crudWriter) param(n int) string {
+	if w.opts.Engine == schema.EnginePostgreSQL {
+		return "$" + strconv.Itoa(n)
+	}
+
+	return "?"
+}
+
+func (w *crudWriter) query(name string, cmd queries.Command, description string) {
+	w.line("-- name: " + name + " " + string(cmd))
+	w.line("-- " + description)
+}
+
+func (w *crudWriter) line(text string) {
+	w.b.WriteString(text)
+	w.b.WriteString("\n")
+}
+
+// end terminates the current query and leaves a blank line before the next.
+func (w *crudWriter) end() {
+	text := strings.TrimSuffix(w.b.String(), "\n")
+	w.b.Reset()
+	w.b.WriteString(text + ";\n\n")
+}
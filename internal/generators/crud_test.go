@@ -0,0 +1,317 @@
+package generators_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/domain"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/generators"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/lint"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/schema"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// crudSchema declares users.id with the auto-increment syntax of each engine.
+const crudSchema = `
+CREATE TABLE users (
+    id %s,
+    email VARCHAR(255) NOT NULL,
+    name TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE memberships (
+    user_id INTEGER NOT NULL,
+    team_id INTEGER NOT NULL,
+    role TEXT NOT NULL,
+    PRIMARY KEY (user_id, team_id)
+);
+
+CREATE TABLE audit_entries (
+    message TEXT NOT NULL
+);
+`
+
+var crudIDColumns = map[string]string{
+	schema.EnginePostgreSQL: "SERIAL PRIMARY KEY",
+	schema.EngineMySQL:      "INTEGER PRIMARY KEY AUTO_INCREMENT",
+	schema.EngineSQLite:     "INTEGER PRIMARY KEY",
+}
+
+func parseCRUDSchema(engine string) *schema.Schema {
+	parser, err := schema.NewParser(engine)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(parser.ParseSQL("schema.sql", fmt.Sprintf(crudSchema, crudIDColumns[engine]))).To(Succeed())
+
+	return parser.Schema("app")
+}
+
+var _ = Describe("CRUDQueries", func() {
+	users := func(engine string, rules domain.TypeSafeSafetyRules) string {
+		return generators.CRUDQueries(parseCRUDSchema(engine).Tables[0],
+			generators.CRUDOptions{Engine: engine, Rules: rules})
+	}
+
+	It("should write PostgreSQL queries with numbered placeholders and RETURNING", func() {
+		queries := users(schema.EnginePostgreSQL, domain.NewTypeSafeSafetyRules())
+
+		Expect(queries).To(ContainSubstring("-- name: GetUser :one\n-- Get one user by primary key\n" +
+			"SELECT id, email, name, created_at\nFROM users\nWHERE id = $1;"))
+		Expect(queries).To(ContainSubstring("-- name: ListUsers :many"))
+		Expect(queries).To(ContainSubstring("ORDER BY id\nLIMIT $1\nOFFSET $2;"))
+		Expect(queries).To(ContainSubstring("INSERT INTO users (\n    email,\n    name\n) VALUES (\n    $1, $2\n)\n" +
+			"RETURNING id, email, name, created_at;"))
+		Expect(queries).To(ContainSubstring("SET\n    email = $1,\n    name = $2\nWHERE id = $3"))
+		Expect(queries).To(ContainSubstring("-- name: DeleteUser :exec\n-- Delete one user by primary key\n" +
+			"DELETE FROM users\nWHERE id = $1;"))
+		Expect(queries).To(ContainSubstring("-- name: CountUsers :one"))
+	})
+
+	It("should write MySQL queries without RETURNING", func() {
+		queries := users(schema.EngineMySQL, domain.NewTypeSafeSafetyRules())
+
+		Expect(queries).To(ContainSubstring("WHERE id = ?;"))
+		Expect(queries).To(ContainSubstring("-- name: CreateUser :execlastid"))
+		Expect(queries).To(ContainSubstring("-- name: UpdateUser :exec\n"))
+		Expect(queries).NotTo(ContainSubstring("RETURNING"))
+		Expect(queries).NotTo(ContainSubstring("$1"))
+	})
+
+	It("should use SELECT * only when the preset allows it", func() {
+		Expect(users(schema.EngineSQLite, domain.NewDevelopmentSafetyRules())).
+			To(ContainSubstring("SELECT *\nFROM users\nWHERE id = ?;"))
+		Expect(users(schema.EngineSQLite, domain.NewTypeSafeSafetyRules())).NotTo(ContainSubstring("*\n"))
+	})
+
+	It("should add LIMIT clauses when the preset requires them", func() {
+		queries := users(schema.EnginePostgreSQL, domain.NewProductionSafetyRules())
+
+		Expect(queries).To(ContainSubstring("WHERE id = $1\nLIMIT 1;"))
+		Expect(queries).To(ContainSubstring("the rules require WHERE on it, so no Count query is generated"))
+		Expect(queries).NotTo(ContainSubstring("count(*) AS count"))
+
+		limitOnly := domain.NewTypeSafeSafetyRules()
+		limitOnly.SafetyRules.LimitRequirement = domain.LimitClauseAlways
+		Expect(users(schema.EnginePostgreSQL, limitOnly)).
+			To(ContainSubstring("the rules require LIMIT on it, so no Count query is generated"))
+
+		development := users(schema.EnginePostgreSQL, domain.NewDevelopmentSafetyRules())
+		Expect(development).To(ContainSubstring("-- List all users\nSELECT *\nFROM users\nORDER BY id;"))
+		Expect(development).NotTo(ContainSubstring("LIMIT"))
+	})
+
+	It("should page by key when the preset requires WHERE on every SELECT", func() {
+		queries := users(schema.EngineSQLite, domain.NewProductionSafetyRules())
+
+		Expect(queries).To(HavePrefix("-- The rules require WHERE on every SELECT, so users has no first page query"))
+		Expect(queries).NotTo(ContainSubstring("-- name: ListUsersFirstPage :many"))
+		Expect(queries).To(ContainSubstring("-- name: ListUsersNextPage :many"))
+		Expect(queries).To(ContainSubstring("-- name: ListUsersPreviousPage :many"))
+		Expect(queries).NotTo(ContainSubstring("OFFSET"))
+
+		audit := generators.CRUDQueries(parseCRUDSchema(schema.EngineSQLite).Tables[2],
+			generators.CRUDOptions{Engine: schema.EngineSQLite, Rules: domain.NewProductionSafetyRules()})
+		Expect(audit).To(ContainSubstring("audit_entries has no unique key to page by, so no List query is generated"))
+		Expect(audit).NotTo(ContainSubstring("ListAuditEntries"))
+	})
+
+	It("should leave columns with a default out of Update and touch updated_at", func() {
+		parser, err := schema.NewParser(schema.EnginePostgreSQL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parser.ParseSQL("schema.sql", `CREATE TABLE posts (
+    id BIGSERIAL PRIMARY KEY,
+    title TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'draft',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);`)).To(Succeed())
+
+		queries := generators.CRUDQueries(parser.Schema("app").Tables[0],
+			generators.CRUDOptions{Engine: schema.EnginePostgreSQL, Rules: domain.NewTypeSafeSafetyRules()})
+
+		Expect(queries).To(ContainSubstring("UPDATE posts\nSET\n    title = $1,\n    updated_at = CURRENT_TIMESTAMP\nWHERE id = $2\n"))
+	})
+
+	It("should match composite primary keys", func() {
+		memberships := generators.CRUDQueries(parseCRUDSchema(schema.EnginePostgreSQL).Tables[1],
+			generators.CRUDOptions{Engine: schema.EnginePostgreSQL, Rules: domain.NewTypeSafeSafetyRules()})
+
+		Expect(memberships).To(ContainSubstring("-- name: GetMembership :one"))
+		Expect(memberships).To(ContainSubstring("WHERE user_id = $1 AND team_id = $2;"))
+		Expect(memberships).To(ContainSubstring("SET\n    role = $1\nWHERE user_id = $2 AND team_id = $3"))
+		Expect(memberships).To(ContainSubstring("ORDER BY user_id, team_id"))
+	})
+
+	It("should only generate List, Create and Count for tables without a primary key", func() {
+		audit := generators.CRUDQueries(parseCRUDSchema(schema.EngineSQLite).Tables[2],
+			generators.CRUDOptions{Engine: schema.EngineSQLite, Rules: domain.NewTypeSafeSafetyRules()})
+
+		Expect(audit).To(HavePrefix("-- audit_entries has no primary key"))
+		Expect(audit).To(ContainSubstring("-- name: ListAuditEntries :many"))
+		Expect(audit).To(ContainSubstring("-- name: CreateAuditEntry :one"))
+		Expect(audit).To(ContainSubstring("-- name: CountAuditEntries :one"))
+		Expect(audit).NotTo(ContainSubstring("GetAuditEntry"))
+		Expect(audit).NotTo(ContainSubstring("DeleteAuditEntry"))
+	})
+
+	DescribeTable("should pass lint under the preset it was generated for",
+		func(engine, preset string) {
+			rules, err := lint.RulesForPreset(preset)
+			Expect(err).NotTo(HaveOccurred())
+
+			linter := lint.NewLinter(rules)
+
+			for _, table := range parseCRUDSchema(engine).Tables {
+				queries := generators.CRUDQueries(table, generators.CRUDOptions{Engine: engine, Rules: rules})
+
+				diagnostics, checked := linter.LintSQL(table.Name+".sql", queries)
+				Expect(checked).To(BeNumerically(">", 0))
+				Expect(diagnostics).To(BeEmpty(), "%s: %v", table.Name, diagnostics)
+			}
+		},
+		Entry("PostgreSQL default", schema.EnginePostgreSQL, lint.PresetDefault),
+		Entry("PostgreSQL development", schema.EnginePostgreSQL, lint.PresetDevelopment),
+		Entry("PostgreSQL production", schema.EnginePostgreSQL, lint.PresetProduction),
+		Entry("MySQL default", schema.EngineMySQL, lint.PresetDefault),
+		Entry("MySQL development", schema.EngineMySQL, lint.PresetDevelopment),
+		Entry("MySQL production", schema.EngineMySQL, lint.PresetProduction),
+		Entry("SQLite default", schema.EngineSQLite, lint.PresetDefault),
+		Entry("SQLite development", schema.EngineSQLite, lint.PresetDevelopment),
+		Entry("SQLite production", schema.EngineSQLite, lint.PresetProduction),
+	)
+})
+
+var _ = Describe("Keyset pagination", func() {
+	const eventsSchema = `
+CREATE TABLE events (
+    id BIGINT NOT NULL PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX events_name_idx ON events (name);
+CREATE INDEX events_created_at_id_idx ON events (created_at, id);
+`
+
+	events := func(engine string) schema.Table {
+		parser, err := schema.NewParser(engine)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parser.ParseSQL("schema.sql", eventsSchema)).To(Succeed())
+
+		return parser.Schema("app").Tables[0]
+	}
+
+	keyset := func(table schema.Table, engine string) string {
+		return generators.CRUDQueries(table, generators.CRUDOptions{
+			Engine:     engine,
+			Rules:      domain.NewTypeSafeSafetyRules(),
+			Pagination: generators.PaginationKeyset,
+		})
+	}
+
+	It("should prefer an index that ends with the primary key", func() {
+		columns := generators.KeysetColumns(events(schema.EnginePostgreSQL))
+
+		Expect(columns).To(HaveLen(2))
+		Expect(columns[0].Name).To(Equal("created_at"))
+		Expect(columns[1].Name).To(Equal("id"))
+	})
+
+	It("should fall back to the primary key", func() {
+		users := parseCRUDSchema(schema.EngineSQLite).Tables[0]
+
+		columns := generators.KeysetColumns(users)
+		Expect(columns).To(HaveLen(1))
+		Expect(columns[0].Name).To(Equal("id"))
+
+		queries := keyset(users, schema.EngineSQLite)
+		Expect(queries).To(ContainSubstring("WHERE id > sqlc.arg(after_id)\nORDER BY id\n"))
+		Expect(queries).To(ContainSubstring("WHERE id < sqlc.arg(before_id)\nORDER BY id DESC\n"))
+	})
+
+	It("should compare row values on PostgreSQL", func() {
+		queries := keyset(events(schema.EnginePostgreSQL), schema.EnginePostgreSQL)
+
+		Expect(queries).To(ContainSubstring("-- name: ListEventsFirstPage :many"))
+		Expect(queries).To(ContainSubstring("ORDER BY created_at, id\nLIMIT sqlc.arg(page_size);"))
+		Expect(queries).To(ContainSubstring("-- name: ListEventsNextPage :many"))
+		Expect(queries).To(ContainSubstring(
+			"WHERE (created_at, id) > (sqlc.arg(after_created_at), sqlc.arg(after_id))\nORDER BY created_at, id\n"))
+		Expect(queries).To(ContainSubstring("-- name: ListEventsPreviousPage :many"))
+		Expect(queries).To(ContainSubstring(
+			"WHERE (created_at, id) < (sqlc.arg(before_created_at), sqlc.arg(before_id))\n" +
+				"ORDER BY created_at DESC, id DESC\n"))
+		Expect(queries).NotTo(ContainSubstring("OFFSET"))
+		Expect(queries).NotTo(ContainSubstring("-- name: ListEvents :many"))
+	})
+
+	It("should expand the comparison on MySQL and SQLite", func() {
+		for _, engine := range []string{schema.EngineMySQL, schema.EngineSQLite} {
+			Expect(keyset(events(engine), engine)).To(ContainSubstring(
+				"WHERE (created_at > sqlc.arg(after_created_at)\n" +
+					"    OR (created_at = sqlc.arg(after_created_at) AND id > sqlc.arg(after_id)))\n"))
+		}
+	})
+
+	It("should keep LIMIT and OFFSET for tables without a key", func() {
+		queries := keyset(parseCRUDSchema(schema.EnginePostgreSQL).Tables[2], schema.EnginePostgreSQL)
+
+		Expect(queries).To(ContainSubstring("-- audit_entries has no unique key to page by"))
+		Expect(queries).To(ContainSubstring("LIMIT $1\nOFFSET $2;"))
+	})
+
+	DescribeTable("should pass lint under the preset it was generated for",
+		func(preset string, firstPage bool) {
+			rules, err := lint.RulesForPreset(preset)
+			Expect(err).NotTo(HaveOccurred())
+
+			linter := lint.NewLinter(rules)
+
+			for _, engine := range []string{schema.EnginePostgreSQL, schema.EngineMySQL, schema.EngineSQLite} {
+				queries := generators.CRUDQueries(events(engine), generators.CRUDOptions{
+					Engine:     engine,
+					Rules:      rules,
+					Pagination: generators.PaginationKeyset,
+				})
+				if firstPage {
+					Expect(queries).To(ContainSubstring("-- name: ListEventsFirstPage :many"))
+				} else {
+					Expect(queries).NotTo(ContainSubstring("-- name: ListEventsFirstPage :many"))
+				}
+
+				Expect(queries).To(ContainSubstring("-- name: ListEventsNextPage :many"))
+
+				diagnostics, _ := linter.LintSQL("events.sql", queries)
+				Expect(diagnostics).To(BeEmpty(), "%s: %v", engine, diagnostics)
+			}
+		},
+		Entry("default", lint.PresetDefault, true),
+		Entry("development", lint.PresetDevelopment, true),
+		Entry("production", lint.PresetProduction, false),
+	)
+})
+
+var _ = Describe("Generator CRUD Queries", func() {
+	It("should write one file per table and refuse to overwrite without force", func() {
+		dir := filepath.Join(GinkgoT().TempDir(), "queries")
+		gen := generators.NewGenerator(dir)
+		tables := parseCRUDSchema(schema.EngineSQLite).Tables[:2]
+		opts := generators.CRUDOptions{Engine: schema.EngineSQLite, Rules: domain.NewTypeSafeSafetyRules()}
+
+		paths, err := gen.GenerateCRUDQueries(tables, opts, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paths).To(Equal([]string{filepath.Join(dir, "users.sql"), filepath.Join(dir, "memberships.sql")}))
+
+		content, err := os.ReadFile(paths[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(HavePrefix("-- name: GetUser :one"))
+
+		_, err = gen.GenerateCRUDQueries(tables, opts, false)
+		Expect(err).To(MatchError(ContainSubstring("already exists")))
+
+		_, err = gen.GenerateCRUDQueries(tables, opts, true)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
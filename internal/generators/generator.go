@@ -4,11 +4,20 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/LarsArtmann/SQLC-Wizzard/internal/templates"
 	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
 )
 
+// Default locations of the example files, relative to the output directory.
+const (
+	exampleQueriesDir  = "internal/db/queries"
+	exampleQueriesFile = "users.sql"
+	exampleSchemaDir   = "internal/db/schema"
+	exampleSchemaFile  = "001_users_table.sql"
+)
+
 // Generator handles file generation.
 type Generator struct {
 	outputDir string
@@ -79,33 +88,23 @@ func (g *Generator) generateFileWithTemplate(
 	// Determine directory
 	var (
 		dir             string
-		templateContent func(templates.DatabaseType) string
+		templateContent func(templates.ProjectType, templates.DatabaseType) (string, error)
 	)
 
 	switch templateType {
 	case "queries":
 		dir = data.Output.QueriesDir
-		if dir == "" {
-			dir = defaultDir
-		}
-
-		templateContent = getQueryTemplate
+		templateContent = ExampleQueries
 	case "schema":
 		dir = data.Output.SchemaDir
-		if dir == "" {
-			dir = defaultDir
-		}
-
-		templateContent = getSchemaTemplate
+		templateContent = ExampleSchema
 	default:
 		return fmt.Errorf("unsupported template type %q (dirKey=%s, defaultDir=%s, filename=%s)",
 			templateType, dirKey, defaultDir, filename)
 	}
 
-	// Make it absolute path if relative
-	if !filepath.IsAbs(dir) {
-		dir = filepath.Join(g.outputDir, dir)
-	}
+	outputPath := g.examplePath(dir, defaultDir, filename)
+	dir = filepath.Dir(outputPath)
 
 	// Ensure directory exists
 	err := os.MkdirAll(dir, 0o750)
@@ -113,20 +112,14 @@ func (g *Generator) generateFileWithTemplate(
 		return fmt.Errorf("failed to create %s directory (dir=%s): %w", templateType, dir, err)
 	}
 
-	// Get template content based on database type
-	content := templateContent(data.Database.Engine)
-	if content == "" {
-		return fmt.Errorf(
-			"no %s template for database %s (engine=%s)",
-			templateType,
-			dir,
-			data.Database.Engine,
-		)
+	// Get template content based on project and database type
+	content, err := templateContent(data.ProjectType, data.Database.Engine)
+	if err != nil {
+		return fmt.Errorf("no %s template for project type %s (engine=%s): %w",
+			templateType, data.ProjectType, data.Database.Engine, err)
 	}
 
 	// Write to output
-	outputPath := filepath.Join(dir, filename)
-
 	err = os.WriteFile(outputPath, []byte(content), 0o600)
 	if err != nil {
 		return fmt.Errorf("failed to write %s file to %s: %w", templateType, outputPath, err)
@@ -135,25 +128,60 @@ func (g *Generator) generateFileWithTemplate(
 	return nil
 }
 
-// GenerateExampleQueries copies example query files.
+// examplePath returns the file an example is written to. A configured path ending in
+// .sql is used as the file itself, as sqlc allows; otherwise filename is placed in the
+// configured directory, or defaultDir when none is configured. Relative paths are
+// resolved against the output directory.
+func (g *Generator) examplePath(configured, defaultDir, filename string) string {
+	dir := configured
+	if dir == "" {
+		dir = defaultDir
+	}
+
+	// Make it absolute path if relative
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(g.outputDir, dir)
+	}
+
+	if strings.EqualFold(filepath.Ext(dir), ".sql") {
+		return dir
+	}
+
+	return filepath.Join(dir, filename)
+}
+
+// ExamplePaths returns the files GenerateExampleQueries and GenerateExampleSchema write.
+// The file names depend on the project type.
+func (g *Generator) ExamplePaths(data templates.TemplateData) (queriesPath, schemaPath string) {
+	queriesFile, schemaFile := exampleFiles(data.ProjectType)
+
+	return g.examplePath(data.Output.QueriesDir, exampleQueriesDir, queriesFile),
+		g.examplePath(data.Output.SchemaDir, exampleSchemaDir, schemaFile)
+}
+
+// GenerateExampleQueries copies the example query file of the project type.
 func (g *Generator) GenerateExampleQueries(data templates.TemplateData) error {
+	queriesFile, _ := exampleFiles(data.ProjectType)
+
 	return g.generateFileWithTemplate(
 		data,
 		"queries",
-		"internal/db/queries",
+		exampleQueriesDir,
 		"queries",
-		"users.sql",
+		queriesFile,
 	)
 }
 
-// GenerateExampleSchema copies example schema files.
+// GenerateExampleSchema copies the example schema file of the project type.
 func (g *Generator) GenerateExampleSchema(data templates.TemplateData) error {
+	_, schemaFile := exampleFiles(data.ProjectType)
+
 	return g.generateFileWithTemplate(
 		data,
 		"schema",
-		"internal/db/schema",
+		exampleSchemaDir,
 		"schema",
-		"001_users_table.sql",
+		schemaFile,
 	)
 }
 
@@ -170,7 +198,7 @@ func (g *Generator) GenerateSummary(
 	}
 
 	if includeSchema {
-		summary += "  • Example schema (users table)\n"
+		summary += "  • Example schema (tables for the project type)\n"
 	}
 
 	summary += "\nNext steps:\n"
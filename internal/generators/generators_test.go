@@ -324,4 +324,19 @@ var _ = Describe("File Structure", func() {
 		schemaFile := filepath.Join(tempDir, "schema", "001_users_table.sql")
 		Expect(schemaFile).To(BeARegularFile())
 	})
+
+	It("should write to configured .sql file paths directly", func() {
+		templateData := createTemplateData(generated.DatabaseTypeMySQL, tempDir)
+		templateData.Output.QueriesDir = "db/query.sql"
+		templateData.Output.SchemaDir = "db/schema.sql"
+
+		Expect(gen.GenerateExampleSchema(templateData)).To(Succeed())
+		Expect(gen.GenerateExampleQueries(templateData)).To(Succeed())
+
+		queriesPath, schemaPath := gen.ExamplePaths(templateData)
+		Expect(queriesPath).To(Equal(filepath.Join(tempDir, "db", "query.sql")))
+		Expect(schemaPath).To(Equal(filepath.Join(tempDir, "db", "schema.sql")))
+		Expect(queriesPath).To(BeARegularFile())
+		Expect(schemaPath).To(BeARegularFile())
+	})
 })
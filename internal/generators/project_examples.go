@@ -0,0 +1,637 @@
+package generators
+
+import (
+	"fmt"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/dialect"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/templates"
+)
+
+// projectExample is the example schema and query set of a project type. Both are
+// written for PostgreSQL and translated to the engine of the project.
+type projectExample struct {
+	// name is the stem of the example file names.
+	name    string
+	schema  string
+	queries string
+}
+
+// projectExamples holds the examples of the project types that need more than the
+// users table. Other project types use the users examples.
+var projectExamples = map[templates.ProjectType]projectExample{
+	templates.ProjectTypeAnalytics:   {name: "events", schema: analyticsSchema, queries: analyticsQueries},
+	templates.ProjectTypeMultiTenant: {name: "tenants", schema: multiTenantSchema, queries: multiTenantQueries},
+	templates.ProjectTypeEnterprise:  {name: "audit", schema: enterpriseSchema, queries: enterpriseQueries},
+	templates.ProjectTypeAPIFirst:    {name: "api_clients", schema: apiFirstSchema, queries: apiFirstQueries},
+	templates.ProjectTypeTesting:     {name: "test_runs", schema: testingSchema, queries: testingQueries},
+	templates.ProjectTypeLibrary:     {name: "examples", schema: librarySchema, queries: libraryQueries},
+}
+
+// ExampleSchema returns the example schema of a project type for a database engine.
+func ExampleSchema(projectType templates.ProjectType, engine templates.DatabaseType) (string, error) {
+	example, ok := projectExamples[projectType]
+	if !ok {
+		return usersExample(getSchemaTemplate, engine)
+	}
+
+	return translateExample(example.schema, engine)
+}
+
+// ExampleQueries returns the queries matching ExampleSchema for a database engine.
+func ExampleQueries(projectType templates.ProjectType, engine templates.DatabaseType) (string, error) {
+	example, ok := projectExamples[projectType]
+	if !ok {
+		return usersExample(getQueryTemplate, engine)
+	}
+
+	return translateExample(example.queries, engine)
+}
+
+// exampleFiles returns the file names of the example queries and schema of a project type.
+func exampleFiles(projectType templates.ProjectType) (queriesFile, schemaFile string) {
+	example, ok := projectExamples[projectType]
+	if !ok {
+		return exampleQueriesFile, exampleSchemaFile
+	}
+
+	return example.name + ".sql", "001_" + example.name + "_schema.sql"
+}
+
+func usersExample(template func(templates.DatabaseType) string, engine templates.DatabaseType) (string, error) {
+	content := template(engine)
+	if content == "" {
+		return "", fmt.Errorf("no example for database %q", engine)
+	}
+
+	return content, nil
+}
+
+// translateExample translates a PostgreSQL example to engine. Removing RETURNING for
+// MySQL is expected; any other note means the example needs a construct of its own.
+func translateExample(src string, engine templates.DatabaseType) (string, error) {
+	translator, err := dialect.NewTranslator(dialect.EnginePostgreSQL, string(engine))
+	if err != nil {
+		return "", err
+	}
+
+	result, err := translator.Translate(src)
+	if err != nil {
+		return "", err
+	}
+
+	for _, note := range result.Notes {
+		if note.Construct != "returning" {
+			return "", fmt.Errorf("example does not translate to %s: %s", engine, note)
+		}
+	}
+
+	return result.SQL, nil
+}
+
+const analyticsSchema = `-- Example analytics schema: raw events and pre-computed daily metrics
+CREATE TABLE IF NOT EXISTS events (
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    user_id VARCHAR(64),
+    properties JSONB,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_events_type_occurred_at ON events(event_type, occurred_at);
+CREATE INDEX idx_events_occurred_at ON events(occurred_at);
+
+CREATE TABLE IF NOT EXISTS daily_metrics (
+    metric_name VARCHAR(100) NOT NULL,
+    bucket_date DATE NOT NULL,
+    metric_value BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (metric_name, bucket_date)
+);
+`
+
+const analyticsQueries = `-- name: RecordEvent :one
+-- Record a single event
+INSERT INTO events (
+    event_type,
+    user_id,
+    properties
+) VALUES (
+    $1, $2, $3
+)
+RETURNING id, event_type, user_id, properties, occurred_at;
+
+-- name: ListRecentEvents :many
+-- List the newest events of a type
+SELECT id, event_type, user_id, properties, occurred_at
+FROM events
+WHERE event_type = $1
+ORDER BY occurred_at DESC
+LIMIT $2;
+
+-- name: CountEventsByHour :many
+-- Count the events of a type per hour in a time range
+SELECT date_trunc('hour', occurred_at) AS bucket, count(*) AS event_count
+FROM events
+WHERE event_type = $1 AND occurred_at >= $2 AND occurred_at < $3
+GROUP BY date_trunc('hour', occurred_at)
+ORDER BY bucket
+LIMIT 1000;
+
+-- name: CountActiveUsersByDay :many
+-- Count the distinct users per day in a time range
+SELECT date_trunc('day', occurred_at) AS bucket, count(DISTINCT user_id) AS active_users
+FROM events
+WHERE occurred_at >= $1 AND occurred_at < $2
+GROUP BY date_trunc('day', occurred_at)
+ORDER BY bucket
+LIMIT 1000;
+
+-- name: CreateDailyMetric :exec
+-- Start a daily metric at a value
+INSERT INTO daily_metrics (
+    metric_name,
+    bucket_date,
+    metric_value
+) VALUES (
+    $1, $2, $3
+);
+
+-- name: IncrementDailyMetric :exec
+-- Add to an existing daily metric
+UPDATE daily_metrics
+SET
+    metric_value = metric_value + $1,
+    updated_at = NOW()
+WHERE metric_name = $2 AND bucket_date = $3;
+
+-- name: ListDailyMetrics :many
+-- List a metric per day in a date range
+SELECT metric_name, bucket_date, metric_value, updated_at
+FROM daily_metrics
+WHERE metric_name = $1 AND bucket_date >= $2 AND bucket_date <= $3
+ORDER BY bucket_date
+LIMIT 366;
+`
+
+const multiTenantSchema = `-- Example multi-tenant schema: every row belongs to a tenant
+CREATE TABLE IF NOT EXISTS tenants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    full_name VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, email),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS projects (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    owner_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (tenant_id) REFERENCES tenants(id) ON DELETE CASCADE,
+    FOREIGN KEY (owner_id) REFERENCES users(id)
+);
+
+CREATE INDEX idx_projects_tenant_created_at ON projects(tenant_id, created_at);
+`
+
+const multiTenantQueries = `-- name: CreateTenant :one
+-- Create a tenant; the slug identifies it in URLs
+INSERT INTO tenants (
+    name,
+    slug
+) VALUES (
+    $1, $2
+)
+RETURNING id, name, slug, created_at;
+
+-- name: GetTenantBySlug :one
+-- Get a tenant by its slug
+SELECT id, name, slug, created_at
+FROM tenants
+WHERE slug = $1
+LIMIT 1;
+
+-- name: CreateUser :one
+-- Create a user in a tenant
+INSERT INTO users (
+    tenant_id,
+    email,
+    full_name
+) VALUES (
+    $1, $2, $3
+)
+RETURNING id, tenant_id, email, full_name, created_at;
+
+-- name: GetUserByEmail :one
+-- Every query filters on tenant_id, so a tenant never sees the rows of another
+SELECT id, tenant_id, email, full_name, created_at
+FROM users
+WHERE tenant_id = $1 AND email = $2
+LIMIT 1;
+
+-- name: ListUsers :many
+-- List the users of a tenant with pagination
+SELECT id, tenant_id, email, full_name, created_at
+FROM users
+WHERE tenant_id = $1
+ORDER BY created_at DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: CreateProject :one
+-- Create a project owned by a user of the tenant
+INSERT INTO projects (
+    tenant_id,
+    owner_id,
+    name
+) VALUES (
+    $1, $2, $3
+)
+RETURNING id, tenant_id, owner_id, name, created_at;
+
+-- name: GetProject :one
+-- Get a project of a tenant
+SELECT id, tenant_id, owner_id, name, created_at
+FROM projects
+WHERE tenant_id = $1 AND id = $2
+LIMIT 1;
+
+-- name: ListProjects :many
+-- List the projects of a tenant with pagination
+SELECT id, tenant_id, owner_id, name, created_at
+FROM projects
+WHERE tenant_id = $1
+ORDER BY created_at DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: CountProjects :one
+-- Count the projects of a tenant
+SELECT count(*) AS project_count
+FROM projects
+WHERE tenant_id = $1;
+
+-- name: DeleteProject :exec
+-- Delete a project of a tenant
+DELETE FROM projects
+WHERE tenant_id = $1 AND id = $2;
+`
+
+const enterpriseSchema = `-- Example enterprise schema: users with roles and an audit log of their changes
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    email VARCHAR(255) NOT NULL UNIQUE,
+    full_name VARCHAR(255),
+    role VARCHAR(50) NOT NULL DEFAULT 'member',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id UUID,
+    action VARCHAR(100) NOT NULL,
+    resource_type VARCHAR(100) NOT NULL,
+    resource_id VARCHAR(255) NOT NULL,
+    old_values JSONB,
+    new_values JSONB,
+    ip_address VARCHAR(45),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_audit_logs_resource ON audit_logs(resource_type, resource_id, created_at);
+CREATE INDEX idx_audit_logs_actor ON audit_logs(actor_id, created_at);
+`
+
+const enterpriseQueries = `-- name: GetUser :one
+-- Get a single user by ID
+SELECT id, email, full_name, role, is_active, created_at, updated_at
+FROM users
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetUserByEmail :one
+-- Get a user by their email address
+SELECT id, email, full_name, role, is_active, created_at, updated_at
+FROM users
+WHERE email = $1
+LIMIT 1;
+
+-- name: ListActiveUsers :many
+-- List active users with pagination
+SELECT id, email, full_name, role, is_active, created_at, updated_at
+FROM users
+WHERE is_active = true
+ORDER BY created_at DESC
+LIMIT $1
+OFFSET $2;
+
+-- name: CreateUser :one
+-- Create a new user
+INSERT INTO users (
+    email,
+    full_name,
+    role
+) VALUES (
+    $1, $2, $3
+)
+RETURNING id, email, full_name, role, is_active, created_at, updated_at;
+
+-- name: UpdateUserRole :exec
+-- Change the role of a user; record an audit log entry in the same transaction
+UPDATE users
+SET
+    role = $1,
+    updated_at = NOW()
+WHERE id = $2;
+
+-- name: DeactivateUser :exec
+-- Deactivate a user instead of deleting them, so their audit trail stays intact
+UPDATE users
+SET
+    is_active = false,
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: RecordAuditLog :exec
+-- Record who changed what
+INSERT INTO audit_logs (
+    actor_id,
+    action,
+    resource_type,
+    resource_id,
+    old_values,
+    new_values,
+    ip_address
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: ListAuditLogsForResource :many
+-- List the history of a resource, newest first
+SELECT id, actor_id, action, resource_type, resource_id, old_values, new_values, ip_address, created_at
+FROM audit_logs
+WHERE resource_type = $1 AND resource_id = $2
+ORDER BY created_at DESC
+LIMIT $3;
+
+-- name: ListAuditLogsByActor :many
+-- List what a user changed since a point in time
+SELECT id, actor_id, action, resource_type, resource_id, old_values, new_values, ip_address, created_at
+FROM audit_logs
+WHERE actor_id = $1 AND created_at >= $2
+ORDER BY created_at DESC
+LIMIT $3;
+`
+
+const apiFirstSchema = `-- Example API schema: API clients and a request log used for rate limiting
+CREATE TABLE IF NOT EXISTS api_clients (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    api_key_hash VARCHAR(255) NOT NULL UNIQUE,
+    requests_per_minute INTEGER NOT NULL DEFAULT 60,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS api_requests (
+    id BIGSERIAL PRIMARY KEY,
+    client_id UUID NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path VARCHAR(255) NOT NULL,
+    status_code INTEGER NOT NULL,
+    duration_ms INTEGER NOT NULL,
+    requested_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (client_id) REFERENCES api_clients(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_api_requests_client_requested_at ON api_requests(client_id, requested_at);
+`
+
+const apiFirstQueries = `-- name: CreateAPIClient :one
+-- Register a client; only the hash of its API key is stored
+INSERT INTO api_clients (
+    name,
+    api_key_hash,
+    requests_per_minute
+) VALUES (
+    $1, $2, $3
+)
+RETURNING id, name, requests_per_minute, created_at, revoked_at;
+
+-- name: GetAPIClientByKeyHash :one
+-- Authenticate a request by the hash of its API key
+SELECT id, name, requests_per_minute, created_at, revoked_at
+FROM api_clients
+WHERE api_key_hash = $1 AND revoked_at IS NULL
+LIMIT 1;
+
+-- name: ListAPIClients :many
+-- List clients with pagination
+SELECT id, name, requests_per_minute, created_at, revoked_at
+FROM api_clients
+WHERE revoked_at IS NULL
+ORDER BY created_at DESC
+LIMIT $1
+OFFSET $2;
+
+-- name: RevokeAPIClient :exec
+-- Revoke the API key of a client
+UPDATE api_clients
+SET revoked_at = NOW()
+WHERE id = $1;
+
+-- name: LogRequest :exec
+-- Log a handled request
+INSERT INTO api_requests (
+    client_id,
+    method,
+    path,
+    status_code,
+    duration_ms
+) VALUES (
+    $1, $2, $3, $4, $5
+);
+
+-- name: CountRequestsSince :one
+-- Count the requests of a client since the start of the rate limit window
+SELECT count(*) AS request_count
+FROM api_requests
+WHERE client_id = $1 AND requested_at >= $2;
+`
+
+const testingSchema = `-- Example testing schema: test runs, their results and named fixtures
+CREATE TABLE IF NOT EXISTS test_runs (
+    id BIGSERIAL PRIMARY KEY,
+    suite VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running',
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_test_runs_suite_started_at ON test_runs(suite, started_at);
+
+CREATE TABLE IF NOT EXISTS test_results (
+    id BIGSERIAL PRIMARY KEY,
+    run_id BIGINT NOT NULL,
+    test_name VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    duration_ms INTEGER NOT NULL,
+    failure_message TEXT,
+    FOREIGN KEY (run_id) REFERENCES test_runs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_test_results_run_id ON test_results(run_id);
+
+CREATE TABLE IF NOT EXISTS fixtures (
+    name VARCHAR(255) PRIMARY KEY,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+`
+
+const testingQueries = `-- name: StartTestRun :one
+-- Start a run of a test suite
+INSERT INTO test_runs (
+    suite
+) VALUES (
+    $1
+)
+RETURNING id, suite, status, started_at, finished_at;
+
+-- name: FinishTestRun :exec
+-- Record the outcome of a run
+UPDATE test_runs
+SET
+    status = $1,
+    finished_at = NOW()
+WHERE id = $2;
+
+-- name: ListTestRuns :many
+-- List the latest runs of a suite
+SELECT id, suite, status, started_at, finished_at
+FROM test_runs
+WHERE suite = $1
+ORDER BY started_at DESC
+LIMIT $2;
+
+-- name: RecordTestResult :exec
+-- Record the result of a single test
+INSERT INTO test_results (
+    run_id,
+    test_name,
+    status,
+    duration_ms,
+    failure_message
+) VALUES (
+    $1, $2, $3, $4, $5
+);
+
+-- name: ListFailedTestResults :many
+-- List the failed tests of a run
+SELECT id, run_id, test_name, status, duration_ms, failure_message
+FROM test_results
+WHERE run_id = $1 AND status = 'failed'
+ORDER BY test_name
+LIMIT $2;
+
+-- name: CountTestResultsByStatus :many
+-- Count the results of a run per status
+SELECT status, count(*) AS result_count
+FROM test_results
+WHERE run_id = $1
+GROUP BY status
+ORDER BY status
+LIMIT 10;
+
+-- name: CreateFixture :exec
+-- Store a named fixture
+INSERT INTO fixtures (
+    name,
+    payload
+) VALUES (
+    $1, $2
+);
+
+-- name: GetFixture :one
+-- Load a named fixture
+SELECT name, payload, created_at
+FROM fixtures
+WHERE name = $1
+LIMIT 1;
+
+-- name: DeleteFixture :exec
+-- Remove a named fixture
+DELETE FROM fixtures
+WHERE name = $1;
+`
+
+const librarySchema = `-- Example library schema: code examples published with the library
+CREATE TABLE IF NOT EXISTS examples (
+    id BIGSERIAL PRIMARY KEY,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    code_snippet TEXT NOT NULL,
+    language VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_examples_language_created_at ON examples(language, created_at);
+`
+
+const libraryQueries = `-- name: GetExample :one
+-- Get an example by its slug
+SELECT id, slug, title, description, code_snippet, language, created_at
+FROM examples
+WHERE slug = $1
+LIMIT 1;
+
+-- name: ListExamplesByLanguage :many
+-- List the examples of a language with pagination
+SELECT id, slug, title, description, code_snippet, language, created_at
+FROM examples
+WHERE language = $1
+ORDER BY created_at DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: CreateExample :one
+-- Publish a new example
+INSERT INTO examples (
+    slug,
+    title,
+    description,
+    code_snippet,
+    language
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING id, slug, title, description, code_snippet, language, created_at;
+
+-- name: UpdateExample :exec
+-- Update the text of an example
+UPDATE examples
+SET
+    title = $1,
+    description = $2,
+    code_snippet = $3
+WHERE id = $4;
+
+-- name: DeleteExample :exec
+-- Delete an example
+DELETE FROM examples
+WHERE id = $1;
+`
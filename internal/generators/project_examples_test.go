@@ -0,0 +1,131 @@
+package generators_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/generated"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/generators"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/lint"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/queries"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/schema"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var allExampleProjectTypes = []generated.ProjectType{
+	generated.ProjectTypeHobby,
+	generated.ProjectTypeMicroservice,
+	generated.ProjectTypeEnterprise,
+	generated.ProjectTypeAPIFirst,
+	generated.ProjectTypeAnalytics,
+	generated.ProjectTypeTesting,
+	generated.ProjectTypeMultiTenant,
+	generated.ProjectTypeLibrary,
+}
+
+// referencedTables returns the tables a query reads from or writes to.
+func referencedTables(query queries.Query) []string {
+	var tables []string
+
+	tokens := query.Statement.Tokens
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i].Is("FROM") || tokens[i].Is("INTO") || (i == 0 && tokens[i].Is("UPDATE")) {
+			tables = append(tables, tokens[i+1].Value)
+		}
+	}
+
+	return tables
+}
+
+var _ = Describe("Project type examples", func() {
+	DescribeTable("should parse, lint and only query tables of the schema",
+		func(engine generated.DatabaseType) {
+			rules, err := lint.RulesForPreset(lint.PresetDefault)
+			Expect(err).NotTo(HaveOccurred())
+
+			linter := lint.NewLinter(rules)
+
+			for _, projectType := range allExampleProjectTypes {
+				ddl, err := generators.ExampleSchema(projectType, engine)
+				Expect(err).NotTo(HaveOccurred(), "%s schema", projectType)
+
+				parser, err := schema.NewParser(string(engine))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parser.ParseSQL("schema.sql", ddl)).To(Succeed(), "%s schema", projectType)
+
+				parsed := parser.Schema(string(projectType))
+				Expect(parsed.Tables).NotTo(BeEmpty())
+
+				src, err := generators.ExampleQueries(projectType, engine)
+				Expect(err).NotTo(HaveOccurred(), "%s queries", projectType)
+
+				diagnostics, checked := linter.LintSQL("queries.sql", src)
+				Expect(checked).To(BeNumerically(">", 0))
+				Expect(diagnostics).To(BeEmpty(), "%s queries", projectType)
+
+				file, err := queries.Parse("queries.sql", src)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, query := range file.Queries {
+					Expect(query.HasHeader()).To(BeTrue())
+
+					for _, table := range referencedTables(query) {
+						_, ok := parsed.GetTable(table)
+						Expect(ok).To(BeTrue(), "%s: %s uses %s", projectType, query.Name, table)
+					}
+				}
+			}
+		},
+		Entry("PostgreSQL", generated.DatabaseTypePostgreSQL),
+		Entry("MySQL", generated.DatabaseTypeMySQL),
+		Entry("SQLite", generated.DatabaseTypeSQLite),
+	)
+
+	It("should bucket analytics events by time in every engine", func() {
+		for engine, bucket := range map[generated.DatabaseType]string{
+			generated.DatabaseTypePostgreSQL: "date_trunc('hour', occurred_at)",
+			generated.DatabaseTypeMySQL:      "DATE_FORMAT(occurred_at, '%Y-%m-%d %H:00:00')",
+			generated.DatabaseTypeSQLite:     "strftime('%Y-%m-%d %H:00:00', occurred_at)",
+		} {
+			src, err := generators.ExampleQueries(generated.ProjectTypeAnalytics, engine)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(src).To(ContainSubstring(bucket))
+		}
+	})
+
+	It("should scope multi-tenant queries to a tenant", func() {
+		src, err := generators.ExampleQueries(generated.ProjectTypeMultiTenant, generated.DatabaseTypeMySQL)
+		Expect(err).NotTo(HaveOccurred())
+
+		file, err := queries.Parse("tenants.sql", src)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, query := range file.Queries {
+			if query.Kind == queries.StatementSelect && query.Name != "GetTenantBySlug" {
+				Expect(query.SQL).To(ContainSubstring("tenant_id = ?"), query.Name)
+			}
+		}
+
+		Expect(file.Query("CreateTenant").Cmd).To(Equal(queries.CommandExec), "MySQL has no RETURNING")
+	})
+
+	It("should write the examples of the project type", func() {
+		tempDir := GinkgoT().TempDir()
+		gen := generators.NewGenerator(tempDir)
+		data := createTemplateData(generated.DatabaseTypeSQLite, tempDir)
+		data.ProjectType = generated.ProjectTypeEnterprise
+
+		Expect(gen.GenerateExampleSchema(data)).To(Succeed())
+		Expect(gen.GenerateExampleQueries(data)).To(Succeed())
+
+		queriesPath, schemaPath := gen.ExamplePaths(data)
+		Expect(queriesPath).To(Equal(filepath.Join(tempDir, "queries", "audit.sql")))
+		Expect(schemaPath).To(Equal(filepath.Join(tempDir, "schema", "001_audit_schema.sql")))
+
+		content, err := os.ReadFile(schemaPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("CREATE TABLE IF NOT EXISTS audit_logs"))
+		Expect(string(content)).To(ContainSubstring("INTEGER PRIMARY KEY AUTOINCREMENT"))
+	})
+})
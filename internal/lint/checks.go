@@ -0,0 +1,370 @@
+package lint
+
+import (
+	"strconv"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/domain"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/queries"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+)
+
+// mainVerbs are the statement keywords that decide the kind of a query.
+var mainVerbs = map[string]bool{
+	"SELECT": true, "INSERT": true, "UPDATE": true, "DELETE": true, "REPLACE": true,
+	"MERGE": true, "VALUES": true, "TRUNCATE": true, "DROP": true, "COPY": true,
+}
+
+// finding is a rule violation before it is attached to a file and query.
+type finding struct {
+	pos      sqlparse.Position
+	rule     string
+	severity apperrors.ErrorSeverity
+	message  string
+}
+
+// statementInfo is the top-level shape of a statement relevant to the safety rules.
+type statementInfo struct {
+	verb     string
+	verbPos  sqlparse.Position
+	hasWhere bool
+	hasLimit bool
+	// limit is the literal row limit, or -1 when absent or parameterised.
+	limit    int
+	limitPos sqlparse.Position
+}
+
+// analyze scans the top level of a statement (outside parentheses) for its verb,
+// WHERE and LIMIT clauses. CTE bodies and subqueries are skipped.
+func analyze(tokens []sqlparse.Token) statementInfo {
+	info := statementInfo{limit: -1}
+	depth := 0
+
+	for i, tok := range tokens {
+		switch {
+		case tok.IsPunct("("):
+			depth++
+
+			continue
+		case tok.IsPunct(")"):
+			depth--
+
+			continue
+		case depth != 0 || tok.Kind != sqlparse.TokenWord:
+			continue
+		}
+
+		keyword := tok.Upper()
+
+		if info.verb == "" {
+			if mainVerbs[keyword] {
+				info.verb, info.verbPos = keyword, tok.Pos
+			}
+
+			continue
+		}
+
+		switch keyword {
+		case "WHERE":
+			info.hasWhere = true
+		case "LIMIT", "FETCH", "TOP":
+			info.hasLimit = true
+			info.limitPos = tok.Pos
+			info.limit = literalLimit(tokens[i+1:])
+		}
+	}
+
+	return info
+}
+
+// literalLimit reads the row count following LIMIT/FETCH FIRST/TOP.
+// MySQL's "LIMIT offset, count" form is supported; placeholders yield -1.
+func literalLimit(rest []sqlparse.Token) int {
+	var numbers []int
+
+	for _, tok := range rest {
+		if tok.Is("FIRST") || tok.Is("NEXT") || tok.IsPunct(",") {
+			continue
+		}
+
+		if tok.Kind != sqlparse.TokenNumber {
+			break
+		}
+
+		n, err := strconv.Atoi(tok.Text)
+		if err != nil {
+			return -1
+		}
+
+		numbers = append(numbers, n)
+	}
+
+	if len(numbers) == 0 {
+		return -1
+	}
+
+	return numbers[len(numbers)-1]
+}
+
+// checkStatement applies every enabled rule to a statement.
+func (l *Linter) checkStatement(stmt *sqlparse.Statement, cmd queries.Command) []finding {
+	info := analyze(stmt.Tokens)
+
+	var findings []finding
+
+	findings = append(findings, l.checkStyle(stmt.Tokens)...)
+	findings = append(findings, l.checkWhere(info)...)
+	findings = append(findings, l.checkLimit(info, cmd)...)
+	findings = append(findings, l.checkDestructive(stmt)...)
+
+	return findings
+}
+
+// checkStyle enforces SelectStarPolicy and ColumnExplicitness.
+func (l *Linter) checkStyle(tokens []sqlparse.Token) []finding {
+	style := l.rules.StyleRules
+	explicit := style.SelectStarPolicy.RequiresExplicitColumns() ||
+		style.ColumnExplicitness.RequiresExplicitColumns()
+
+	var findings []finding
+
+	for i, tok := range tokens {
+		if !tok.IsPunct("*") || i == 0 {
+			continue
+		}
+
+		prev := tokens[i-1]
+
+		switch {
+		case prev.Is("SELECT") || prev.Is("DISTINCT") || prev.Is("ALL") || isSelectListComma(tokens, i):
+			if style.SelectStarPolicy.ForbidsSelectStar() {
+				findings = append(findings, finding{
+					pos:      tok.Pos,
+					rule:     RuleNoSelectStar,
+					severity: apperrors.ErrorSeverityError,
+					message:  "SELECT * is not allowed - use explicit column names",
+				})
+			}
+		case prev.IsPunct(".") && explicit:
+			findings = append(findings, finding{
+				pos:      tokens[max(i-2, 0)].Pos,
+				rule:     RuleRequireExplicitColumns,
+				severity: apperrors.ErrorSeverityError,
+				message:  "qualified * is not allowed - list the columns or use sqlc.embed()",
+			})
+		case prev.Is("RETURNING") && explicit:
+			findings = append(findings, finding{
+				pos:      tok.Pos,
+				rule:     RuleRequireExplicitColumns,
+				severity: apperrors.ErrorSeverityError,
+				message:  "RETURNING * is not allowed - list the returned columns",
+			})
+		}
+	}
+
+	if style.ColumnExplicitness == domain.ColumnExplicitnessNamed {
+		findings = append(findings, unaliasedExpressions(tokens)...)
+	}
+
+	return findings
+}
+
+// isSelectListComma reports whether the "*" at index i directly follows a comma in a select list.
+func isSelectListComma(tokens []sqlparse.Token, i int) bool {
+	if !tokens[i-1].IsPunct(",") {
+		return false
+	}
+
+	next := sqlparse.Token{}
+	if i+1 < len(tokens) {
+		next = tokens[i+1]
+	}
+
+	// "a, * FROM" or "a, *, b"; function arguments such as count(a, *) are not select lists.
+	return next.Is("FROM") || next.IsPunct(",") || i+1 == len(tokens)
+}
+
+// unaliasedExpressions reports top-level select-list expressions without an alias,
+// which sqlc would otherwise name column_1, column_2, ...
+func unaliasedExpressions(tokens []sqlparse.Token) []finding {
+	var findings []finding
+
+	start := -1
+
+	for i, tok := range tokens {
+		if tok.Is("SELECT") {
+			start = i + 1
+
+			break
+		}
+	}
+
+	if start < 0 {
+		return nil
+	}
+
+	end := len(tokens)
+	depth := 0
+
+	for i := start; i < len(tokens); i++ {
+		switch {
+		case tokens[i].IsPunct("("):
+			depth++
+		case tokens[i].IsPunct(")"):
+			depth--
+		case depth == 0 && tokens[i].Is("FROM"):
+			end = i
+		}
+
+		if end != len(tokens) {
+			break
+		}
+	}
+
+	for _, item := range sqlparse.SplitTopLevel(tokens[start:end]) {
+		if len(item) == 0 || isPlainColumn(item) || hasAlias(item) {
+			continue
+		}
+
+		findings = append(findings, finding{
+			pos:      item[0].Pos,
+			rule:     RuleRequireColumnAliases,
+			severity: apperrors.ErrorSeverityWarning,
+			message:  "select expression has no alias - add AS <name>",
+		})
+	}
+
+	return findings
+}
+
+func isPlainColumn(item []sqlparse.Token) bool {
+	switch {
+	case len(item) == 1:
+		return item[0].IsIdent() || item[0].IsPunct("*")
+	case len(item) == 3:
+		return item[0].IsIdent() && item[1].IsPunct(".") && (item[2].IsIdent() || item[2].IsPunct("*"))
+	default:
+		return item[0].Is("DISTINCT") && isPlainColumn(item[1:])
+	}
+}
+
+func hasAlias(item []sqlparse.Token) bool {
+	n := len(item)
+
+	return n >= 2 && item[n-1].IsIdent() && (item[n-2].Is("AS") || item[n-2].IsPunct(")"))
+}
+
+// checkWhere enforces WhereClauseRequirement.
+func (l *Linter) checkWhere(info statementInfo) []finding {
+	requirement := l.rules.SafetyRules.WhereRequirement
+
+	var required bool
+
+	switch info.verb {
+	case "UPDATE", "DELETE":
+		required = requirement.RequiresOnDestructive()
+	case "SELECT":
+		// select_unlimited only asks for WHERE when the result is also unbounded.
+		required = requirement == domain.WhereClauseAlways ||
+			(requirement == domain.WhereClauseOnSelect && !info.hasLimit)
+	}
+
+	if !required || info.hasWhere {
+		return nil
+	}
+
+	return []finding{{
+		pos:      info.verbPos,
+		rule:     RuleRequireWhere,
+		severity: apperrors.ErrorSeverityError,
+		message:  info.verb + " without WHERE clause affects every row",
+	}}
+}
+
+// checkLimit enforces LimitClauseRequirement and MaxRowsWithoutLimit.
+func (l *Linter) checkLimit(info statementInfo, cmd queries.Command) []finding {
+	if info.verb != "SELECT" {
+		return nil
+	}
+
+	safety := l.rules.SafetyRules
+
+	var findings []finding
+
+	requireLimit := safety.LimitRequirement.RequiresOnSelect() &&
+		!(safety.LimitRequirement.RequiresWithoutWhere() && info.hasWhere)
+
+	if requireLimit && !info.hasLimit {
+		findings = append(findings, finding{
+			pos:      info.verbPos,
+			rule:     RuleRequireLimit,
+			severity: apperrors.ErrorSeverityError,
+			message:  "LIMIT clause is required for SELECT queries",
+		})
+	}
+
+	maxRows := safety.MaxRowsWithoutLimit
+	if maxRows == 0 {
+		return findings
+	}
+
+	switch {
+	case info.hasLimit && info.limit > 0 && uint(info.limit) > maxRows:
+		findings = append(findings, finding{
+			pos:      info.limitPos,
+			rule:     RuleMaxRowsWithoutLimit,
+			severity: apperrors.ErrorSeverityError,
+			message:  "LIMIT " + strconv.Itoa(info.limit) + " exceeds the maximum of " + strconv.FormatUint(uint64(maxRows), 10) + " rows",
+		})
+	case !info.hasLimit && !requireLimit && !cmd.ReturnsSingleRow():
+		findings = append(findings, finding{
+			pos:      info.verbPos,
+			rule:     RuleMaxRowsWithoutLimit,
+			severity: apperrors.ErrorSeverityWarning,
+			message:  "SELECT without LIMIT may return more than " + strconv.FormatUint(uint64(maxRows), 10) + " rows",
+		})
+	}
+
+	return findings
+}
+
+// checkDestructive enforces DestructiveOperationPolicy for DROP TABLE and TRUNCATE.
+func (l *Linter) checkDestructive(stmt *sqlparse.Statement) []finding {
+	policy := l.rules.DestructiveOps
+	if policy == domain.DestructiveAllowed || len(stmt.Tokens) == 0 {
+		return nil
+	}
+
+	var rule, confirmRule, operation, allowName string
+
+	first := stmt.Tokens[0]
+
+	switch {
+	case first.Is("DROP") && len(stmt.Tokens) > 1 && stmt.Tokens[1].Is("TABLE"):
+		rule, confirmRule, operation, allowName = RuleNoDropTable, RuleDropTableRequiresConfirmation, "DROP TABLE", OperationDropTable
+	case first.Is("TRUNCATE"):
+		rule, confirmRule, operation, allowName = RuleNoTruncate, RuleTruncateRequiresConfirmation, "TRUNCATE", OperationTruncate
+	default:
+		return nil
+	}
+
+	if policy.RequiresConfirmation() {
+		if allowedOperations(stmt.Comments)[allowName] {
+			return nil
+		}
+
+		return []finding{{
+			pos:      first.Pos,
+			rule:     confirmRule,
+			severity: apperrors.ErrorSeverityError,
+			message:  operation + " requires explicit confirmation" + allowHint(allowName),
+		}}
+	}
+
+	return []finding{{
+		pos:      first.Pos,
+		rule:     rule,
+		severity: apperrors.ErrorSeverityError,
+		message:  operation + " is forbidden by safety policy",
+	}}
+}
@@ -0,0 +1,85 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+)
+
+// Rule names match the sqlc rule names produced by validation.RuleTransformer,
+// so a local finding maps onto the rule `sqlc vet` would report.
+const (
+	RuleNoSelectStar                   = "no-select-star"
+	RuleRequireExplicitColumns         = "require-explicit-columns"
+	RuleRequireColumnAliases           = "require-column-aliases"
+	RuleRequireWhere                   = "require-where"
+	RuleRequireLimit                   = "require-limit"
+	RuleMaxRowsWithoutLimit            = "max-rows-without-limit"
+	RuleNoDropTable                    = "no-drop-table"
+	RuleNoTruncate                     = "no-truncate"
+	RuleDropTableRequiresConfirmation  = "drop-table-requires-confirmation"
+	RuleTruncateRequiresConfirmation   = "truncate-requires-confirmation"
+	RuleNoDropColumn                   = "no-drop-column"
+	RuleDropColumnRequiresConfirmation = "drop-column-requires-confirmation"
+	RuleAlterColumnType                = "alter-column-type"
+	RuleNotNullWithoutDefault          = "not-null-without-default"
+	RuleNonConcurrentIndex             = "non-concurrent-index"
+	RuleRename                         = "rename"
+	RuleSyntax                         = "syntax"
+)
+
+// Diagnostic is a single rule violation at a position in a query file.
+type Diagnostic struct {
+	File     string                  `json:"file"`
+	Line     int                     `json:"line"`
+	Column   int                     `json:"column"`
+	Query    string                  `json:"query,omitempty"`
+	Rule     string                  `json:"rule"`
+	Severity apperrors.ErrorSeverity `json:"severity"`
+	Message  string                  `json:"message"`
+}
+
+// String formats the diagnostic as "file:line:col: severity [rule] Query: message".
+func (d Diagnostic) String() string {
+	query := ""
+	if d.Query != "" {
+		query = d.Query + ": "
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %s [%s] %s%s",
+		d.File, d.Line, d.Column, d.Severity, d.Rule, query, d.Message)
+}
+
+// Result summarises a lint run.
+type Result struct {
+	Diagnostics    []Diagnostic `json:"diagnostics"`
+	FilesChecked   int          `json:"files_checked"`
+	QueriesChecked int          `json:"queries_checked"`
+}
+
+// ErrorCount returns the number of error-level diagnostics.
+func (r *Result) ErrorCount() int {
+	return r.count(apperrors.ErrorSeverityError)
+}
+
+// WarningCount returns the number of warning-level diagnostics.
+func (r *Result) WarningCount() int {
+	return r.count(apperrors.ErrorSeverityWarning)
+}
+
+// HasErrors returns true if any diagnostic is an error.
+func (r *Result) HasErrors() bool {
+	return r.ErrorCount() > 0
+}
+
+func (r *Result) count(severity apperrors.ErrorSeverity) int {
+	n := 0
+
+	for _, d := range r.Diagnostics {
+		if d.Severity == severity {
+			n++
+		}
+	}
+
+	return n
+}
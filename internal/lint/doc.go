@@ -0,0 +1,3 @@
+// Package lint provides offline enforcement of the type-safe safety rules
+// against sqlc query files and golang-migrate migration files.
+package lint
@@ -0,0 +1,355 @@
+package lint_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/domain"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/lint"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestLint(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Lint Suite")
+}
+
+func rulesOf(diagnostics []lint.Diagnostic) []string {
+	rules := make([]string, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		rules = append(rules, d.Rule)
+	}
+
+	return rules
+}
+
+var _ = Describe("Linter", func() {
+	Describe("RulesForPreset", func() {
+		It("should map presets onto the domain constructors", func() {
+			rules, err := lint.RulesForPreset(lint.PresetProduction)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rules).To(Equal(domain.NewProductionSafetyRules()))
+		})
+
+		It("should reject unknown presets", func() {
+			_, err := lint.RulesForPreset("yolo")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("RulesForConfig", func() {
+		It("should read the rule names of a sql entry", func() {
+			cfg := &config.SqlcConfig{
+				SQL: []config.SQLConfig{{Rules: []string{
+					"no-select-star", "require-where", "max-rows-without-limit",
+					"truncate-requires-confirmation", "sqlc/db-prepare",
+				}}},
+				Rules: []config.RuleConfig{{
+					Name: "max-rows-without-limit",
+					Rule: "query.type == 'SELECT' && (!query.hasLimitClause() || query.limitValue() > 250)",
+				}},
+			}
+
+			rules, ok := lint.RulesForConfig(cfg, &cfg.SQL[0])
+			Expect(ok).To(BeTrue())
+			Expect(rules.StyleRules.SelectStarPolicy).To(Equal(domain.SelectStarForbidden))
+			Expect(rules.SafetyRules.WhereRequirement).To(Equal(domain.WhereClauseOnDestructive))
+			Expect(rules.SafetyRules.LimitRequirement).To(Equal(domain.LimitClauseNever))
+			Expect(rules.SafetyRules.MaxRowsWithoutLimit).To(BeEquivalentTo(250))
+			Expect(rules.DestructiveOps).To(Equal(domain.DestructiveWithConfirmation))
+		})
+
+		It("should report entries without known rules", func() {
+			cfg := &config.SqlcConfig{SQL: []config.SQLConfig{{Rules: []string{"sqlc/db-prepare"}}}}
+
+			_, ok := lint.RulesForConfig(cfg, &cfg.SQL[0])
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("with default rules", func() {
+		linter := lint.NewLinter(domain.NewTypeSafeSafetyRules())
+
+		It("should report violations with file, line and query name", func() {
+			src := `-- name: ListUsers :many
+SELECT * FROM users LIMIT 10;
+
+-- name: DeleteAll :exec
+DELETE FROM users;
+
+-- name: Wipe :exec
+TRUNCATE users;
+`
+			diagnostics, queries := linter.LintSQL("queries/users.sql", src)
+			Expect(queries).To(Equal(3))
+
+			Expect(rulesOf(diagnostics)).To(Equal([]string{
+				lint.RuleNoSelectStar, lint.RuleRequireWhere, lint.RuleNoTruncate,
+			}))
+
+			Expect(diagnostics[0].File).To(Equal("queries/users.sql"))
+			Expect(diagnostics[0].Line).To(Equal(2))
+			Expect(diagnostics[0].Column).To(Equal(8))
+			Expect(diagnostics[0].Query).To(Equal("ListUsers"))
+			Expect(diagnostics[0].String()).To(HavePrefix("queries/users.sql:2:8: error [no-select-star] ListUsers:"))
+
+			Expect(diagnostics[1].Line).To(Equal(5))
+			Expect(diagnostics[1].Query).To(Equal("DeleteAll"))
+			Expect(diagnostics[2].Query).To(Equal("Wipe"))
+		})
+
+		It("should not flag expressions that only look like SELECT *", func() {
+			src := `-- name: CountUsers :one
+SELECT count(*), 2 * 3 AS six FROM users WHERE note = 'SELECT * FROM x';
+`
+			diagnostics, _ := linter.LintSQL("q.sql", src)
+			Expect(diagnostics).To(BeEmpty())
+		})
+
+		It("should warn about unbounded :many queries but not :one", func() {
+			src := `-- name: ListUsers :many
+SELECT id FROM users WHERE active;
+
+-- name: GetUser :one
+SELECT id FROM users WHERE id = $1;
+
+-- name: PageUsers :many
+SELECT id FROM users WHERE active LIMIT 5000;
+`
+			diagnostics, _ := linter.LintSQL("q.sql", src)
+			Expect(rulesOf(diagnostics)).To(Equal([]string{
+				lint.RuleMaxRowsWithoutLimit, lint.RuleMaxRowsWithoutLimit,
+			}))
+			Expect(diagnostics[0].Severity).To(Equal(apperrors.ErrorSeverityWarning))
+			Expect(diagnostics[1].Severity).To(Equal(apperrors.ErrorSeverityError))
+			Expect(diagnostics[1].Query).To(Equal("PageUsers"))
+		})
+
+		It("should only inspect the top level for WHERE", func() {
+			src := `-- name: DeleteStale :exec
+WITH stale AS (SELECT id FROM sessions WHERE expired)
+DELETE FROM sessions;
+`
+			diagnostics, _ := linter.LintSQL("q.sql", src)
+			Expect(rulesOf(diagnostics)).To(Equal([]string{lint.RuleRequireWhere}))
+			Expect(diagnostics[0].Line).To(Equal(3))
+		})
+
+		It("should report tokenizer errors as syntax diagnostics", func() {
+			diagnostics, _ := linter.LintSQL("q.sql", "-- name: Broken :one\nSELECT 'oops;\n")
+			Expect(rulesOf(diagnostics)).To(Equal([]string{lint.RuleSyntax}))
+			Expect(diagnostics[0].Line).To(Equal(2))
+		})
+	})
+
+	Context("with production rules", func() {
+		linter := lint.NewLinter(domain.NewProductionSafetyRules())
+
+		It("should require WHERE and LIMIT on every SELECT", func() {
+			diagnostics, _ := linter.LintSQL("q.sql", "-- name: All :many\nSELECT id FROM users;\n")
+			Expect(rulesOf(diagnostics)).To(ConsistOf(lint.RuleRequireWhere, lint.RuleRequireLimit))
+		})
+
+		It("should require WHERE on every SELECT, first pages and aggregates included", func() {
+			src := `-- name: CountUsers :one
+SELECT count(*) AS count FROM users LIMIT 1;
+
+-- name: ListUsersFirstPage :many
+SELECT id FROM users ORDER BY id LIMIT $1;
+
+-- name: ListUsersNextPage :many
+SELECT id FROM users WHERE id > $1 ORDER BY id LIMIT $2;
+`
+			diagnostics, _ := linter.LintSQL("q.sql", src)
+			Expect(rulesOf(diagnostics)).To(Equal([]string{lint.RuleRequireWhere, lint.RuleRequireWhere}))
+			Expect(diagnostics[0].Query).To(Equal("CountUsers"))
+			Expect(diagnostics[1].Query).To(Equal("ListUsersFirstPage"))
+		})
+
+		It("should forbid qualified stars and RETURNING *", func() {
+			src := `-- name: Posts :many
+SELECT p.* FROM posts p WHERE p.id = $1 LIMIT 1;
+
+-- name: CreatePost :one
+INSERT INTO posts (title) VALUES ($1) RETURNING *;
+`
+			diagnostics, _ := linter.LintSQL("q.sql", src)
+			Expect(rulesOf(diagnostics)).To(Equal([]string{
+				lint.RuleRequireExplicitColumns, lint.RuleRequireExplicitColumns,
+			}))
+			Expect(diagnostics[0].Column).To(Equal(8))
+		})
+	})
+
+	Context("with confirmation required for destructive operations", func() {
+		rules := domain.NewDevelopmentSafetyRules()
+		rules.DestructiveOps = domain.DestructiveWithConfirmation
+		linter := lint.NewLinter(rules)
+
+		It("should accept statements acknowledged by the allow pragma only", func() {
+			src := `-- name: DropLegacy :exec
+-- sqlc-wizard:allow drop_table
+DROP TABLE legacy;
+
+-- name: DropOther :exec
+-- NOT CONFIRMED, sqlc-wizard:allow truncate
+DROP TABLE other;
+
+-- name: DropLater :exec
+-- sqlc-wizard:allow drop_table_later
+DROP TABLE later;
+
+-- name: TruncateLog :exec
+-- sqlc-wizard:allow truncate
+TRUNCATE log;
+`
+			diagnostics, _ := linter.LintSQL("q.sql", src)
+			Expect(rulesOf(diagnostics)).To(Equal([]string{
+				lint.RuleDropTableRequiresConfirmation, lint.RuleDropTableRequiresConfirmation,
+			}))
+			Expect(diagnostics[0].Query).To(Equal("DropOther"))
+			Expect(diagnostics[0].Message).To(ContainSubstring("-- sqlc-wizard:allow drop_table)"))
+			Expect(diagnostics[1].Query).To(Equal("DropLater"))
+		})
+	})
+
+	Context("with named columns required", func() {
+		rules := domain.NewDevelopmentSafetyRules()
+		rules.StyleRules.ColumnExplicitness = domain.ColumnExplicitnessNamed
+		linter := lint.NewLinter(rules)
+
+		It("should warn about unaliased expressions", func() {
+			src := "-- name: Stats :one\nSELECT id, count(*), lower(email) AS email, u.name FROM users u;\n"
+			diagnostics, _ := linter.LintSQL("q.sql", src)
+			Expect(rulesOf(diagnostics)).To(Equal([]string{lint.RuleRequireColumnAliases}))
+			Expect(diagnostics[0].Column).To(Equal(12))
+		})
+	})
+
+	Describe("LintMigrationSQL", func() {
+		It("should forbid destructive DDL under the default rules", func() {
+			src := "DROP TABLE legacy;\nALTER TABLE users DROP COLUMN nickname;\nTRUNCATE audit;\n"
+			diagnostics, statements := lint.NewLinter(domain.NewTypeSafeSafetyRules()).
+				LintMigrationSQL("1_cleanup.up.sql", src, "postgresql")
+			Expect(statements).To(Equal(3))
+			Expect(rulesOf(diagnostics)).To(Equal([]string{
+				lint.RuleNoDropTable, lint.RuleNoDropColumn, lint.RuleNoTruncate,
+			}))
+			Expect(diagnostics[1].Line).To(Equal(2))
+			Expect(diagnostics[1].Severity).To(Equal(apperrors.ErrorSeverityError))
+		})
+
+		Context("with confirmation required for destructive operations", func() {
+			rules := domain.NewDevelopmentSafetyRules()
+			rules.DestructiveOps = domain.DestructiveWithConfirmation
+			linter := lint.NewLinter(rules)
+
+			It("should require the allow pragma", func() {
+				diagnostics, _ := linter.LintMigrationSQL("m.up.sql", "DROP TABLE legacy;\n", "sqlite")
+				Expect(rulesOf(diagnostics)).To(Equal([]string{lint.RuleDropTableRequiresConfirmation}))
+				Expect(diagnostics[0].Message).To(ContainSubstring("-- sqlc-wizard:allow drop_table"))
+			})
+
+			It("should accept operations acknowledged by the pragma", func() {
+				src := "-- sqlc-wizard:allow drop_table, drop_column\nDROP TABLE legacy;\nALTER TABLE users DROP COLUMN nickname;\n"
+				diagnostics, _ := linter.LintMigrationSQL("m.up.sql", src, "sqlite")
+				Expect(diagnostics).To(BeEmpty())
+			})
+		})
+
+		It("should allow destructive DDL under the development rules", func() {
+			diagnostics, _ := lint.NewLinter(domain.NewDevelopmentSafetyRules()).
+				LintMigrationSQL("m.up.sql", "DROP TABLE legacy;\nTRUNCATE audit;\n", "postgresql")
+			Expect(diagnostics).To(BeEmpty())
+		})
+
+		It("should warn about risky column changes and renames", func() {
+			src := `ALTER TABLE users ADD COLUMN age int NOT NULL;
+ALTER TABLE users ADD COLUMN score int NOT NULL DEFAULT 0;
+ALTER TABLE users ALTER COLUMN id TYPE bigint;
+ALTER TABLE users RENAME COLUMN name TO full_name;
+`
+			diagnostics, _ := lint.NewLinter(domain.NewDevelopmentSafetyRules()).
+				LintMigrationSQL("m.up.sql", src, "postgresql")
+			Expect(rulesOf(diagnostics)).To(Equal([]string{
+				lint.RuleNotNullWithoutDefault, lint.RuleAlterColumnType, lint.RuleRename,
+			}))
+			Expect(diagnostics[0].Severity).To(Equal(apperrors.ErrorSeverityWarning))
+		})
+
+		It("should only flag non-concurrent indexes on existing PostgreSQL tables", func() {
+			src := `CREATE TABLE posts (id int, author_id int NOT NULL);
+CREATE INDEX posts_author_idx ON posts (author_id);
+CREATE INDEX CONCURRENTLY users_name_idx ON users (name);
+CREATE INDEX users_email_idx ON users (email);
+`
+			linter := lint.NewLinter(domain.NewDevelopmentSafetyRules())
+
+			diagnostics, _ := linter.LintMigrationSQL("m.up.sql", src, "postgresql")
+			Expect(rulesOf(diagnostics)).To(Equal([]string{lint.RuleNonConcurrentIndex}))
+			Expect(diagnostics[0].Line).To(Equal(4))
+
+			diagnostics, _ = linter.LintMigrationSQL("m.up.sql", src, "mysql")
+			Expect(diagnostics).To(BeEmpty())
+		})
+
+		It("should not flag NOT NULL columns on tables created in the same file", func() {
+			src := "CREATE TABLE posts (id int);\nALTER TABLE posts ADD COLUMN title text NOT NULL;\n"
+			diagnostics, _ := lint.NewLinter(domain.NewDevelopmentSafetyRules()).
+				LintMigrationSQL("m.up.sql", src, "postgresql")
+			Expect(diagnostics).To(BeEmpty())
+		})
+
+		It("should lint migration files from disk", func() {
+			dir := GinkgoT().TempDir()
+			file := filepath.Join(dir, "1_drop.up.sql")
+			Expect(os.WriteFile(file, []byte("DROP TABLE legacy;\n"), 0o644)).To(Succeed())
+
+			result, err := lint.NewLinter(domain.NewTypeSafeSafetyRules()).
+				LintMigrationFiles([]string{file}, "sqlite")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.FilesChecked).To(Equal(1))
+			Expect(result.HasErrors()).To(BeTrue())
+			Expect(result.Diagnostics[0].File).To(Equal(file))
+		})
+	})
+
+	Describe("LintConfig", func() {
+		It("should lint every queries path of sqlc.yaml", func() {
+			dir := GinkgoT().TempDir()
+			Expect(os.MkdirAll(filepath.Join(dir, "queries"), 0o755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "queries", "a.sql"),
+				[]byte("-- name: A :many\nSELECT * FROM a;\n"), 0o644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "queries", "b.sql"),
+				[]byte("-- name: B :one\nSELECT id FROM b WHERE id = $1;\n"), 0o644)).To(Succeed())
+
+			cfg := &config.SqlcConfig{
+				Version: "2",
+				SQL: []config.SQLConfig{
+					{Engine: "postgresql", Queries: config.NewSinglePath("queries")},
+					{Engine: "postgresql", Queries: config.NewSinglePath("queries/a.sql")},
+				},
+			}
+
+			result, err := lint.NewLinter(domain.NewTypeSafeSafetyRules()).LintConfig(cfg, dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.FilesChecked).To(Equal(2))
+			Expect(result.QueriesChecked).To(Equal(2))
+			Expect(result.HasErrors()).To(BeTrue())
+			Expect(result.Diagnostics[0].File).To(Equal(filepath.Join(dir, "queries", "a.sql")))
+		})
+
+		It("should fail when a queries path does not exist", func() {
+			cfg := &config.SqlcConfig{
+				SQL: []config.SQLConfig{{Engine: "sqlite", Queries: config.NewSinglePath("missing")}},
+			}
+
+			_, err := lint.NewLinter(domain.NewTypeSafeSafetyRules()).LintConfig(cfg, GinkgoT().TempDir())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,227 @@
+package lint
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/generated"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/domain"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/queries"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+)
+
+// Safety rule presets selectable from the command line.
+const (
+	PresetDefault     = "default"
+	PresetDevelopment = "development"
+	PresetProduction  = "production"
+)
+
+// RulesForPreset returns the safety rules for a named preset.
+func RulesForPreset(preset string) (domain.TypeSafeSafetyRules, error) {
+	switch preset {
+	case PresetDefault, "":
+		return domain.NewTypeSafeSafetyRules(), nil
+	case PresetDevelopment:
+		return domain.NewDevelopmentSafetyRules(), nil
+	case PresetProduction:
+		return domain.NewProductionSafetyRules(), nil
+	default:
+		return domain.TypeSafeSafetyRules{}, apperrors.Newf(
+			apperrors.ErrorCodeInvalidValue,
+			"unknown lint preset %q (must be one of: default, development, production)",
+			preset,
+		)
+	}
+}
+
+// maxRowsPattern reads the row count from the max-rows-without-limit rule expression.
+var maxRowsPattern = regexp.MustCompile(`limitValue\(\)\s*>\s*(\d+)`)
+
+// RulesForConfig returns the safety rules named in the rules list of a sql[] entry.
+// Names are the sqlc rule names the wizard writes (see validation.RuleTransformer) and
+// are read like the legacy SafetyRules booleans; other names are ignored. It returns
+// false when the entry names none of them, so callers can fall back to a preset.
+func RulesForConfig(cfg *config.SqlcConfig, sql *config.SQLConfig) (domain.TypeSafeSafetyRules, bool) {
+	var (
+		legacy  generated.SafetyRules
+		names   = map[string]bool{}
+		matched bool
+	)
+
+	for _, name := range sql.Rules {
+		switch name {
+		case RuleNoSelectStar:
+			legacy.NoSelectStar = true
+		case RuleRequireWhere:
+			legacy.RequireWhere = true
+		case RuleRequireLimit:
+			legacy.RequireLimit = true
+		case RuleNoDropTable:
+			legacy.NoDropTable = true
+		case RuleNoTruncate:
+			legacy.NoTruncate = true
+		case RuleRequireExplicitColumns, RuleMaxRowsWithoutLimit,
+			RuleDropTableRequiresConfirmation, RuleTruncateRequiresConfirmation:
+		default:
+			continue
+		}
+
+		names[name] = true
+		matched = true
+	}
+
+	if !matched {
+		return domain.TypeSafeSafetyRules{}, false
+	}
+
+	rules := domain.SafetyRulesToTypeSafe(legacy)
+
+	if names[RuleRequireExplicitColumns] {
+		rules.StyleRules.ColumnExplicitness = domain.ColumnExplicitnessRequired
+	}
+
+	if names[RuleDropTableRequiresConfirmation] || names[RuleTruncateRequiresConfirmation] {
+		rules.DestructiveOps = domain.DestructiveWithConfirmation
+	}
+
+	if rule := cfg.Rule(RuleMaxRowsWithoutLimit); names[RuleMaxRowsWithoutLimit] && rule != nil {
+		if match := maxRowsPattern.FindStringSubmatch(rule.Rule); match != nil {
+			if n, err := strconv.ParseUint(match[1], 10, 0); err == nil {
+				rules.SafetyRules.MaxRowsWithoutLimit = uint(n)
+			}
+		}
+	}
+
+	return rules, true
+}
+
+// Linter checks query files against type-safe safety rules without sqlc or a database.
+type Linter struct {
+	rules domain.TypeSafeSafetyRules
+}
+
+// NewLinter creates a linter enforcing the given rules.
+func NewLinter(rules domain.TypeSafeSafetyRules) *Linter {
+	return &Linter{rules: rules}
+}
+
+// LintConfig lints every query file referenced by the sql[] entries of cfg.
+// Relative query paths are resolved against baseDir.
+func (l *Linter) LintConfig(cfg *config.SqlcConfig, baseDir string) (*Result, error) {
+	var files []string
+
+	seen := map[string]bool{}
+
+	for _, sql := range cfg.SQL {
+		queryFiles, err := sql.Queries.SQLFiles(baseDir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range queryFiles {
+			if !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+
+	return l.LintFiles(files)
+}
+
+// LintFiles lints the given query files and returns all diagnostics sorted by position.
+func (l *Linter) LintFiles(files []string) (*Result, error) {
+	result := &Result{Diagnostics: []Diagnostic{}}
+
+	for _, file := range files {
+		diagnostics, checked, err := l.lintFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		result.FilesChecked++
+		result.QueriesChecked += checked
+		result.Diagnostics = append(result.Diagnostics, diagnostics...)
+	}
+
+	sort.SliceStable(result.Diagnostics, func(i, j int) bool {
+		a, b := result.Diagnostics[i], result.Diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+
+		return a.Column < b.Column
+	})
+
+	return result, nil
+}
+
+func (l *Linter) lintFile(path string) ([]Diagnostic, int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, apperrors.FileNotFoundError(path)
+		}
+
+		return nil, 0, apperrors.FileReadError(path, err)
+	}
+
+	diagnostics, checked := l.LintSQL(path, string(data))
+
+	return diagnostics, checked, nil
+}
+
+// LintSQL lints query source text. file is only used to label diagnostics.
+// It returns the diagnostics and the number of queries checked.
+// Source that cannot be tokenized yields a single syntax diagnostic.
+func (l *Linter) LintSQL(file, src string) ([]Diagnostic, int) {
+	parsed, err := queries.Parse(file, src)
+	if err != nil {
+		pos := sqlparse.Position{Line: 1, Column: 1}
+		message := err.Error()
+
+		var syntaxErr *sqlparse.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			pos, message = syntaxErr.Pos, syntaxErr.Message
+		}
+
+		return []Diagnostic{{
+			File:     file,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     RuleSyntax,
+			Severity: apperrors.ErrorSeverityError,
+			Message:  message,
+		}}, 0
+	}
+
+	var diagnostics []Diagnostic
+
+	for i := range parsed.Queries {
+		query := &parsed.Queries[i]
+
+		for _, finding := range l.checkStatement(&query.Statement, query.Cmd) {
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     file,
+				Line:     finding.pos.Line,
+				Column:   finding.pos.Column,
+				Query:    query.Name,
+				Rule:     finding.rule,
+				Severity: finding.severity,
+				Message:  finding.message,
+			})
+		}
+	}
+
+	return diagnostics, len(parsed.Queries)
+}
@@ -0,0 +1,397 @@
+package lint
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/domain"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+)
+
+// Operations that can be acknowledged in a migration file, or in the comments of a
+// query, with "-- sqlc-wizard:allow <operation>[, <operation>...]".
+const (
+	OperationDropTable             = "drop_table"
+	OperationDropColumn            = "drop_column"
+	OperationTruncate              = "truncate"
+	OperationAlterColumnType       = "alter_column_type"
+	OperationNotNullWithoutDefault = "not_null_without_default"
+	OperationNonConcurrentIndex    = "non_concurrent_index"
+	OperationRename                = "rename"
+)
+
+// allowPragma matches the migration pragma acknowledging risky operations.
+var allowPragma = regexp.MustCompile(`(?i)sqlc-wizard:allow\s+([a-z_,\s]+)`)
+
+// ddlKeywordsAfterDrop are ALTER TABLE ... DROP targets that are not columns.
+var ddlKeywordsAfterDrop = map[string]bool{
+	"CONSTRAINT": true, "INDEX": true, "KEY": true, "PRIMARY": true,
+	"FOREIGN": true, "CHECK": true, "PARTITION": true, "DEFAULT": true,
+}
+
+// ddlKeywordsAfterAdd are ALTER TABLE ... ADD targets that are not columns.
+var ddlKeywordsAfterAdd = map[string]bool{
+	"CONSTRAINT": true, "INDEX": true, "KEY": true, "PRIMARY": true, "UNIQUE": true,
+	"FOREIGN": true, "CHECK": true, "PARTITION": true, "FULLTEXT": true, "SPATIAL": true,
+}
+
+// migrationCheck is a risky operation found in a migration statement.
+type migrationCheck struct {
+	pos       sqlparse.Position
+	operation string
+	message   string
+}
+
+// LintMigrationFiles checks migration files for destructive and risky DDL.
+// engine is the sqlc engine of the target database and enables dialect-specific checks.
+func (l *Linter) LintMigrationFiles(files []string, engine string) (*Result, error) {
+	result := &Result{Diagnostics: []Diagnostic{}}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, apperrors.FileNotFoundError(file)
+			}
+
+			return nil, apperrors.FileReadError(file, err)
+		}
+
+		diagnostics, checked := l.LintMigrationSQL(file, string(data), engine)
+
+		result.FilesChecked++
+		result.QueriesChecked += checked
+		result.Diagnostics = append(result.Diagnostics, diagnostics...)
+	}
+
+	return result, nil
+}
+
+// LintMigrationSQL checks the DDL of one migration file against DestructiveOperationPolicy
+// and for operations that lock or break running code. DROP TABLE, DROP COLUMN and TRUNCATE
+// follow the policy: forbidden is an error, with_confirmation is an error unless the file
+// acknowledges the operation with an allow pragma, and allowed is silent. Other risky
+// operations are warnings unless acknowledged. It returns the diagnostics and the number
+// of statements checked.
+func (l *Linter) LintMigrationSQL(file, src, engine string) ([]Diagnostic, int) {
+	tokens, err := sqlparse.Tokenize(src)
+	if err != nil {
+		pos := sqlparse.Position{Line: 1, Column: 1}
+		message := err.Error()
+
+		var syntaxErr *sqlparse.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			pos, message = syntaxErr.Pos, syntaxErr.Message
+		}
+
+		return []Diagnostic{{
+			File:     file,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     RuleSyntax,
+			Severity: apperrors.ErrorSeverityError,
+			Message:  message,
+		}}, 0
+	}
+
+	allowed := allowedOperations(tokens)
+	statements := sqlparse.SplitTokens(src, tokens)
+	created := createdTables(statements)
+
+	var diagnostics []Diagnostic
+
+	for i := range statements {
+		for _, check := range migrationChecks(&statements[i], engine, created) {
+			rule, severity, message, report := l.classifyMigrationCheck(check, allowed[check.operation])
+			if !report {
+				continue
+			}
+
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     file,
+				Line:     check.pos.Line,
+				Column:   check.pos.Column,
+				Rule:     rule,
+				Severity: severity,
+				Message:  message,
+			})
+		}
+	}
+
+	return diagnostics, len(statements)
+}
+
+// classifyMigrationCheck maps a risky operation onto a rule and severity.
+func (l *Linter) classifyMigrationCheck(
+	check migrationCheck,
+	acknowledged bool,
+) (rule string, severity apperrors.ErrorSeverity, message string, report bool) {
+	pragma := allowHint(check.operation)
+
+	var forbidRule, confirmRule string
+
+	switch check.operation {
+	case OperationDropTable:
+		forbidRule, confirmRule = RuleNoDropTable, RuleDropTableRequiresConfirmation
+	case OperationDropColumn:
+		forbidRule, confirmRule = RuleNoDropColumn, RuleDropColumnRequiresConfirmation
+	case OperationTruncate:
+		forbidRule, confirmRule = RuleNoTruncate, RuleTruncateRequiresConfirmation
+	default:
+		if acknowledged {
+			return "", "", "", false
+		}
+
+		return riskyOperationRules[check.operation], apperrors.ErrorSeverityWarning, check.message + pragma, true
+	}
+
+	policy := l.rules.DestructiveOps
+
+	switch {
+	case policy.RequiresConfirmation():
+		if acknowledged {
+			return "", "", "", false
+		}
+
+		return confirmRule, apperrors.ErrorSeverityError, check.message + " requires explicit confirmation" + pragma, true
+	case policy == domain.DestructiveAllowed:
+		return "", "", "", false
+	default:
+		return forbidRule, apperrors.ErrorSeverityError, check.message + " is forbidden by safety policy", true
+	}
+}
+
+// riskyOperationRules maps the non-destructive risky operations onto their rule names.
+var riskyOperationRules = map[string]string{
+	OperationAlterColumnType:       RuleAlterColumnType,
+	OperationNotNullWithoutDefault: RuleNotNullWithoutDefault,
+	OperationNonConcurrentIndex:    RuleNonConcurrentIndex,
+	OperationRename:                RuleRename,
+}
+
+// allowHint returns the message suffix telling how to acknowledge an operation.
+func allowHint(operation string) string {
+	return " (acknowledge with: -- sqlc-wizard:allow " + operation + ")"
+}
+
+// allowedOperations collects the operations acknowledged by the allow pragmas among
+// tokens: anywhere in a migration file, or in the comments of a query. Operation names
+// are matched whole, so "drop_table_later" does not acknowledge drop_table.
+func allowedOperations(tokens []sqlparse.Token) map[string]bool {
+	allowed := map[string]bool{}
+
+	for _, tok := range tokens {
+		if tok.Kind != sqlparse.TokenComment {
+			continue
+		}
+
+		for _, match := range allowPragma.FindAllStringSubmatch(tok.Text, -1) {
+			for _, name := range strings.FieldsFunc(match[1], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+				allowed[strings.ToLower(name)] = true
+			}
+		}
+	}
+
+	return allowed
+}
+
+// createdTables returns the lower-cased names of the tables created in the file;
+// indexes on them cannot block running code.
+func createdTables(statements []sqlparse.Statement) map[string]bool {
+	created := map[string]bool{}
+
+	for _, stmt := range statements {
+		c := sqlparse.NewCursor(stmt.Text, stmt.Tokens)
+		if !c.Accept("CREATE") {
+			continue
+		}
+
+		c.Accept("TEMP", "TEMPORARY", "UNLOGGED")
+
+		if !c.Accept("TABLE") {
+			continue
+		}
+
+		if c.Accept("IF") {
+			c.Accept("NOT")
+			c.Accept("EXISTS")
+		}
+
+		if name, err := c.QualifiedIdent(); err == nil {
+			created[strings.ToLower(name[len(name)-1])] = true
+		}
+	}
+
+	return created
+}
+
+// migrationChecks returns the risky operations in a statement.
+func migrationChecks(stmt *sqlparse.Statement, engine string, created map[string]bool) []migrationCheck {
+	tokens := stmt.Tokens
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	first := tokens[0]
+
+	switch {
+	case first.Is("DROP") && len(tokens) > 1 && tokens[1].Is("TABLE"):
+		return []migrationCheck{{pos: first.Pos, operation: OperationDropTable, message: "DROP TABLE"}}
+	case first.Is("TRUNCATE"):
+		return []migrationCheck{{pos: first.Pos, operation: OperationTruncate, message: "TRUNCATE"}}
+	case first.Is("RENAME") && len(tokens) > 1 && tokens[1].Is("TABLE"):
+		return []migrationCheck{{pos: first.Pos, operation: OperationRename,
+			message: "renaming a table breaks code that still uses the old name"}}
+	case first.Is("ALTER") && len(tokens) > 1 && tokens[1].Is("TABLE"):
+		return alterTableChecks(tokens[2:], created)
+	case first.Is("CREATE") && engine == config.EnginePostgreSQL:
+		return createIndexChecks(tokens, created)
+	default:
+		return nil
+	}
+}
+
+// alterTableChecks inspects each action of an ALTER TABLE statement; tokens start after ALTER TABLE.
+// Tables created in the same file are empty, so NOT NULL columns are safe to add to them.
+func alterTableChecks(tokens []sqlparse.Token, created map[string]bool) []migrationCheck {
+	c := sqlparse.NewCursor("", tokens)
+
+	if c.Accept("IF") {
+		c.Accept("EXISTS")
+	}
+
+	c.Accept("ONLY")
+
+	name, err := c.QualifiedIdent()
+	if err != nil {
+		return nil
+	}
+
+	isNew := created[strings.ToLower(name[len(name)-1])]
+
+	var checks []migrationCheck
+
+	for _, action := range sqlparse.SplitTopLevel(c.Rest()) {
+		check, ok := alterActionCheck(action)
+		if ok && !(isNew && check.operation == OperationNotNullWithoutDefault) {
+			checks = append(checks, check)
+		}
+	}
+
+	return checks
+}
+
+// alterActionCheck classifies one ALTER TABLE action.
+func alterActionCheck(action []sqlparse.Token) (migrationCheck, bool) {
+	if len(action) < 2 {
+		return migrationCheck{}, false
+	}
+
+	pos := action[0].Pos
+	verb := action[0].Upper()
+	target := action[1].Upper()
+
+	switch verb {
+	case "DROP":
+		if ddlKeywordsAfterDrop[target] {
+			return migrationCheck{}, false
+		}
+
+		return migrationCheck{pos: pos, operation: OperationDropColumn, message: "DROP COLUMN"}, true
+	case "ADD":
+		if ddlKeywordsAfterAdd[target] || !hasWords(action, "NOT", "NULL") || hasWord(action, "DEFAULT") ||
+			hasWord(action, "GENERATED") || hasWord(action, "AS") {
+			return migrationCheck{}, false
+		}
+
+		return migrationCheck{pos: pos, operation: OperationNotNullWithoutDefault,
+			message: "adding a NOT NULL column without a DEFAULT fails on tables that already have rows"}, true
+	case "ALTER":
+		switch {
+		case hasWord(action, "TYPE"):
+			return migrationCheck{pos: pos, operation: OperationAlterColumnType,
+				message: "changing a column type can rewrite the table and break code that reads the column"}, true
+		case hasWords(action, "SET", "NOT", "NULL"):
+			return migrationCheck{pos: pos, operation: OperationNotNullWithoutDefault,
+				message: "SET NOT NULL scans the table and fails if any row is NULL"}, true
+		}
+	case "MODIFY", "CHANGE":
+		return migrationCheck{pos: pos, operation: OperationAlterColumnType,
+			message: "changing a column definition can rewrite the table and break code that reads the column"}, true
+	case "RENAME":
+		if target == "CONSTRAINT" || target == "INDEX" || target == "KEY" {
+			return migrationCheck{}, false
+		}
+
+		return migrationCheck{pos: pos, operation: OperationRename,
+			message: "renaming breaks code that still uses the old name"}, true
+	}
+
+	return migrationCheck{}, false
+}
+
+// createIndexChecks reports PostgreSQL indexes built without CONCURRENTLY on existing tables.
+func createIndexChecks(tokens []sqlparse.Token, created map[string]bool) []migrationCheck {
+	c := sqlparse.NewCursor("", tokens)
+	c.Accept("CREATE")
+	c.Accept("UNIQUE")
+
+	if !c.Accept("INDEX") || c.Accept("CONCURRENTLY") {
+		return nil
+	}
+
+	for !c.Done() && !c.Peek().Is("ON") {
+		c.Next()
+	}
+
+	if !c.Accept("ON") {
+		return nil
+	}
+
+	c.Accept("ONLY")
+
+	name, err := c.QualifiedIdent()
+	if err != nil || created[strings.ToLower(name[len(name)-1])] {
+		return nil
+	}
+
+	return []migrationCheck{{pos: tokens[0].Pos, operation: OperationNonConcurrentIndex,
+		message: "CREATE INDEX without CONCURRENTLY blocks writes to " + strings.Join(name, ".") +
+			" while the index is built"}}
+}
+
+// hasWord reports whether a top-level word appears in tokens.
+func hasWord(tokens []sqlparse.Token, keyword string) bool {
+	for _, tok := range tokens {
+		if tok.Is(keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasWords reports whether the keywords appear consecutively in tokens.
+func hasWords(tokens []sqlparse.Token, keywords ...string) bool {
+	for i := 0; i+len(keywords) <= len(tokens); i++ {
+		match := true
+
+		for j, keyword := range keywords {
+			if !tokens[i+j].Is(keyword) {
+				match = false
+
+				break
+			}
+		}
+
+		if match {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,142 @@
+package migration
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// fileNamePattern matches golang-migrate file names: <version>_<name>.(up|down).<ext>.
+var fileNamePattern = regexp.MustCompile(`^([0-9]+)_(.*)\.(down|up)\.(.*)$`)
+
+// Migration file directions.
+const (
+	DirectionUp   = "up"
+	DirectionDown = "down"
+)
+
+// File is a single golang-migrate file parsed from its name.
+type File struct {
+	Path      string
+	Version   uint
+	Name      string
+	Direction string
+	// Digits is the version as written, e.g. "0001" or "1763227265".
+	Digits string
+}
+
+// parseFileName parses a golang-migrate file name. ok is false when the name
+// does not follow the pattern; err is set when the version does not fit a uint.
+func parseFileName(dir, name string) (file File, ok bool, err error) {
+	match := fileNamePattern.FindStringSubmatch(name)
+	if match == nil {
+		return File{}, false, nil
+	}
+
+	version, err := strconv.ParseUint(match[1], 10, 0)
+	if err != nil {
+		return File{}, true, &ValidationError{
+			Field:   name,
+			Message: fmt.Sprintf("migration version %s is out of range", match[1]),
+		}
+	}
+
+	return File{
+		Path:      filepath.Join(dir, name),
+		Version:   uint(version),
+		Name:      match[2],
+		Direction: match[3],
+		Digits:    match[1],
+	}, true, nil
+}
+
+// ScanDirectory reads the golang-migrate files in dir and returns one Migration per
+// version, sorted by version. Files that do not follow the naming scheme are ignored.
+func ScanDirectory(dir string) ([]Migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory %s: %w", dir, err)
+	}
+
+	byVersion := make(map[uint]*Migration)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		file, ok, err := parseFileName(dir, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		mig, ok := byVersion[file.Version]
+		if !ok {
+			mig = &Migration{Version: file.Version, Name: file.Name}
+			byVersion[file.Version] = mig
+		}
+
+		existing := &mig.UpFile
+		if file.Direction == DirectionDown {
+			existing = &mig.DownFile
+		}
+
+		if *existing != "" {
+			return nil, &ValidationError{
+				Field: entry.Name(),
+				Message: fmt.Sprintf("duplicate %s migration for version %d: %s and %s",
+					file.Direction, file.Version, *existing, file.Path),
+			}
+		}
+
+		*existing = file.Path
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		migrations = append(migrations, *mig)
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return migrations, nil
+}
+
+// NextVersion returns the version of a new migration in dir as it is written in the
+// file name. A directory numbered sequentially (000001, 000002, ...) continues its
+// sequence with the zero padding of the newest file, since a timestamp would sort
+// after every sequential version and mix the schemes. Otherwise the version is the
+// Unix time of now, or the newest version plus one when that is later, so that two
+// migrations written within the same second still get distinct, ordered versions.
+func NextVersion(dir string, now time.Time) string {
+	var newest File
+
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if file, ok, err := parseFileName(dir, entry.Name()); ok && err == nil && file.Version >= newest.Version {
+			newest = file
+		}
+	}
+
+	if newest.Digits != "" && len(newest.Digits) < timestampDigits {
+		return fmt.Sprintf("%0*d", len(newest.Digits), newest.Version+1)
+	}
+
+	version := uint(now.Unix())
+	if newest.Digits != "" {
+		version = max(version, newest.Version+1)
+	}
+
+	return strconv.FormatUint(uint64(version), 10)
+}
@@ -0,0 +1,145 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeMigrationFiles(t *testing.T, names ...string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("SELECT 1;"), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	return dir
+}
+
+func TestScanDirectory(t *testing.T) {
+	dir := writeMigrationFiles(t,
+		"10_add_posts.up.sql",
+		"2_create_users.up.sql",
+		"2_create_users.down.sql",
+		"10_add_posts.down.sql",
+		"11_seed.up.sql",
+		"README.md",
+	)
+
+	migrations, err := ScanDirectory(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(migrations) != 3 {
+		t.Fatalf("Expected 3 migrations, got %d", len(migrations))
+	}
+
+	wantVersions := []uint{2, 10, 11}
+	for i, mig := range migrations {
+		if mig.Version != wantVersions[i] {
+			t.Errorf("Expected version %d at %d, got %d", wantVersions[i], i, mig.Version)
+		}
+	}
+
+	if migrations[0].Name != "create_users" {
+		t.Errorf("Expected name create_users, got %s", migrations[0].Name)
+	}
+
+	if migrations[0].UpFile != filepath.Join(dir, "2_create_users.up.sql") {
+		t.Errorf("Unexpected up file %s", migrations[0].UpFile)
+	}
+
+	if migrations[2].DownFile != "" {
+		t.Errorf("Expected no down file for version 11, got %s", migrations[2].DownFile)
+	}
+}
+
+func TestScanDirectory_Errors(t *testing.T) {
+	if _, err := ScanDirectory(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected error for missing directory")
+	}
+
+	dir := writeMigrationFiles(t, "1_users.up.sql", "001_users_again.up.sql")
+	if _, err := ScanDirectory(dir); err == nil {
+		t.Error("Expected error for duplicate version")
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	now := time.Unix(1763227265, 0)
+
+	tests := []struct {
+		name  string
+		files []string
+		want  string
+	}{
+		{"empty directory", nil, "1763227265"},
+		{"sequential", []string{"000001_users.up.sql", "000002_posts.up.sql", "000002_posts.down.sql"}, "000003"},
+		{"sequential without padding", []string{"9_users.up.sql"}, "10"},
+		{"timestamps", []string{"1700000000_users.up.sql"}, "1763227265"},
+		{"timestamp in the same second", []string{"1763227265_users.up.sql"}, "1763227266"},
+		{"other files only", []string{"README.md"}, "1763227265"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextVersion(writeMigrationFiles(t, tt.files...), now); got != tt.want {
+				t.Errorf("NextVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := NextVersion(filepath.Join(t.TempDir(), "missing"), now); got != "1763227265" {
+		t.Errorf("NextVersion() of a missing directory = %q", got)
+	}
+}
+
+func TestMigrationStatus_MarkApplied(t *testing.T) {
+	status, _ := NewMigrationStatus("file://migrations", "")
+	status.WithMigrations([]Migration{
+		{Version: 1, UpFile: "1.up.sql", DownFile: "1.down.sql"},
+		{Version: 2, UpFile: "2.up.sql"},
+		{Version: 3, UpFile: "3.up.sql", DownFile: "3.down.sql"},
+	})
+
+	status.MarkApplied()
+
+	if len(status.Pending()) != 3 {
+		t.Errorf("Expected all migrations pending without a version, got %d", len(status.Pending()))
+	}
+
+	status.WithVersion(2)
+	status.WithDirty(true)
+	status.MarkApplied()
+
+	if !status.Migrations[0].Applied || !status.Migrations[1].Applied || status.Migrations[2].Applied {
+		t.Errorf("Unexpected applied flags: %+v", status.Migrations)
+	}
+
+	if status.Migrations[0].Dirty || !status.Migrations[1].Dirty {
+		t.Errorf("Expected only the current migration to be dirty: %+v", status.Migrations)
+	}
+
+	if pending := status.Pending(); len(pending) != 1 || pending[0].Version != 3 {
+		t.Errorf("Expected version 3 to be pending, got %+v", pending)
+	}
+
+	if missing := status.MissingDownFiles(); len(missing) != 1 || missing[0].Version != 2 {
+		t.Errorf("Expected version 2 to miss its down file, got %+v", missing)
+	}
+
+	if status.IsOutOfOrder() {
+		t.Error("Expected version 2 to be in order")
+	}
+
+	status.WithVersion(5)
+
+	if !status.IsOutOfOrder() {
+		t.Error("Expected unknown version 5 to be out of order")
+	}
+}
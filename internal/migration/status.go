@@ -116,6 +116,60 @@ func (ms *MigrationStatus) GetPendingMigrations() uint {
 	return total - applied
 }
 
+// MarkApplied derives the per-migration state from the current version:
+// golang-migrate applies migrations in order, so every version up to and including
+// the current one counts as applied, and the current one inherits the dirty flag.
+func (ms *MigrationStatus) MarkApplied() {
+	for i := range ms.Migrations {
+		mig := &ms.Migrations[i]
+		mig.Applied = ms.CurrentVersion != nil && mig.Version <= *ms.CurrentVersion
+		mig.Dirty = ms.Dirty && ms.CurrentVersion != nil && mig.Version == *ms.CurrentVersion
+	}
+}
+
+// Pending returns the migrations that have not been applied yet.
+func (ms *MigrationStatus) Pending() []Migration {
+	var pending []Migration
+
+	for _, mig := range ms.Migrations {
+		if !mig.Applied {
+			pending = append(pending, mig)
+		}
+	}
+
+	return pending
+}
+
+// MissingDownFiles returns the migrations that cannot be rolled back because they have no down file.
+func (ms *MigrationStatus) MissingDownFiles() []Migration {
+	var missing []Migration
+
+	for _, mig := range ms.Migrations {
+		if mig.DownFile == "" {
+			missing = append(missing, mig)
+		}
+	}
+
+	return missing
+}
+
+// IsOutOfOrder reports whether the database version does not match any migration in the
+// source, typically because it was migrated from another branch. golang-migrate can then
+// neither apply nor roll back from the current state.
+func (ms *MigrationStatus) IsOutOfOrder() bool {
+	if ms.CurrentVersion == nil || len(ms.Migrations) == 0 {
+		return false
+	}
+
+	for _, mig := range ms.Migrations {
+		if mig.Version == *ms.CurrentVersion {
+			return mig.UpFile == ""
+		}
+	}
+
+	return true
+}
+
 // ValidationError represents migration-specific validation apperrors.
 type ValidationError struct {
 	Field   string `json:"field"`
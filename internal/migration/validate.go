@@ -0,0 +1,216 @@
+package migration
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+)
+
+// Check codes reported by ValidateDirectory.
+const (
+	CheckInvalidName      = "migration-invalid-name"
+	CheckDuplicateVersion = "migration-duplicate-version"
+	CheckMissingUp        = "migration-missing-up"
+	CheckMissingDown      = "migration-missing-down"
+	CheckVersionGap       = "migration-version-gap"
+	CheckMixedNumbering   = "migration-mixed-numbering"
+	CheckEmptyFile        = "migration-empty-file"
+)
+
+// timestampDigits is the minimum number of digits of a timestamp version;
+// Unix seconds have 10 digits and YYYYMMDDHHMMSS has 14.
+const timestampDigits = 10
+
+// Issue is a problem found in a migrations directory.
+type Issue struct {
+	Code     string                  `json:"code"`
+	Severity apperrors.ErrorSeverity `json:"severity"`
+	File     string                  `json:"file"`
+	Message  string                  `json:"message"`
+}
+
+// DirectoryReport is the result of validating a migrations directory.
+type DirectoryReport struct {
+	Dir    string  `json:"dir"`
+	Files  []File  `json:"-"`
+	Issues []Issue `json:"issues"`
+}
+
+// HasErrors returns true if any issue is an error.
+func (r *DirectoryReport) HasErrors() bool {
+	return slices.ContainsFunc(r.Issues, func(issue Issue) bool {
+		return issue.Severity == apperrors.ErrorSeverityError
+	})
+}
+
+func (r *DirectoryReport) add(code string, severity apperrors.ErrorSeverity, file, format string, args ...any) {
+	r.Issues = append(r.Issues, Issue{
+		Code:     code,
+		Severity: severity,
+		File:     file,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// ValidateDirectory checks a golang-migrate directory for .sql files that do not follow
+// the naming scheme, duplicate versions, up files without down files and the reverse,
+// gaps in sequential numbering, mixed timestamp and sequential numbering, and files
+// without any SQL statement.
+func ValidateDirectory(dir string) (*DirectoryReport, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory %s: %w", dir, err)
+	}
+
+	report := &DirectoryReport{Dir: dir, Issues: []Issue{}}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		file, ok, err := parseFileName(dir, entry.Name())
+		path := filepath.Join(dir, entry.Name())
+
+		switch {
+		case err != nil:
+			report.add(CheckInvalidName, apperrors.ErrorSeverityError, path, "%v", err)
+		case !ok && strings.HasSuffix(strings.ToLower(entry.Name()), ".sql"):
+			report.add(CheckInvalidName, apperrors.ErrorSeverityError, path,
+				"file name does not match <version>_<name>.up.sql or <version>_<name>.down.sql; golang-migrate ignores it")
+		case ok:
+			report.Files = append(report.Files, file)
+		}
+	}
+
+	slices.SortFunc(report.Files, func(a, b File) int {
+		return cmp.Or(cmp.Compare(a.Version, b.Version), cmp.Compare(a.Direction, b.Direction), cmp.Compare(a.Path, b.Path))
+	})
+
+	report.checkPairs()
+	report.checkNumbering()
+	report.checkEmptyFiles()
+
+	return report, nil
+}
+
+// checkPairs reports duplicate versions and up/down files without their counterpart.
+func (r *DirectoryReport) checkPairs() {
+	for _, files := range r.byVersion() {
+		version := files[0].Version
+
+		var ups, downs []File
+
+		for _, file := range files {
+			if file.Direction == DirectionUp {
+				ups = append(ups, file)
+			} else {
+				downs = append(downs, file)
+			}
+		}
+
+		names := map[string]bool{}
+		for _, file := range files {
+			names[file.Digits+"_"+file.Name] = true
+		}
+
+		if len(ups) > 1 || len(downs) > 1 || len(names) > 1 {
+			r.add(CheckDuplicateVersion, apperrors.ErrorSeverityError, files[len(files)-1].Path,
+				"version %d is used by more than one migration: %s", version, strings.Join(fileNames(files), ", "))
+
+			continue
+		}
+
+		switch {
+		case len(ups) == 0:
+			r.add(CheckMissingUp, apperrors.ErrorSeverityError, downs[0].Path,
+				"down migration for version %d has no up file", version)
+		case len(downs) == 0:
+			r.add(CheckMissingDown, apperrors.ErrorSeverityWarning, ups[0].Path,
+				"migration %d has no down file and cannot be rolled back", version)
+		}
+	}
+}
+
+// checkNumbering reports mixed timestamp and sequential versions and gaps in sequential ones.
+func (r *DirectoryReport) checkNumbering() {
+	var sequential, timestamps []File
+
+	for _, files := range r.byVersion() {
+		if len(files[0].Digits) >= timestampDigits {
+			timestamps = append(timestamps, files[0])
+		} else {
+			sequential = append(sequential, files[0])
+		}
+	}
+
+	if len(sequential) > 0 && len(timestamps) > 0 {
+		r.add(CheckMixedNumbering, apperrors.ErrorSeverityError, sequential[len(sequential)-1].Path,
+			"%d migration(s) use sequential versions and %d use timestamps; sequential versions always sort first, "+
+				"so new ones would never be applied after a timestamped migration", len(sequential), len(timestamps))
+	}
+
+	for i := 1; i < len(sequential); i++ {
+		prev, next := sequential[i-1].Version, sequential[i].Version
+		if next != prev+1 {
+			r.add(CheckVersionGap, apperrors.ErrorSeverityWarning, sequential[i].Path,
+				"version %d follows %d; versions %d to %d are missing", next, prev, prev+1, next-1)
+		}
+	}
+}
+
+// checkEmptyFiles reports files without any SQL statement, such as untouched
+// migrate create templates. Applying an empty up migration records a version
+// without changing the schema, so those are errors; empty down files are warnings.
+func (r *DirectoryReport) checkEmptyFiles() {
+	for _, file := range r.Files {
+		data, err := os.ReadFile(file.Path)
+		if err != nil {
+			r.add(CheckEmptyFile, apperrors.ErrorSeverityError, file.Path, "cannot read file: %v", err)
+
+			continue
+		}
+
+		statements, err := sqlparse.Split(string(data))
+		if err != nil || len(statements) > 0 {
+			continue
+		}
+
+		severity := apperrors.ErrorSeverityError
+		if file.Direction == DirectionDown {
+			severity = apperrors.ErrorSeverityWarning
+		}
+
+		r.add(CheckEmptyFile, severity, file.Path, "%s migration %d contains no SQL statements", file.Direction, file.Version)
+	}
+}
+
+// byVersion groups the files by version, in version order.
+func (r *DirectoryReport) byVersion() [][]File {
+	var groups [][]File
+
+	for _, file := range r.Files {
+		if n := len(groups); n > 0 && groups[n-1][0].Version == file.Version {
+			groups[n-1] = append(groups[n-1], file)
+		} else {
+			groups = append(groups, []File{file})
+		}
+	}
+
+	return groups
+}
+
+func fileNames(files []File) []string {
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, filepath.Base(file.Path))
+	}
+
+	return names
+}
@@ -0,0 +1,161 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+)
+
+func writeMigrationDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	return dir
+}
+
+func TestValidateDirectory_Clean(t *testing.T) {
+	dir := writeMigrationDir(t, map[string]string{
+		"000001_users.up.sql":   "CREATE TABLE users (id int);",
+		"000001_users.down.sql": "DROP TABLE users;",
+		"000002_posts.up.sql":   "CREATE TABLE posts (id int);",
+		"000002_posts.down.sql": "DROP TABLE posts;",
+		"README.md":             "# migrations",
+	})
+
+	report, err := ValidateDirectory(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(report.Issues) != 0 {
+		t.Errorf("Expected no issues, got %+v", report.Issues)
+	}
+
+	if len(report.Files) != 4 {
+		t.Errorf("Expected 4 migration files, got %d", len(report.Files))
+	}
+}
+
+func TestValidateDirectory_Problems(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		code     string
+		severity apperrors.ErrorSeverity
+	}{
+		{
+			name: "missing down file",
+			files: map[string]string{
+				"1_users.up.sql": "CREATE TABLE users (id int);",
+			},
+			code:     CheckMissingDown,
+			severity: apperrors.ErrorSeverityWarning,
+		},
+		{
+			name: "missing up file",
+			files: map[string]string{
+				"1_users.down.sql": "DROP TABLE users;",
+			},
+			code:     CheckMissingUp,
+			severity: apperrors.ErrorSeverityError,
+		},
+		{
+			name: "duplicate version",
+			files: map[string]string{
+				"1_users.up.sql":   "CREATE TABLE users (id int);",
+				"1_users.down.sql": "DROP TABLE users;",
+				"01_posts.up.sql":  "CREATE TABLE posts (id int);",
+			},
+			code:     CheckDuplicateVersion,
+			severity: apperrors.ErrorSeverityError,
+		},
+		{
+			name: "version gap",
+			files: map[string]string{
+				"1_users.up.sql":   "CREATE TABLE users (id int);",
+				"1_users.down.sql": "DROP TABLE users;",
+				"4_posts.up.sql":   "CREATE TABLE posts (id int);",
+				"4_posts.down.sql": "DROP TABLE posts;",
+			},
+			code:     CheckVersionGap,
+			severity: apperrors.ErrorSeverityWarning,
+		},
+		{
+			name: "mixed numbering",
+			files: map[string]string{
+				"1_users.up.sql":            "CREATE TABLE users (id int);",
+				"1_users.down.sql":          "DROP TABLE users;",
+				"1763227265_posts.up.sql":   "CREATE TABLE posts (id int);",
+				"1763227265_posts.down.sql": "DROP TABLE posts;",
+			},
+			code:     CheckMixedNumbering,
+			severity: apperrors.ErrorSeverityError,
+		},
+		{
+			name: "invalid name",
+			files: map[string]string{
+				"create_users.sql": "CREATE TABLE users (id int);",
+			},
+			code:     CheckInvalidName,
+			severity: apperrors.ErrorSeverityError,
+		},
+		{
+			name: "untouched template",
+			files: map[string]string{
+				"1763227265_test_migration.up.sql":   "-- Migration: test_migration\n\n-- Add your SQL statements here\n",
+				"1763227265_test_migration.down.sql": "DROP TABLE users;",
+			},
+			code:     CheckEmptyFile,
+			severity: apperrors.ErrorSeverityError,
+		},
+		{
+			name: "empty down file",
+			files: map[string]string{
+				"1_users.up.sql":   "CREATE TABLE users (id int);",
+				"1_users.down.sql": "",
+			},
+			code:     CheckEmptyFile,
+			severity: apperrors.ErrorSeverityWarning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := ValidateDirectory(writeMigrationDir(t, tt.files))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(report.Issues) != 1 {
+				t.Fatalf("Expected exactly one issue, got %+v", report.Issues)
+			}
+
+			issue := report.Issues[0]
+			if issue.Code != tt.code || issue.Severity != tt.severity {
+				t.Errorf("Expected %s %s, got %s %s: %s", tt.severity, tt.code, issue.Severity, issue.Code, issue.Message)
+			}
+
+			if !filepath.IsAbs(issue.File) && filepath.Dir(issue.File) == "." {
+				t.Errorf("Expected the issue to carry the file path, got %s", issue.File)
+			}
+
+			if report.HasErrors() != (tt.severity == apperrors.ErrorSeverityError) {
+				t.Errorf("Unexpected HasErrors %v", report.HasErrors())
+			}
+		})
+	}
+}
+
+func TestValidateDirectory_MissingDirectory(t *testing.T) {
+	if _, err := ValidateDirectory(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected error for missing directory")
+	}
+}
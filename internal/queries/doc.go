@@ -0,0 +1,3 @@
+// Package queries provides a typed model of sqlc query files: named query blocks,
+// their commands, doc comments, statement kinds and placeholders.
+package queries
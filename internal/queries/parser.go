@@ -0,0 +1,242 @@
+package queries
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+)
+
+// headerPattern matches "-- name: GetUser :one" and "/* name: GetUser :one */".
+var headerPattern = regexp.MustCompile(`^(?:--|#|/\*)\s*name:\s*(\S+)\s*(\S*)`)
+
+// sqlcMacros maps sqlc.<macro>(...) names to their placeholder kinds.
+var sqlcMacros = map[string]PlaceholderKind{
+	"arg":   PlaceholderArg,
+	"narg":  PlaceholderNarg,
+	"slice": PlaceholderSlice,
+	"embed": PlaceholderEmbed,
+}
+
+// ParseFile reads and parses a query file.
+func ParseFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, apperrors.FileNotFoundError(path)
+		}
+
+		return nil, apperrors.FileReadError(path, err)
+	}
+
+	return Parse(path, string(data))
+}
+
+// Parse splits query source into its "-- name:" blocks.
+// Statements without an annotation are kept with an empty name so callers can
+// report them; an annotation that is directly followed by another annotation
+// yields a query with no SQL. Tokenizer errors are returned as *sqlparse.SyntaxError.
+func Parse(path, src string) (*File, error) {
+	statements, err := sqlparse.Split(src)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &File{Path: path, Queries: []Query{}}
+
+	for _, stmt := range statements {
+		file.Queries = append(file.Queries, buildQueries(path, stmt)...)
+	}
+
+	return file, nil
+}
+
+// buildQueries turns a statement and its leading comments into queries.
+// Usually this is exactly one query; extra annotations before the statement
+// produce empty queries.
+func buildQueries(path string, stmt sqlparse.Statement) []Query {
+	var (
+		result  []Query
+		current *Query
+	)
+
+	for _, comment := range stmt.Comments {
+		if comment.Pos.Offset > stmt.Pos.Offset {
+			break // comments inside the statement body are not docs
+		}
+
+		if name, cmd, ok := parseHeader(comment.Text); ok {
+			if current != nil {
+				result = append(result, *current)
+			}
+
+			current = &Query{
+				Name: name,
+				Cmd:  Command(cmd),
+				Kind: StatementOther,
+				File: path,
+				Pos:  comment.Pos,
+			}
+
+			continue
+		}
+
+		if current != nil {
+			current.Docs = append(current.Docs, commentText(comment.Text))
+		}
+	}
+
+	if current == nil {
+		current = &Query{File: path, Pos: stmt.Pos}
+	}
+
+	current.SQL = stmt.Text
+	current.Kind = statementKind(stmt.Tokens)
+	current.Placeholders = placeholders(stmt.Tokens)
+	current.Statement = stmt
+
+	return append(result, *current)
+}
+
+// parseHeader extracts the name and command from an annotation comment.
+func parseHeader(text string) (string, string, bool) {
+	text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "*/"))
+
+	match := headerPattern.FindStringSubmatch(text)
+	if match == nil {
+		return "", "", false
+	}
+
+	return match[1], match[2], true
+}
+
+// commentText strips comment markers and surrounding whitespace.
+func commentText(text string) string {
+	switch {
+	case strings.HasPrefix(text, "--"):
+		text = text[2:]
+	case strings.HasPrefix(text, "#"):
+		text = text[1:]
+	case strings.HasPrefix(text, "/*"):
+		text = strings.TrimSuffix(text[2:], "*/")
+	}
+
+	return strings.TrimSpace(text)
+}
+
+// statementKind classifies a statement by its verb. For WITH queries the first
+// top-level verb after the CTE definitions decides, so CTE bodies are skipped.
+func statementKind(tokens []sqlparse.Token) StatementKind {
+	if len(tokens) == 0 {
+		return StatementOther
+	}
+
+	if !tokens[0].Is("WITH") {
+		return verbKind(tokens[0])
+	}
+
+	depth := 0
+
+	for _, tok := range tokens[1:] {
+		switch {
+		case tok.IsPunct("("):
+			depth++
+		case tok.IsPunct(")"):
+			depth--
+		case depth == 0:
+			if kind := verbKind(tok); kind != StatementOther {
+				return kind
+			}
+		}
+	}
+
+	return StatementOther
+}
+
+func verbKind(tok sqlparse.Token) StatementKind {
+	if tok.Kind != sqlparse.TokenWord {
+		return StatementOther
+	}
+
+	switch tok.Upper() {
+	case "SELECT", "VALUES", "TABLE":
+		return StatementSelect
+	case "INSERT", "REPLACE":
+		return StatementInsert
+	case "UPDATE":
+		return StatementUpdate
+	case "DELETE":
+		return StatementDelete
+	default:
+		return StatementOther
+	}
+}
+
+// placeholders collects the parameter references of a statement in source order.
+func placeholders(tokens []sqlparse.Token) []Placeholder {
+	var (
+		result    []Placeholder
+		questions int
+	)
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+
+		if tok.Kind == sqlparse.TokenParam {
+			p := Placeholder{Text: tok.Text, Pos: tok.Pos}
+
+			switch {
+			case tok.Text == "?":
+				questions++
+				p.Kind, p.Index = PlaceholderQuestion, questions
+			case tok.Text[0] == '$' || tok.Text[0] == '?':
+				p.Kind = PlaceholderPositional
+				p.Index, _ = strconv.Atoi(tok.Text[1:])
+			default: // @name, :name
+				p.Kind, p.Name = PlaceholderNamed, tok.Text[1:]
+			}
+
+			result = append(result, p)
+
+			continue
+		}
+
+		if p, n, ok := sqlcMacro(tokens[i:]); ok {
+			result = append(result, p)
+			i += n - 1
+		}
+	}
+
+	return result
+}
+
+// sqlcMacro matches sqlc.<macro>(name) at the start of tokens and returns
+// the placeholder and the number of tokens it spans.
+func sqlcMacro(tokens []sqlparse.Token) (Placeholder, int, bool) {
+	const macroTokens = 6 // sqlc . macro ( name )
+
+	if len(tokens) < macroTokens || !tokens[0].Is("sqlc") || !tokens[1].IsPunct(".") ||
+		!tokens[3].IsPunct("(") || !tokens[5].IsPunct(")") {
+		return Placeholder{}, 0, false
+	}
+
+	kind, ok := sqlcMacros[strings.ToLower(tokens[2].Text)]
+	if !ok {
+		return Placeholder{}, 0, false
+	}
+
+	name := tokens[4]
+	if !name.IsIdent() && name.Kind != sqlparse.TokenString {
+		return Placeholder{}, 0, false
+	}
+
+	return Placeholder{
+		Kind: kind,
+		Name: name.Value,
+		Text: strings.Join([]string{"sqlc.", tokens[2].Text, "(", name.Text, ")"}, ""),
+		Pos:  tokens[0].Pos,
+	}, macroTokens, true
+}
@@ -0,0 +1,195 @@
+package queries_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/generators"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/queries"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/templates"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestQueries(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Queries Suite")
+}
+
+func mustParse(src string) *queries.File {
+	file, err := queries.Parse("query.sql", src)
+	Expect(err).NotTo(HaveOccurred())
+
+	return file
+}
+
+var _ = Describe("Command", func() {
+	It("should recognise every sqlc command", func() {
+		for _, cmd := range queries.AllCommands() {
+			Expect(cmd.IsValid()).To(BeTrue(), string(cmd))
+		}
+
+		Expect(queries.Command(":first").IsValid()).To(BeFalse())
+		Expect(queries.Command("").IsValid()).To(BeFalse())
+	})
+
+	It("should classify result shapes", func() {
+		Expect(queries.CommandMany.ReturnsRows()).To(BeTrue())
+		Expect(queries.CommandExecRows.ReturnsRows()).To(BeFalse())
+		Expect(queries.CommandBatchOne.ReturnsSingleRow()).To(BeTrue())
+		Expect(queries.CommandBatchExec.IsBatch()).To(BeTrue())
+		Expect(queries.CommandCopyFrom.IsBatch()).To(BeFalse())
+	})
+})
+
+var _ = Describe("Parse", func() {
+	It("should split annotated blocks with docs and positions", func() {
+		file := mustParse(`-- leading file comment
+
+-- name: GetUser :one
+-- Get a single user by ID
+-- Returns sql.ErrNoRows when missing.
+SELECT id, email FROM users
+WHERE id = $1 -- inline comment is not a doc
+LIMIT 1;
+
+/* name: CreateUser :execlastid */
+INSERT INTO users (email) VALUES (?);
+`)
+
+		Expect(file.Path).To(Equal("query.sql"))
+		Expect(file.Queries).To(HaveLen(2))
+
+		get := file.Query("GetUser")
+		Expect(get).NotTo(BeNil())
+		Expect(get.Cmd).To(Equal(queries.CommandOne))
+		Expect(get.Docs).To(Equal([]string{"Get a single user by ID", "Returns sql.ErrNoRows when missing."}))
+		Expect(get.SQL).To(HavePrefix("SELECT id, email FROM users"))
+		Expect(get.SQL).To(HaveSuffix("LIMIT 1"))
+		Expect(get.Kind).To(Equal(queries.StatementSelect))
+		Expect(get.Pos.Line).To(Equal(3))
+		Expect(get.HasHeader()).To(BeTrue())
+
+		create := file.Query("CreateUser")
+		Expect(create.Cmd).To(Equal(queries.CommandExecLastID))
+		Expect(create.Kind).To(Equal(queries.StatementInsert))
+		Expect(create.Docs).To(BeEmpty())
+	})
+
+	It("should keep unannotated statements and unknown commands", func() {
+		file := mustParse(`-- name: Broken :first
+SELECT 1;
+
+SELECT 2;
+
+-- name: NoCmd
+SELECT 3;
+`)
+
+		Expect(file.Queries).To(HaveLen(3))
+		Expect(file.Queries[0].Cmd.IsValid()).To(BeFalse())
+		Expect(file.Queries[1].HasHeader()).To(BeFalse())
+		Expect(file.Queries[1].Pos.Line).To(Equal(4))
+		Expect(file.Queries[2].Name).To(Equal("NoCmd"))
+		Expect(file.Queries[2].Cmd).To(BeEmpty())
+	})
+
+	It("should report annotations without a statement as empty queries", func() {
+		file := mustParse(`-- name: Forgotten :exec
+-- name: Real :exec
+DELETE FROM users WHERE id = $1;
+`)
+
+		Expect(file.Queries).To(HaveLen(2))
+		Expect(file.Queries[0].Name).To(Equal("Forgotten"))
+		Expect(file.Queries[0].SQL).To(BeEmpty())
+		Expect(file.Queries[1].Kind).To(Equal(queries.StatementDelete))
+	})
+
+	DescribeTable("statement kinds",
+		func(sql string, kind queries.StatementKind) {
+			file := mustParse("-- name: Q :exec\n" + sql + ";")
+			Expect(file.Queries[0].Kind).To(Equal(kind))
+		},
+		Entry("update", "UPDATE users SET active = false", queries.StatementUpdate),
+		Entry("CTE feeding a delete", "WITH old AS (SELECT id FROM users) DELETE FROM users WHERE id IN (SELECT id FROM old)", queries.StatementDelete),
+		Entry("recursive CTE", "WITH RECURSIVE t(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM t) SELECT n FROM t", queries.StatementSelect),
+		Entry("MySQL replace", "REPLACE INTO users (id) VALUES (?)", queries.StatementInsert),
+		Entry("DDL", "CREATE TABLE x (id int)", queries.StatementOther),
+	)
+
+	It("should collect every placeholder form", func() {
+		file := mustParse(`-- name: Search :many
+SELECT sqlc.embed(users), p.title
+FROM users JOIN posts p ON p.user_id = users.id
+WHERE users.id = $1
+  AND users.email = @email
+  AND p.status = sqlc.narg('status')
+  AND p.id IN (sqlc.slice(ids))
+  AND p.owner = sqlc.arg(owner)::bigint
+  AND p.title <> $2;
+`)
+
+		placeholders := file.Queries[0].Placeholders
+		Expect(placeholders).To(HaveLen(7))
+
+		Expect(placeholders[0].Kind).To(Equal(queries.PlaceholderEmbed))
+		Expect(placeholders[0].Name).To(Equal("users"))
+		Expect(placeholders[1].Kind).To(Equal(queries.PlaceholderPositional))
+		Expect(placeholders[1].Index).To(Equal(1))
+		Expect(placeholders[2].Kind).To(Equal(queries.PlaceholderNamed))
+		Expect(placeholders[2].Name).To(Equal("email"))
+		Expect(placeholders[3].Kind).To(Equal(queries.PlaceholderNarg))
+		Expect(placeholders[3].Name).To(Equal("status"))
+		Expect(placeholders[4].Kind).To(Equal(queries.PlaceholderSlice))
+		Expect(placeholders[5].Kind).To(Equal(queries.PlaceholderArg))
+		Expect(placeholders[5].Text).To(Equal("sqlc.arg(owner)"))
+		Expect(placeholders[5].Pos.Line).To(Equal(8))
+
+		Expect(file.Queries[0].Parameters()).To(HaveLen(6))
+	})
+
+	It("should number anonymous question marks", func() {
+		file := mustParse("-- name: Q :exec\nUPDATE t SET a = ?, b = ? WHERE id = ?;")
+
+		params := file.Queries[0].Parameters()
+		Expect(params).To(HaveLen(3))
+		Expect(params[2].Kind).To(Equal(queries.PlaceholderQuestion))
+		Expect(params[2].Index).To(Equal(3))
+	})
+
+	It("should return syntax errors for unterminated input", func() {
+		_, err := queries.Parse("query.sql", "-- name: Q :one\nSELECT 'open;")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("generated example queries", func() {
+	DescribeTable("should read back what the generator writes",
+		func(engine templates.DatabaseType) {
+			dir := GinkgoT().TempDir()
+			generator := generators.NewGenerator(dir)
+
+			data := templates.TemplateData{}
+			data.Database.Engine = engine
+			data.Output.QueriesDir = "queries"
+			Expect(generator.GenerateExampleQueries(data)).To(Succeed())
+
+			file, err := queries.ParseFile(filepath.Join(dir, "queries", "users.sql"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.Queries).NotTo(BeEmpty())
+
+			names := map[string]bool{}
+			for _, query := range file.Queries {
+				Expect(query.HasHeader()).To(BeTrue(), query.SQL)
+				Expect(query.Cmd.IsValid()).To(BeTrue(), query.Name)
+				Expect(query.Docs).NotTo(BeEmpty(), query.Name)
+				Expect(names).NotTo(HaveKey(query.Name))
+				names[query.Name] = true
+			}
+		},
+		Entry("postgresql", templates.DatabaseTypePostgreSQL),
+		Entry("mysql", templates.DatabaseTypeMySQL),
+		Entry("sqlite", templates.DatabaseTypeSQLite),
+	)
+})
@@ -0,0 +1,188 @@
+package queries
+
+import (
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+)
+
+// Command is the sqlc query command from the "-- name: X :cmd" annotation.
+type Command string
+
+const (
+	CommandOne        Command = ":one"
+	CommandMany       Command = ":many"
+	CommandExec       Command = ":exec"
+	CommandExecRows   Command = ":execrows"
+	CommandExecResult Command = ":execresult"
+	CommandExecLastID Command = ":execlastid"
+	CommandCopyFrom   Command = ":copyfrom"
+	CommandBatchExec  Command = ":batchexec"
+	CommandBatchMany  Command = ":batchmany"
+	CommandBatchOne   Command = ":batchone"
+)
+
+// AllCommands returns every command sqlc understands.
+func AllCommands() []Command {
+	return []Command{
+		CommandOne, CommandMany, CommandExec, CommandExecRows, CommandExecResult,
+		CommandExecLastID, CommandCopyFrom, CommandBatchExec, CommandBatchMany, CommandBatchOne,
+	}
+}
+
+// IsValid returns true if the command is known to sqlc.
+func (c Command) IsValid() bool {
+	switch c {
+	case CommandOne, CommandMany, CommandExec, CommandExecRows, CommandExecResult,
+		CommandExecLastID, CommandCopyFrom, CommandBatchExec, CommandBatchMany, CommandBatchOne:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the command including its leading colon.
+func (c Command) String() string {
+	return string(c)
+}
+
+// ReturnsRows returns true if the generated method returns result rows.
+func (c Command) ReturnsRows() bool {
+	return c == CommandOne || c == CommandMany || c == CommandBatchOne || c == CommandBatchMany
+}
+
+// ReturnsSingleRow returns true if the generated method returns at most one row.
+func (c Command) ReturnsSingleRow() bool {
+	return c == CommandOne || c == CommandBatchOne
+}
+
+// IsBatch returns true for the pgx batch commands.
+func (c Command) IsBatch() bool {
+	return c == CommandBatchExec || c == CommandBatchMany || c == CommandBatchOne
+}
+
+// StatementKind classifies the SQL statement of a query.
+type StatementKind string
+
+const (
+	StatementSelect StatementKind = "select"
+	StatementInsert StatementKind = "insert"
+	StatementUpdate StatementKind = "update"
+	StatementDelete StatementKind = "delete"
+	StatementOther  StatementKind = "other"
+)
+
+// IsValid returns true if the statement kind is recognized.
+func (k StatementKind) IsValid() bool {
+	switch k {
+	case StatementSelect, StatementInsert, StatementUpdate, StatementDelete, StatementOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsWrite returns true for statements that modify rows.
+func (k StatementKind) IsWrite() bool {
+	return k == StatementInsert || k == StatementUpdate || k == StatementDelete
+}
+
+// PlaceholderKind classifies how a query parameter is written.
+type PlaceholderKind string
+
+const (
+	// PlaceholderPositional is a numbered PostgreSQL/SQLite parameter such as $1 or ?1.
+	PlaceholderPositional PlaceholderKind = "positional"
+	// PlaceholderQuestion is an anonymous MySQL/SQLite "?" parameter.
+	PlaceholderQuestion PlaceholderKind = "question"
+	// PlaceholderNamed is the @name (or :name) shorthand for sqlc.arg(name).
+	PlaceholderNamed PlaceholderKind = "named"
+	// PlaceholderArg is sqlc.arg(name).
+	PlaceholderArg PlaceholderKind = "sqlc.arg"
+	// PlaceholderNarg is sqlc.narg(name), a nullable parameter.
+	PlaceholderNarg PlaceholderKind = "sqlc.narg"
+	// PlaceholderSlice is sqlc.slice(name), expanded to a list of values.
+	PlaceholderSlice PlaceholderKind = "sqlc.slice"
+	// PlaceholderEmbed is sqlc.embed(table); it shapes the result rather than taking input.
+	PlaceholderEmbed PlaceholderKind = "sqlc.embed"
+)
+
+// IsValid returns true if the placeholder kind is recognized.
+func (k PlaceholderKind) IsValid() bool {
+	switch k {
+	case PlaceholderPositional, PlaceholderQuestion, PlaceholderNamed, PlaceholderArg,
+		PlaceholderNarg, PlaceholderSlice, PlaceholderEmbed:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsParameter returns true if the placeholder becomes a method parameter.
+func (k PlaceholderKind) IsParameter() bool {
+	return k.IsValid() && k != PlaceholderEmbed
+}
+
+// Placeholder is a parameter reference inside a query body.
+type Placeholder struct {
+	Kind PlaceholderKind `json:"kind"`
+	// Name is the parameter name for named forms and the table for sqlc.embed.
+	Name string `json:"name,omitempty"`
+	// Index is the parameter number for $n/?n, or the 1-based order of anonymous "?".
+	Index int `json:"index,omitempty"`
+	// Text is the placeholder as written, e.g. "$1" or "sqlc.arg(id)".
+	Text string            `json:"text"`
+	Pos  sqlparse.Position `json:"pos"`
+}
+
+// Query is a single "-- name:" block of a query file.
+type Query struct {
+	// Name is the query name; empty when the statement has no annotation.
+	Name string `json:"name"`
+	// Cmd is the command as written; it may be empty or unknown to sqlc.
+	Cmd Command `json:"cmd"`
+	// Docs are the comment lines between the annotation and the SQL, without comment markers.
+	Docs []string `json:"docs,omitempty"`
+	// SQL is the statement text without the trailing semicolon.
+	SQL          string        `json:"sql"`
+	Kind         StatementKind `json:"kind"`
+	Placeholders []Placeholder `json:"placeholders,omitempty"`
+	File         string        `json:"file"`
+	// Pos is the position of the annotation, or of the statement when there is none.
+	Pos sqlparse.Position `json:"pos"`
+	// Statement is the underlying tokenized statement.
+	Statement sqlparse.Statement `json:"-"`
+}
+
+// HasHeader returns true if the query has a "-- name:" annotation.
+func (q *Query) HasHeader() bool {
+	return q.Name != ""
+}
+
+// Parameters returns the placeholders that become method parameters.
+func (q *Query) Parameters() []Placeholder {
+	var params []Placeholder
+
+	for _, p := range q.Placeholders {
+		if p.Kind.IsParameter() {
+			params = append(params, p)
+		}
+	}
+
+	return params
+}
+
+// File is a parsed query file.
+type File struct {
+	Path    string  `json:"path"`
+	Queries []Query `json:"queries"`
+}
+
+// Query returns the query with the given name, or nil.
+func (f *File) Query(name string) *Query {
+	for i := range f.Queries {
+		if f.Queries[i].Name == name {
+			return &f.Queries[i]
+		}
+	}
+
+	return nil
+}
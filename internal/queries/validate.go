@@ -0,0 +1,159 @@
+package queries
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+)
+
+// Check codes reported by ValidateConfig and ValidateFiles.
+const (
+	CheckMissingName        = "query-missing-name"
+	CheckDuplicateName      = "query-duplicate-name"
+	CheckEmptyQuery         = "query-empty"
+	CheckUnknownCommand     = "query-unknown-command"
+	CheckUnsupportedCommand = "query-unsupported-command"
+	CheckSyntax             = "query-syntax"
+	CheckUnreadable         = "query-unreadable"
+)
+
+// ValidateConfig opens the query files of every sql[] entry and checks their annotations:
+// missing "-- name:" headers, unknown or missing commands, duplicate query names
+// within an entry, and commands the engine or sql_package cannot generate.
+// Relative paths are resolved against baseDir, normally the directory of sqlc.yaml.
+func ValidateConfig(cfg *config.SqlcConfig, baseDir string) *config.ValidationResult {
+	result := &config.ValidationResult{}
+
+	if cfg == nil {
+		return result
+	}
+
+	for i := range cfg.SQL {
+		validateSQLConfig(&cfg.SQL[i], i, baseDir, result)
+	}
+
+	return result
+}
+
+func validateSQLConfig(cfg *config.SQLConfig, index int, baseDir string, result *config.ValidationResult) {
+	prefix := fmt.Sprintf("sql[%d].queries", index)
+
+	if cfg.Queries.IsEmpty() {
+		return // reported by config.Validate
+	}
+
+	paths, err := cfg.Queries.SQLFiles(baseDir)
+	if err != nil {
+		if apperrors.Is(err, apperrors.ErrFileNotFound) {
+			return // reported by config.ValidatePaths
+		}
+
+		result.AddErrorWithCode(CheckUnreadable, prefix, fmt.Sprintf("cannot read query files: %v", err))
+
+		return
+	}
+
+	sqlPackage := ""
+	if cfg.Gen.Go != nil {
+		sqlPackage = cfg.Gen.Go.SQLPackage
+	}
+
+	var files []*File
+
+	for _, path := range paths {
+		file, err := ParseFile(path)
+		if err != nil {
+			var syntaxErr *sqlparse.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				result.AddErrorWithCode(CheckSyntax, location(path, syntaxErr.Pos), syntaxErr.Message)
+
+				continue
+			}
+
+			result.AddErrorWithCode(CheckUnreadable, prefix, err.Error())
+
+			continue
+		}
+
+		files = append(files, file)
+	}
+
+	ValidateFiles(files, cfg.Engine, sqlPackage, result)
+}
+
+// ValidateFiles checks the annotations of query files that belong to one sql[] entry.
+// Problems are reported with "file:line" as the field.
+func ValidateFiles(files []*File, engine, sqlPackage string, result *config.ValidationResult) {
+	seen := map[string]*Query{}
+
+	for _, file := range files {
+		for i := range file.Queries {
+			query := &file.Queries[i]
+			field := location(file.Path, query.Pos)
+
+			if !query.HasHeader() {
+				result.AddErrorWithCode(CheckMissingName, field, `statement has no "-- name: <Name> :<command>" annotation`)
+
+				continue
+			}
+
+			if first, ok := seen[query.Name]; ok {
+				result.AddErrorWithCode(CheckDuplicateName, field, fmt.Sprintf(
+					"duplicate query name %q (first defined at %s)",
+					query.Name, location(first.File, first.Pos),
+				))
+			} else {
+				seen[query.Name] = query
+			}
+
+			if strings.TrimSpace(query.SQL) == "" {
+				result.AddErrorWithCode(CheckEmptyQuery, field, fmt.Sprintf("query %q has no SQL statement", query.Name))
+			}
+
+			validateCommand(query, field, engine, sqlPackage, result)
+		}
+	}
+}
+
+func validateCommand(query *Query, field, engine, sqlPackage string, result *config.ValidationResult) {
+	switch {
+	case query.Cmd == "":
+		result.AddErrorWithCode(CheckUnknownCommand, field, fmt.Sprintf(
+			"query %q has no command (expected one of: %s)", query.Name, commandList(),
+		))
+
+		return
+	case !query.Cmd.IsValid():
+		result.AddErrorWithCode(CheckUnknownCommand, field, fmt.Sprintf(
+			"query %q has unknown command %s (expected one of: %s)", query.Name, query.Cmd, commandList(),
+		))
+
+		return
+	}
+
+	if message, isError := config.CommandCompatibility(query.Cmd.String(), engine, sqlPackage); message != "" {
+		message = fmt.Sprintf("query %q: %s", query.Name, message)
+		if isError {
+			result.AddErrorWithCode(CheckUnsupportedCommand, field, message)
+		} else {
+			result.AddWarningWithCode(CheckUnsupportedCommand, field, message)
+		}
+	}
+}
+
+func commandList() string {
+	names := make([]string, 0, len(AllCommands()))
+	for _, cmd := range AllCommands() {
+		names = append(names, cmd.String())
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func location(path string, pos sqlparse.Position) string {
+	return fmt.Sprintf("%s:%d", path, pos.Line)
+}
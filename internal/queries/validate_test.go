@@ -0,0 +1,110 @@
+package queries_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/queries"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func messages(items []config.ValidationError) []string {
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		out = append(out, item.Field+": "+item.Message)
+	}
+
+	return out
+}
+
+var _ = Describe("ValidateConfig", func() {
+	var dir string
+
+	writeQueries := func(name, content string) {
+		path := filepath.Join(dir, "queries", name)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+	}
+
+	configFor := func(engine, sqlPackage string) *config.SqlcConfig {
+		return &config.SqlcConfig{
+			Version: "2",
+			SQL: []config.SQLConfig{{
+				Engine:  engine,
+				Queries: config.NewSinglePath("queries"),
+				Gen:     config.GenConfig{Go: &config.GoGenConfig{SQLPackage: sqlPackage}},
+			}},
+		}
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("should accept well-formed queries", func() {
+		writeQueries("users.sql", "-- name: GetUser :one\nSELECT id FROM users WHERE id = $1;\n")
+
+		result := queries.ValidateConfig(configFor("postgresql", "pgx/v5"), dir)
+		Expect(result.Errors).To(BeEmpty())
+		Expect(result.Warnings).To(BeEmpty())
+	})
+
+	It("should flag missing headers, bad commands and duplicates across files", func() {
+		writeQueries("a.sql", `-- name: GetUser :one
+SELECT id FROM users WHERE id = $1;
+
+SELECT 1;
+
+-- name: Broken :first
+SELECT 2;
+
+-- name: NoCmd
+SELECT 3;
+`)
+		writeQueries("b.sql", "-- name: GetUser :many\nSELECT id FROM users;\n")
+
+		result := queries.ValidateConfig(configFor("postgresql", "pgx/v5"), dir)
+		aPath := filepath.Join(dir, "queries", "a.sql")
+		bPath := filepath.Join(dir, "queries", "b.sql")
+
+		Expect(messages(result.Errors)).To(ConsistOf(
+			HavePrefix(aPath+":4: statement has no"),
+			HavePrefix(aPath+`:6: query "Broken" has unknown command :first`),
+			HavePrefix(aPath+`:9: query "NoCmd" has no command`),
+			Equal(bPath+`:1: duplicate query name "GetUser" (first defined at `+aPath+":1)"),
+		))
+	})
+
+	It("should flag annotations without SQL", func() {
+		writeQueries("a.sql", "-- name: Forgotten :exec\n-- name: Real :exec\nDELETE FROM users WHERE id = $1;\n")
+
+		result := queries.ValidateConfig(configFor("postgresql", "pgx/v5"), dir)
+		Expect(messages(result.Errors)).To(ConsistOf(ContainSubstring(`query "Forgotten" has no SQL statement`)))
+	})
+
+	DescribeTable("engine and sql_package support",
+		func(engine, sqlPackage, cmd string, errors, warnings int) {
+			writeQueries("a.sql", "-- name: Q "+cmd+"\nINSERT INTO users (id) VALUES ($1);\n")
+
+			result := queries.ValidateConfig(configFor(engine, sqlPackage), dir)
+			Expect(result.Errors).To(HaveLen(errors), "%v", messages(result.Errors))
+			Expect(result.Warnings).To(HaveLen(warnings), "%v", messages(result.Warnings))
+		},
+		Entry("batch on pgx", "postgresql", "pgx/v5", ":batchexec", 0, 0),
+		Entry("batch on database/sql", "postgresql", "database/sql", ":batchone", 1, 0),
+		Entry("batch on mysql", "mysql", "database/sql", ":batchmany", 1, 0),
+		Entry("copyfrom on pgx/v4", "postgresql", "pgx/v4", ":copyfrom", 0, 0),
+		Entry("copyfrom on database/sql", "postgresql", "database/sql", ":copyfrom", 1, 0),
+		Entry("copyfrom on sqlite", "sqlite", "database/sql", ":copyfrom", 1, 0),
+		Entry("copyfrom on mysql", "mysql", "database/sql", ":copyfrom", 0, 1),
+		Entry("execlastid on postgresql", "postgresql", "pgx/v5", ":execlastid", 1, 0),
+		Entry("execlastid on mysql", "mysql", "database/sql", ":execlastid", 0, 0),
+	)
+
+	It("should leave missing query paths to config.ValidatePaths", func() {
+		result := queries.ValidateConfig(configFor("postgresql", "pgx/v5"), dir)
+		Expect(result.Errors).To(BeEmpty())
+	})
+})
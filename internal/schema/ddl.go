@@ -0,0 +1,1091 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+)
+
+// columnConstraintKeywords end a column's type and start its constraint list
+// (or, for ALTER COLUMN ... TYPE, the USING conversion expression).
+var columnConstraintKeywords = map[string]bool{
+	"CONSTRAINT": true, "NOT": true, "NULL": true, "DEFAULT": true, "PRIMARY": true,
+	"UNIQUE": true, "REFERENCES": true, "CHECK": true, "AUTO_INCREMENT": true,
+	"AUTOINCREMENT": true, "GENERATED": true, "COLLATE": true, "COMMENT": true,
+	"ON": true, "AS": true, "IDENTITY": true, "USING": true,
+}
+
+// tableConstraintKeywords start a table-level constraint inside CREATE TABLE.
+var tableConstraintKeywords = map[string]bool{
+	"CONSTRAINT": true, "PRIMARY": true, "UNIQUE": true, "FOREIGN": true, "CHECK": true,
+	"EXCLUDE": true,
+}
+
+// mysqlIndexKeywords start an inline index inside a MySQL CREATE TABLE. They are reserved
+// in MySQL only; PostgreSQL and SQLite accept them as unquoted column names.
+var mysqlIndexKeywords = map[string]bool{
+	"KEY": true, "INDEX": true, "FULLTEXT": true, "SPATIAL": true,
+}
+
+// parseStatement dispatches a single statement to its handler.
+func (p *Parser) parseStatement(stmt *sqlparse.Statement) error {
+	c := sqlparse.NewCursor(p.src, stmt.Tokens)
+
+	switch {
+	case c.Accept("CREATE"):
+		return p.parseCreate(c)
+	case c.Accept("ALTER", "TABLE"):
+		return p.parseAlterTable(c)
+	case c.Accept("ALTER", "TYPE"):
+		return p.parseAlterType(c)
+	case c.Accept("DROP"):
+		return p.parseDrop(c)
+	default:
+		return nil
+	}
+}
+
+func (p *Parser) parseCreate(c *sqlparse.Cursor) error {
+	c.Accept("OR", "REPLACE")
+
+	for c.Accept("TEMP") || c.Accept("TEMPORARY") || c.Accept("UNLOGGED") ||
+		c.Accept("GLOBAL") || c.Accept("LOCAL") {
+	}
+
+	switch {
+	case c.Accept("TABLE"):
+		return p.parseCreateTable(c)
+	case c.Accept("UNIQUE", "INDEX"):
+		return p.parseCreateIndex(c, true, IndexTypeBTree)
+	case c.Accept("FULLTEXT", "INDEX"):
+		return p.parseCreateIndex(c, false, IndexTypeFullText)
+	case c.Accept("INDEX"):
+		return p.parseCreateIndex(c, false, IndexTypeBTree)
+	case c.Accept("TYPE"):
+		return p.parseCreateType(c)
+	case c.Accept("MATERIALIZED", "VIEW"), c.Accept("VIEW"):
+		return p.parseCreateView(c)
+	default:
+		return nil
+	}
+}
+
+// objectName reads a possibly schema-qualified object name.
+// The engine's default schema qualifier (public, main) is dropped and unquoted
+// PostgreSQL identifiers are folded to lower case, as the server does.
+func (p *Parser) objectName(c *sqlparse.Cursor) (string, error) {
+	tok := c.Peek()
+	if c.Done() || !tok.IsIdent() {
+		return "", c.Errorf("expected name")
+	}
+
+	parts := []string{p.identValue(c.Next())}
+
+	for c.Peek().IsPunct(".") && c.PeekAt(1).IsIdent() {
+		c.Next()
+
+		parts = append(parts, p.identValue(c.Next()))
+	}
+
+	if len(parts) > 1 && p.isDefaultSchema(parts[0]) {
+		parts = parts[1:]
+	}
+
+	return strings.Join(parts, "."), nil
+}
+
+// identValue returns an identifier's name, folding unquoted PostgreSQL names.
+func (p *Parser) identValue(tok sqlparse.Token) string {
+	if tok.Kind == sqlparse.TokenWord && p.engine == EnginePostgreSQL {
+		return strings.ToLower(tok.Value)
+	}
+
+	return tok.Value
+}
+
+func (p *Parser) isDefaultSchema(name string) bool {
+	switch strings.ToLower(name) {
+	case "public":
+		return p.engine == EnginePostgreSQL
+	case "main":
+		return p.engine == EngineSQLite
+	default:
+		return false
+	}
+}
+
+// table returns the table with the given name, or nil.
+func (p *Parser) table(name string) *Table {
+	for i := range p.tables {
+		if strings.EqualFold(p.tables[i].Name, name) {
+			return &p.tables[i]
+		}
+	}
+
+	return nil
+}
+
+// requireTable returns the named table or a positioned error.
+func (p *Parser) requireTable(c *sqlparse.Cursor, name string) (*Table, error) {
+	table := p.table(name)
+	if table == nil {
+		return nil, &sqlparse.SyntaxError{
+			Pos:     c.Peek().Pos,
+			Message: fmt.Sprintf("relation %q does not exist", name),
+		}
+	}
+
+	return table, nil
+}
+
+func (p *Parser) parseCreateTable(c *sqlparse.Cursor) error {
+	ifNotExists := c.Accept("IF", "NOT", "EXISTS")
+	namePos := c.Peek().Pos
+
+	name, err := p.objectName(c)
+	if err != nil {
+		return err
+	}
+
+	if p.table(name) != nil || p.view(name) != nil {
+		if ifNotExists {
+			return nil
+		}
+
+		return &sqlparse.SyntaxError{
+			Pos:     namePos,
+			Message: fmt.Sprintf("relation %q already exists", name),
+		}
+	}
+
+	// CREATE TABLE ... AS SELECT and LIKE copies cannot be resolved statically.
+	if c.Peek().Is("AS") || c.Peek().Is("LIKE") {
+		return nil
+	}
+
+	body, err := c.Group()
+	if err != nil {
+		return err
+	}
+
+	// The table is registered while its body parses so self-references resolve, and
+	// dropped again with its constraints when the body turns out to be invalid.
+	p.tables = append(p.tables, Table{Name: name, Columns: []Column{}})
+	constraints := len(p.constraints)
+
+	if err := p.parseTableBody(name, body); err != nil {
+		p.tables = p.tables[:len(p.tables)-1]
+		p.constraints = p.constraints[:constraints]
+
+		return err
+	}
+
+	return nil
+}
+
+// parseTableBody parses the column and constraint definitions of a CREATE TABLE.
+func (p *Parser) parseTableBody(name string, body []sqlparse.Token) error {
+	for _, def := range sqlparse.SplitTopLevel(body) {
+		if len(def) == 0 {
+			continue
+		}
+
+		table := p.table(name)
+		defCursor := sqlparse.NewCursor(p.src, def)
+
+		var err error
+		if p.startsTableConstraint(def[0]) {
+			err = p.parseTableConstraint(defCursor, table)
+		} else {
+			err = p.addColumn(defCursor, table)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startsTableConstraint reports whether a CREATE TABLE definition starting with tok is a
+// constraint or inline index rather than a column.
+func (p *Parser) startsTableConstraint(tok sqlparse.Token) bool {
+	if tok.Kind != sqlparse.TokenWord {
+		return false
+	}
+
+	return tableConstraintKeywords[tok.Upper()] || (p.engine == EngineMySQL && mysqlIndexKeywords[tok.Upper()])
+}
+
+// addColumn parses a column definition and appends it to the table.
+func (p *Parser) addColumn(c *sqlparse.Cursor, table *Table) error {
+	namePos := c.Peek().Pos
+
+	column, fk, checks, err := p.parseColumnDef(c, table.Name)
+	if err != nil {
+		return err
+	}
+
+	if slices.ContainsFunc(table.Columns, func(col Column) bool {
+		return strings.EqualFold(col.Name, column.Name)
+	}) {
+		return &sqlparse.SyntaxError{
+			Pos:     namePos,
+			Message: fmt.Sprintf("column %q of relation %q already exists", column.Name, table.Name),
+		}
+	}
+
+	table.Columns = append(table.Columns, column)
+
+	if column.PrimaryKey {
+		table.PrimaryKey = &Index{
+			Name:    table.Name + "_pkey",
+			Table:   table.Name,
+			Columns: []string{column.Name},
+			Unique:  true,
+			Type:    IndexTypeBTree,
+		}
+	}
+
+	if fk != nil {
+		table.ForeignKeys = append(table.ForeignKeys, *fk)
+	}
+
+	p.constraints = append(p.constraints, checks...)
+
+	return nil
+}
+
+// parseColumnDef parses "name type [constraints...]".
+func (p *Parser) parseColumnDef(
+	c *sqlparse.Cursor,
+	tableName string,
+) (Column, *ForeignKey, []Constraint, error) {
+	nameTok := c.Peek()
+	if !nameTok.IsIdent() {
+		return Column{}, nil, nil, c.Errorf("expected column name")
+	}
+
+	c.Next()
+
+	column := Column{Name: p.identValue(nameTok), Nullable: true}
+	column.SQLType = p.readColumnType(c)
+	column.Type = ColumnTypeFromSQL(column.SQLType)
+	column.AutoIncrement = isSerialType(column.SQLType)
+
+	var (
+		fk             *ForeignKey
+		constraints    []Constraint
+		constraintName string
+	)
+
+	for !c.Done() {
+		switch {
+		case c.Accept("CONSTRAINT"):
+			name, err := c.Ident()
+			if err != nil {
+				return Column{}, nil, nil, err
+			}
+
+			constraintName = name
+
+			continue
+		case c.Accept("NOT", "NULL"):
+			column.Nullable = false
+		case c.Accept("NULL"):
+			column.Nullable = true
+		case c.Accept("DEFAULT"):
+			expr := p.readExpression(c)
+			column.Default = &expr
+		case c.Accept("PRIMARY", "KEY"):
+			column.PrimaryKey = true
+			column.Nullable = false
+
+			_ = c.Accept("ASC") || c.Accept("DESC")
+
+			if c.Accept("AUTOINCREMENT") {
+				column.AutoIncrement = true
+				column.SQLiteAutoincrement = p.engine == EngineSQLite
+			}
+
+			if p.engine == EngineSQLite && strings.EqualFold(column.SQLType, "INTEGER") {
+				// INTEGER PRIMARY KEY aliases the SQLite rowid.
+				column.AutoIncrement = true
+			}
+		case c.Accept("UNIQUE"):
+			_ = c.Accept("KEY")
+			column.Unique = true
+			constraints = append(constraints, Constraint{
+				Name:    defaultName(constraintName, tableName+"_"+column.Name+"_key"),
+				Type:    ConstraintTypeUnique,
+				Table:   tableName,
+				Columns: []string{column.Name},
+			})
+		case c.Accept("REFERENCES"):
+			ref, err := p.parseReference(c, tableName, []string{column.Name}, constraintName)
+			if err != nil {
+				return Column{}, nil, nil, err
+			}
+
+			fk = &ref[0]
+		case c.Accept("CHECK"):
+			body, err := c.Group()
+			if err != nil {
+				return Column{}, nil, nil, err
+			}
+
+			constraints = append(constraints, checkConstraint(
+				defaultName(constraintName, tableName+"_"+column.Name+"_check"),
+				tableName,
+				[]string{column.Name},
+				c.Text(body),
+			))
+		case c.Accept("AUTO_INCREMENT"), c.Accept("AUTOINCREMENT"), c.Accept("IDENTITY"):
+			column.AutoIncrement = true
+			_ = c.SkipGroupIfPresent()
+		case c.Accept("GENERATED"):
+			if err := p.skipGenerated(c, &column); err != nil {
+				return Column{}, nil, nil, err
+			}
+		case c.Accept("AS"):
+			// SQLite/MySQL generated column: AS (expr) [STORED|VIRTUAL]
+			if err := c.SkipGroupIfPresent(); err != nil {
+				return Column{}, nil, nil, err
+			}
+		case c.Accept("ON", "UPDATE"):
+			p.readExpression(c)
+		case c.Accept("COLLATE"), c.Accept("COMMENT"):
+			c.Next()
+		default:
+			c.Next()
+		}
+
+		constraintName = ""
+	}
+
+	return column, fk, constraints, nil
+}
+
+// readColumnType consumes the type tokens of a column definition and returns them as written.
+func (p *Parser) readColumnType(c *sqlparse.Cursor) string {
+	var typeTokens []sqlparse.Token
+
+	for !c.Done() {
+		tok := c.Peek()
+		if tok.Kind == sqlparse.TokenWord && columnConstraintKeywords[tok.Upper()] {
+			break
+		}
+
+		if tok.IsPunct("(") {
+			start := c.Peek()
+
+			group, err := c.Group()
+			if err != nil {
+				break
+			}
+
+			typeTokens = append(typeTokens, start)
+			typeTokens = append(typeTokens, group...)
+			typeTokens = append(typeTokens, sqlparse.Token{Pos: start.Pos, End: closingEnd(start, group)})
+
+			continue
+		}
+
+		typeTokens = append(typeTokens, c.Next())
+	}
+
+	if len(typeTokens) == 0 {
+		return ""
+	}
+
+	return strings.Join(strings.Fields(p.src[typeTokens[0].Pos.Offset:typeTokens[len(typeTokens)-1].End]), " ")
+}
+
+// closingEnd returns the offset just past a group's closing parenthesis.
+func closingEnd(open sqlparse.Token, inner []sqlparse.Token) int {
+	if len(inner) == 0 {
+		return open.End + 1
+	}
+
+	return inner[len(inner)-1].End + 1
+}
+
+// readExpression consumes a default/ON UPDATE expression up to the next column constraint.
+func (p *Parser) readExpression(c *sqlparse.Cursor) string {
+	start := c.Peek()
+	end := start.End
+	first := true
+
+	for !c.Done() {
+		tok := c.Peek()
+		if !first && tok.Kind == sqlparse.TokenWord && columnConstraintKeywords[tok.Upper()] {
+			break
+		}
+
+		if tok.IsPunct("(") {
+			group, err := c.Group()
+			if err != nil {
+				break
+			}
+
+			end = closingEnd(tok, group)
+		} else {
+			end = c.Next().End
+		}
+
+		first = false
+	}
+
+	return strings.TrimSpace(p.src[start.Pos.Offset:end])
+}
+
+// skipGenerated handles GENERATED {ALWAYS|BY DEFAULT} AS IDENTITY and generated expressions.
+func (p *Parser) skipGenerated(c *sqlparse.Cursor, column *Column) error {
+	_ = c.Accept("ALWAYS") || c.Accept("BY", "DEFAULT")
+
+	if err := c.Expect("AS"); err != nil {
+		return err
+	}
+
+	if c.Accept("IDENTITY") {
+		column.AutoIncrement = true
+	}
+
+	return c.SkipGroupIfPresent()
+}
+
+// parseReference parses "table [(cols)] [ON DELETE x] [ON UPDATE y] ..." after REFERENCES.
+func (p *Parser) parseReference(
+	c *sqlparse.Cursor,
+	tableName string,
+	sourceColumns []string,
+	constraintName string,
+) ([]ForeignKey, error) {
+	target, err := p.objectName(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var targetColumns []string
+
+	if c.Peek().IsPunct("(") {
+		targetColumns, err = p.columnList(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(targetColumns) == 0 {
+		targetColumns = p.primaryKeyColumns(target)
+	}
+
+	name := defaultName(constraintName, tableName+"_"+strings.Join(sourceColumns, "_")+"_fkey")
+	onDelete, onUpdate := "NO ACTION", "NO ACTION"
+
+	for !c.Done() {
+		switch {
+		case c.Accept("ON", "DELETE"):
+			onDelete = readReferentialAction(c)
+		case c.Accept("ON", "UPDATE"):
+			onUpdate = readReferentialAction(c)
+		case c.Peek().Is("MATCH"), c.Peek().Is("DEFERRABLE"), c.Peek().Is("INITIALLY"),
+			c.Peek().Is("NOT") && c.PeekAt(1).Is("DEFERRABLE"), c.Peek().Is("IMMEDIATE"),
+			c.Peek().Is("DEFERRED"), c.Peek().Is("FULL"), c.Peek().Is("SIMPLE"),
+			c.Peek().Is("PARTIAL"):
+			c.Next()
+		default:
+			return buildForeignKeys(name, tableName, sourceColumns, target, targetColumns, onDelete, onUpdate), nil
+		}
+	}
+
+	return buildForeignKeys(name, tableName, sourceColumns, target, targetColumns, onDelete, onUpdate), nil
+}
+
+// buildForeignKeys pairs source and target columns, one ForeignKey per pair.
+func buildForeignKeys(
+	name, table string,
+	sourceColumns []string,
+	target string,
+	targetColumns []string,
+	onDelete, onUpdate string,
+) []ForeignKey {
+	fks := make([]ForeignKey, 0, len(sourceColumns))
+
+	for i, source := range sourceColumns {
+		targetColumn := ""
+		if i < len(targetColumns) {
+			targetColumn = targetColumns[i]
+		}
+
+		fks = append(fks, ForeignKey{
+			Name:           name,
+			SourceTable:    table,
+			SourceColumn:   source,
+			TargetTable:    target,
+			TargetColumn:   targetColumn,
+			OnDeleteAction: onDelete,
+			OnUpdateAction: onUpdate,
+		})
+	}
+
+	return fks
+}
+
+func readReferentialAction(c *sqlparse.Cursor) string {
+	switch {
+	case c.Accept("CASCADE"):
+		return "CASCADE"
+	case c.Accept("RESTRICT"):
+		return "RESTRICT"
+	case c.Accept("NO", "ACTION"):
+		return "NO ACTION"
+	case c.Accept("SET", "NULL"):
+		return "SET NULL"
+	case c.Accept("SET", "DEFAULT"):
+		return "SET DEFAULT"
+	default:
+		return strings.ToUpper(c.Next().Text)
+	}
+}
+
+// primaryKeyColumns returns the primary key columns of a table, used for implicit references.
+func (p *Parser) primaryKeyColumns(tableName string) []string {
+	table := p.table(tableName)
+	if table == nil || table.PrimaryKey == nil {
+		return nil
+	}
+
+	return slices.Clone(table.PrimaryKey.Columns)
+}
+
+// columnList parses "(a, b DESC, lower(c))" into column names or expressions.
+func (p *Parser) columnList(c *sqlparse.Cursor) ([]string, error) {
+	group, err := c.Group()
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []string
+
+	for _, part := range sqlparse.SplitTopLevel(group) {
+		if len(part) == 0 {
+			continue
+		}
+
+		// Plain column (optionally with ASC/DESC, opclass or MySQL prefix length).
+		if part[0].IsIdent() && (len(part) == 1 || !part[1].IsPunct("(")) && !part[0].Is("CASE") {
+			columns = append(columns, p.identValue(part[0]))
+
+			continue
+		}
+
+		columns = append(columns, sqlparse.SourceText(p.src, part))
+	}
+
+	return columns, nil
+}
+
+// parseTableConstraint parses a table-level constraint or MySQL inline index.
+func (p *Parser) parseTableConstraint(c *sqlparse.Cursor, table *Table) error {
+	constraintName := ""
+
+	if c.Accept("CONSTRAINT") {
+		name, err := c.Ident()
+		if err != nil {
+			return err
+		}
+
+		constraintName = name
+	}
+
+	switch {
+	case c.Accept("PRIMARY", "KEY"):
+		columns, err := p.columnList(c)
+		if err != nil {
+			return err
+		}
+
+		p.setPrimaryKey(table, defaultName(constraintName, table.Name+"_pkey"), columns)
+	case c.Accept("UNIQUE"):
+		_ = c.Accept("KEY") || c.Accept("INDEX")
+		name := constraintName
+
+		if c.Peek().IsIdent() {
+			name, _ = c.Ident()
+		}
+
+		columns, err := p.columnList(c)
+		if err != nil {
+			return err
+		}
+
+		p.addUnique(table, defaultName(name, table.Name+"_"+strings.Join(columns, "_")+"_key"), columns)
+	case c.Accept("FOREIGN", "KEY"):
+		if c.Peek().IsIdent() {
+			c.Next() // MySQL allows an index name here
+		}
+
+		columns, err := p.columnList(c)
+		if err != nil {
+			return err
+		}
+
+		if err := c.Expect("REFERENCES"); err != nil {
+			return err
+		}
+
+		fks, err := p.parseReference(c, table.Name, columns, constraintName)
+		if err != nil {
+			return err
+		}
+
+		table.ForeignKeys = append(table.ForeignKeys, fks...)
+	case c.Accept("CHECK"):
+		body, err := c.Group()
+		if err != nil {
+			return err
+		}
+
+		p.constraints = append(p.constraints, checkConstraint(
+			defaultName(constraintName, table.Name+"_check"), table.Name, nil, c.Text(body),
+		))
+	case c.Accept("KEY"), c.Accept("INDEX"):
+		return p.inlineIndex(c, table, IndexTypeBTree)
+	case c.Accept("FULLTEXT"), c.Accept("SPATIAL"):
+		_ = c.Accept("KEY") || c.Accept("INDEX")
+
+		return p.inlineIndex(c, table, IndexTypeFullText)
+	case c.Accept("EXCLUDE"):
+		return nil
+	default:
+		return c.Errorf("unsupported table constraint")
+	}
+
+	return nil
+}
+
+// inlineIndex parses MySQL "KEY name (cols)" inside CREATE TABLE.
+func (p *Parser) inlineIndex(c *sqlparse.Cursor, table *Table, indexType IndexType) error {
+	name := ""
+	if c.Peek().IsIdent() {
+		name, _ = c.Ident()
+	}
+
+	columns, err := p.columnList(c)
+	if err != nil {
+		return err
+	}
+
+	table.Indexes = append(table.Indexes, Index{
+		Name:    defaultName(name, table.Name+"_"+strings.Join(columns, "_")+"_idx"),
+		Table:   table.Name,
+		Columns: columns,
+		Type:    indexType,
+	})
+
+	return nil
+}
+
+func (p *Parser) setPrimaryKey(table *Table, name string, columns []string) {
+	table.PrimaryKey = &Index{
+		Name:    name,
+		Table:   table.Name,
+		Columns: columns,
+		Unique:  true,
+		Type:    IndexTypeBTree,
+	}
+
+	for i := range table.Columns {
+		if containsFold(columns, table.Columns[i].Name) {
+			table.Columns[i].PrimaryKey = true
+			table.Columns[i].Nullable = false
+
+			// A single INTEGER primary key aliases the SQLite rowid, as inline.
+			if p.engine == EngineSQLite && len(columns) == 1 && strings.EqualFold(table.Columns[i].SQLType, "INTEGER") {
+				table.Columns[i].AutoIncrement = true
+			}
+		}
+	}
+}
+
+func (p *Parser) addUnique(table *Table, name string, columns []string) {
+	if len(columns) == 1 {
+		for i := range table.Columns {
+			if strings.EqualFold(table.Columns[i].Name, columns[0]) {
+				table.Columns[i].Unique = true
+			}
+		}
+	}
+
+	p.constraints = append(p.constraints, Constraint{
+		Name:    name,
+		Type:    ConstraintTypeUnique,
+		Table:   table.Name,
+		Columns: columns,
+	})
+}
+
+func (p *Parser) parseCreateIndex(c *sqlparse.Cursor, unique bool, indexType IndexType) error {
+	_ = c.Accept("CONCURRENTLY")
+	ifNotExists := c.Accept("IF", "NOT", "EXISTS")
+
+	name := ""
+	if !c.Peek().Is("ON") {
+		var err error
+
+		name, err = p.objectName(c)
+		if err != nil {
+			return err
+		}
+	}
+
+	// MySQL: CREATE INDEX name USING BTREE ON t (...)
+	if c.Accept("USING") {
+		indexType = IndexType(strings.ToLower(c.Next().Text))
+	}
+
+	if err := c.Expect("ON"); err != nil {
+		return err
+	}
+
+	_ = c.Accept("ONLY")
+
+	tableName, err := p.objectName(c)
+	if err != nil {
+		return err
+	}
+
+	table, err := p.requireTable(c, tableName)
+	if err != nil {
+		return err
+	}
+
+	if c.Accept("USING") {
+		indexType = IndexType(strings.ToLower(c.Next().Text))
+	}
+
+	columns, err := p.columnList(c)
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		name = table.Name + "_" + strings.Join(columns, "_") + "_idx"
+	}
+
+	if p.index(name) != nil {
+		if ifNotExists {
+			return nil
+		}
+
+		return c.Errorf("relation %q already exists", name)
+	}
+
+	index := Index{Name: name, Table: table.Name, Columns: columns, Unique: unique, Type: indexType}
+
+	for !c.Done() {
+		if c.Accept("WHERE") {
+			index.Where = c.Text(c.Rest())
+
+			break
+		}
+
+		if c.Peek().Is("INCLUDE") || c.Peek().Is("WITH") {
+			c.Next()
+
+			if err := c.SkipGroupIfPresent(); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		c.Next()
+	}
+
+	table.Indexes = append(table.Indexes, index)
+
+	return nil
+}
+
+// index returns the index with the given name on any table, or nil.
+func (p *Parser) index(name string) *Index {
+	for i := range p.tables {
+		for j := range p.tables[i].Indexes {
+			if strings.EqualFold(p.tables[i].Indexes[j].Name, name) {
+				return &p.tables[i].Indexes[j]
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *Parser) parseCreateType(c *sqlparse.Cursor) error {
+	name, err := p.objectName(c)
+	if err != nil {
+		return err
+	}
+
+	if !c.Accept("AS", "ENUM") {
+		return nil // composite and range types are not modelled
+	}
+
+	group, err := c.Group()
+	if err != nil {
+		return err
+	}
+
+	enum := Enum{Name: name, Values: []string{}}
+
+	for _, tok := range group {
+		if tok.Kind == sqlparse.TokenString {
+			enum.Values = append(enum.Values, tok.Value)
+		}
+	}
+
+	if p.enum(name) != nil {
+		return &sqlparse.SyntaxError{
+			Pos:     group[0].Pos,
+			Message: fmt.Sprintf("type %q already exists", name),
+		}
+	}
+
+	p.enums = append(p.enums, enum)
+
+	return nil
+}
+
+func (p *Parser) enum(name string) *Enum {
+	for i := range p.enums {
+		if strings.EqualFold(p.enums[i].Name, name) {
+			return &p.enums[i]
+		}
+	}
+
+	return nil
+}
+
+func (p *Parser) parseAlterType(c *sqlparse.Cursor) error {
+	name, err := p.objectName(c)
+	if err != nil {
+		return err
+	}
+
+	enum := p.enum(name)
+	if enum == nil {
+		return nil
+	}
+
+	switch {
+	case c.Accept("ADD", "VALUE"):
+		_ = c.Accept("IF", "NOT", "EXISTS")
+
+		value := c.Next().Value
+		if slices.Contains(enum.Values, value) {
+			return nil
+		}
+
+		enum.Values = insertEnumValue(c, enum.Values, value)
+	case c.Accept("RENAME", "VALUE"):
+		from := c.Next().Value
+
+		if err := c.Expect("TO"); err != nil {
+			return err
+		}
+
+		to := c.Next().Value
+		if i := slices.Index(enum.Values, from); i >= 0 {
+			enum.Values[i] = to
+		}
+	case c.Accept("RENAME", "TO"):
+		enum.Name = p.identValue(c.Next())
+	}
+
+	return nil
+}
+
+// insertEnumValue honours BEFORE/AFTER placement of ALTER TYPE ... ADD VALUE.
+func insertEnumValue(c *sqlparse.Cursor, values []string, value string) []string {
+	switch {
+	case c.Accept("BEFORE"):
+		if i := slices.Index(values, c.Next().Value); i >= 0 {
+			return slices.Insert(values, i, value)
+		}
+	case c.Accept("AFTER"):
+		if i := slices.Index(values, c.Next().Value); i >= 0 {
+			return slices.Insert(values, i+1, value)
+		}
+	}
+
+	return append(values, value)
+}
+
+func (p *Parser) parseCreateView(c *sqlparse.Cursor) error {
+	ifNotExists := c.Accept("IF", "NOT", "EXISTS")
+	namePos := c.Peek().Pos
+
+	name, err := p.objectName(c)
+	if err != nil {
+		return err
+	}
+
+	if p.view(name) != nil || p.table(name) != nil {
+		if ifNotExists {
+			return nil
+		}
+
+		// CREATE OR REPLACE VIEW replaces the existing definition.
+		p.views = slices.DeleteFunc(p.views, func(v View) bool { return strings.EqualFold(v.Name, name) })
+	}
+
+	var explicitColumns []string
+
+	if c.Peek().IsPunct("(") {
+		explicitColumns, err = p.columnList(c)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.Expect("AS"); err != nil {
+		return &sqlparse.SyntaxError{Pos: namePos, Message: "view " + name + ": " + err.Error()}
+	}
+
+	query := c.Rest()
+	view := View{
+		Name:       name,
+		Definition: c.Text(query),
+		Columns:    p.viewColumns(query),
+	}
+
+	for i, col := range explicitColumns {
+		if i < len(view.Columns) {
+			view.Columns[i].Name = col
+		} else {
+			view.Columns = append(view.Columns, Column{Name: col, Type: ColumnTypeString, Nullable: true})
+		}
+	}
+
+	p.views = append(p.views, view)
+
+	return nil
+}
+
+func (p *Parser) view(name string) *View {
+	for i := range p.views {
+		if strings.EqualFold(p.views[i].Name, name) {
+			return &p.views[i]
+		}
+	}
+
+	return nil
+}
+
+func (p *Parser) parseDrop(c *sqlparse.Cursor) error {
+	var kind string
+
+	switch {
+	case c.Accept("TABLE"):
+		kind = "TABLE"
+	case c.Accept("MATERIALIZED", "VIEW"), c.Accept("VIEW"):
+		kind = "VIEW"
+	case c.Accept("INDEX"):
+		kind = "INDEX"
+
+		_ = c.Accept("CONCURRENTLY")
+	case c.Accept("TYPE"):
+		kind = "TYPE"
+	default:
+		return nil
+	}
+
+	ifExists := c.Accept("IF", "EXISTS")
+
+	for !c.Done() {
+		pos := c.Peek().Pos
+
+		name, err := p.objectName(c)
+		if err != nil {
+			return err
+		}
+
+		if !p.dropObject(kind, name) && !ifExists {
+			return &sqlparse.SyntaxError{
+				Pos:     pos,
+				Message: fmt.Sprintf("%s %q does not exist", strings.ToLower(kind), name),
+			}
+		}
+
+		if !c.AcceptPunct(",") {
+			break
+		}
+	}
+
+	return nil
+}
+
+// dropObject removes a named object and reports whether it existed.
+func (p *Parser) dropObject(kind, name string) bool {
+	matches := func(n string) bool { return strings.EqualFold(n, name) }
+
+	switch kind {
+	case "TABLE":
+		before := len(p.tables)
+		p.tables = slices.DeleteFunc(p.tables, func(t Table) bool { return matches(t.Name) })
+		p.constraints = slices.DeleteFunc(p.constraints, func(c Constraint) bool { return matches(c.Table) })
+
+		return len(p.tables) != before
+	case "VIEW":
+		before := len(p.views)
+		p.views = slices.DeleteFunc(p.views, func(v View) bool { return matches(v.Name) })
+
+		return len(p.views) != before
+	case "INDEX":
+		for i := range p.tables {
+			before := len(p.tables[i].Indexes)
+			p.tables[i].Indexes = slices.DeleteFunc(p.tables[i].Indexes, func(idx Index) bool {
+				return matches(idx.Name)
+			})
+
+			if len(p.tables[i].Indexes) != before {
+				return true
+			}
+		}
+
+		return false
+	case "TYPE":
+		before := len(p.enums)
+		p.enums = slices.DeleteFunc(p.enums, func(e Enum) bool { return matches(e.Name) })
+
+		return len(p.enums) != before
+	default:
+		return false
+	}
+}
+
+func checkConstraint(name, table string, columns []string, condition string) Constraint {
+	return Constraint{
+		Name:       name,
+		Type:       ConstraintTypeCheck,
+		Table:      table,
+		Columns:    columns,
+		Definition: "CHECK (" + condition + ")",
+		Check:      &CheckConstraint{Condition: condition},
+	}
+}
+
+func defaultName(name, fallback string) string {
+	if name != "" {
+		return name
+	}
+
+	return fallback
+}
+
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, value) })
+}
@@ -0,0 +1,409 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+)
+
+// parseAlterTable applies the comma-separated actions of an ALTER TABLE statement.
+func (p *Parser) parseAlterTable(c *sqlparse.Cursor) error {
+	ifExists := c.Accept("IF", "EXISTS")
+	_ = c.Accept("ONLY")
+
+	name, err := p.objectName(c)
+	if err != nil {
+		return err
+	}
+
+	table := p.table(name)
+	if table == nil {
+		if ifExists {
+			return nil
+		}
+
+		return &sqlparse.SyntaxError{
+			Pos:     c.Peek().Pos,
+			Message: fmt.Sprintf("relation %q does not exist", name),
+		}
+	}
+
+	// RENAME TO changes the table name and is not combined with other actions.
+	if c.Accept("RENAME", "TO") {
+		return p.renameTable(c, table)
+	}
+
+	for _, action := range sqlparse.SplitTopLevel(c.Rest()) {
+		if len(action) == 0 {
+			continue
+		}
+
+		// Actions may rename the table, so look it up again each time.
+		table = p.table(name)
+		if err := p.alterTableAction(sqlparse.NewCursor(p.src, action), table); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// alterTableAction applies a single ALTER TABLE action.
+func (p *Parser) alterTableAction(c *sqlparse.Cursor, table *Table) error {
+	switch {
+	case c.Accept("ADD"):
+		if c.Peek().Kind == sqlparse.TokenWord && tableConstraintKeywords[c.Peek().Upper()] {
+			return p.parseTableConstraint(c, table)
+		}
+
+		_ = c.Accept("COLUMN")
+
+		if c.Accept("IF", "NOT", "EXISTS") && p.column(table, c.Peek().Value) != nil {
+			return nil
+		}
+
+		return p.addColumn(c, table)
+	case c.Accept("DROP", "CONSTRAINT"):
+		ifExists := c.Accept("IF", "EXISTS")
+		pos := c.Peek().Pos
+
+		name, err := c.Ident()
+		if err != nil {
+			return err
+		}
+
+		if !p.dropConstraint(table, name) && !ifExists {
+			return &sqlparse.SyntaxError{
+				Pos:     pos,
+				Message: fmt.Sprintf("constraint %q of relation %q does not exist", name, table.Name),
+			}
+		}
+	case c.Accept("DROP", "PRIMARY", "KEY"):
+		p.dropPrimaryKey(table)
+	case c.Accept("DROP", "FOREIGN", "KEY"), c.Accept("DROP", "INDEX"), c.Accept("DROP", "KEY"):
+		name, err := c.Ident()
+		if err != nil {
+			return err
+		}
+
+		p.dropConstraint(table, name)
+	case c.Accept("DROP"):
+		_ = c.Accept("COLUMN")
+		ifExists := c.Accept("IF", "EXISTS")
+		pos := c.Peek().Pos
+		name := p.identValue(c.Next())
+
+		if !p.dropColumn(table, name) && !ifExists {
+			return &sqlparse.SyntaxError{
+				Pos:     pos,
+				Message: fmt.Sprintf("column %q of relation %q does not exist", name, table.Name),
+			}
+		}
+	case c.Accept("ALTER"):
+		_ = c.Accept("COLUMN")
+
+		return p.alterColumn(c, table)
+	case c.Accept("RENAME", "COLUMN"):
+		return p.renameColumn(c, table)
+	case c.Accept("RENAME", "TO"):
+		return p.renameTable(c, table)
+	case c.Accept("RENAME"):
+		if c.Peek().Is("CONSTRAINT") || c.Peek().Is("INDEX") || c.Peek().Is("KEY") {
+			return nil
+		}
+
+		return p.renameColumn(c, table)
+	case c.Accept("MODIFY"):
+		_ = c.Accept("COLUMN")
+
+		return p.replaceColumn(c, table, c.Peek().Value)
+	case c.Accept("CHANGE"):
+		_ = c.Accept("COLUMN")
+		old := p.identValue(c.Next())
+
+		return p.replaceColumn(c, table, old)
+	}
+
+	// Ownership, storage, trigger and partition actions do not affect the model.
+	return nil
+}
+
+// column returns the named column of table, or nil.
+func (p *Parser) column(table *Table, name string) *Column {
+	for i := range table.Columns {
+		if strings.EqualFold(table.Columns[i].Name, name) {
+			return &table.Columns[i]
+		}
+	}
+
+	return nil
+}
+
+// requireColumn returns the named column or a positioned error.
+func (p *Parser) requireColumn(c *sqlparse.Cursor, table *Table, name string) (*Column, error) {
+	column := p.column(table, name)
+	if column == nil {
+		return nil, &sqlparse.SyntaxError{
+			Pos:     c.Peek().Pos,
+			Message: fmt.Sprintf("column %q of relation %q does not exist", name, table.Name),
+		}
+	}
+
+	return column, nil
+}
+
+// alterColumn handles ALTER COLUMN ... SET/DROP NOT NULL, SET/DROP DEFAULT and TYPE.
+func (p *Parser) alterColumn(c *sqlparse.Cursor, table *Table) error {
+	name := p.identValue(c.Peek())
+
+	column, err := p.requireColumn(c, table, name)
+	if err != nil {
+		return err
+	}
+
+	c.Next()
+
+	switch {
+	case c.Accept("SET", "NOT", "NULL"):
+		column.Nullable = false
+	case c.Accept("DROP", "NOT", "NULL"):
+		column.Nullable = true
+	case c.Accept("SET", "DEFAULT"):
+		expr := p.readExpression(c)
+		column.Default = &expr
+	case c.Accept("DROP", "DEFAULT"):
+		column.Default = nil
+	case c.Accept("SET", "DATA", "TYPE"), c.Accept("TYPE"):
+		column.SQLType = p.readColumnType(c)
+		column.Type = ColumnTypeFromSQL(column.SQLType)
+	}
+
+	return nil
+}
+
+// replaceColumn handles MySQL MODIFY/CHANGE, which restate the full column definition.
+func (p *Parser) replaceColumn(c *sqlparse.Cursor, table *Table, old string) error {
+	if _, err := p.requireColumn(c, table, old); err != nil {
+		return err
+	}
+
+	column, fk, checks, err := p.parseColumnDef(c, table.Name)
+	if err != nil {
+		return err
+	}
+
+	i := slices.IndexFunc(table.Columns, func(col Column) bool { return strings.EqualFold(col.Name, old) })
+	// MODIFY does not restate the primary key; keep it unless the definition says otherwise.
+	column.PrimaryKey = column.PrimaryKey || table.Columns[i].PrimaryKey
+	table.Columns[i] = column
+	p.renameColumnReferences(table, old, column.Name)
+
+	if fk != nil {
+		table.ForeignKeys = append(table.ForeignKeys, *fk)
+	}
+
+	p.constraints = append(p.constraints, checks...)
+
+	return nil
+}
+
+func (p *Parser) renameColumn(c *sqlparse.Cursor, table *Table) error {
+	old := p.identValue(c.Peek())
+
+	column, err := p.requireColumn(c, table, old)
+	if err != nil {
+		return err
+	}
+
+	c.Next()
+
+	if err := c.Expect("TO"); err != nil {
+		return err
+	}
+
+	newName := c.Peek()
+	if !newName.IsIdent() {
+		return c.Errorf("expected column name")
+	}
+
+	c.Next()
+
+	column.Name = p.identValue(newName)
+	p.renameColumnReferences(table, old, column.Name)
+
+	return nil
+}
+
+// renameColumnReferences updates keys, indexes and constraints after a column rename.
+func (p *Parser) renameColumnReferences(table *Table, old, newName string) {
+	rename := func(columns []string) {
+		for i := range columns {
+			if strings.EqualFold(columns[i], old) {
+				columns[i] = newName
+			}
+		}
+	}
+
+	if table.PrimaryKey != nil {
+		rename(table.PrimaryKey.Columns)
+	}
+
+	for i := range table.Indexes {
+		rename(table.Indexes[i].Columns)
+	}
+
+	for i := range table.ForeignKeys {
+		if strings.EqualFold(table.ForeignKeys[i].SourceColumn, old) {
+			table.ForeignKeys[i].SourceColumn = newName
+		}
+	}
+
+	for i := range p.tables {
+		for j := range p.tables[i].ForeignKeys {
+			fk := &p.tables[i].ForeignKeys[j]
+			if strings.EqualFold(fk.TargetTable, table.Name) && strings.EqualFold(fk.TargetColumn, old) {
+				fk.TargetColumn = newName
+			}
+		}
+	}
+
+	for i := range p.constraints {
+		if strings.EqualFold(p.constraints[i].Table, table.Name) {
+			rename(p.constraints[i].Columns)
+		}
+	}
+}
+
+func (p *Parser) renameTable(c *sqlparse.Cursor, table *Table) error {
+	newName, err := p.objectName(c)
+	if err != nil {
+		return err
+	}
+
+	old := table.Name
+	table.Name = newName
+
+	if table.PrimaryKey != nil {
+		table.PrimaryKey.Table = newName
+	}
+
+	for i := range table.Indexes {
+		table.Indexes[i].Table = newName
+	}
+
+	for i := range p.tables {
+		for j := range p.tables[i].ForeignKeys {
+			fk := &p.tables[i].ForeignKeys[j]
+			if strings.EqualFold(fk.SourceTable, old) {
+				fk.SourceTable = newName
+			}
+
+			if strings.EqualFold(fk.TargetTable, old) {
+				fk.TargetTable = newName
+			}
+		}
+	}
+
+	for i := range p.constraints {
+		if strings.EqualFold(p.constraints[i].Table, old) {
+			p.constraints[i].Table = newName
+		}
+	}
+
+	return nil
+}
+
+// dropColumn removes a column and everything that only referenced it.
+func (p *Parser) dropColumn(table *Table, name string) bool {
+	before := len(table.Columns)
+	table.Columns = slices.DeleteFunc(table.Columns, func(col Column) bool {
+		return strings.EqualFold(col.Name, name)
+	})
+
+	if len(table.Columns) == before {
+		return false
+	}
+
+	if table.PrimaryKey != nil && containsFold(table.PrimaryKey.Columns, name) {
+		table.PrimaryKey = nil
+	}
+
+	table.Indexes = slices.DeleteFunc(table.Indexes, func(idx Index) bool {
+		return containsFold(idx.Columns, name)
+	})
+	// A composite foreign key is one row per column under one name: drop every leg.
+	var droppedKeys []string
+
+	for _, fk := range table.ForeignKeys {
+		if strings.EqualFold(fk.SourceColumn, name) {
+			droppedKeys = append(droppedKeys, fk.Name)
+		}
+	}
+
+	table.ForeignKeys = slices.DeleteFunc(table.ForeignKeys, func(fk ForeignKey) bool {
+		return strings.EqualFold(fk.SourceColumn, name) || fk.Name != "" && containsFold(droppedKeys, fk.Name)
+	})
+	p.constraints = slices.DeleteFunc(p.constraints, func(con Constraint) bool {
+		return strings.EqualFold(con.Table, table.Name) && containsFold(con.Columns, name)
+	})
+
+	return true
+}
+
+// dropConstraint removes a named constraint, foreign key or index from table.
+func (p *Parser) dropConstraint(table *Table, name string) bool {
+	found := false
+
+	if table.PrimaryKey != nil && strings.EqualFold(table.PrimaryKey.Name, name) {
+		p.dropPrimaryKey(table)
+
+		found = true
+	}
+
+	before := len(table.ForeignKeys) + len(table.Indexes) + len(p.constraints)
+
+	table.ForeignKeys = slices.DeleteFunc(table.ForeignKeys, func(fk ForeignKey) bool {
+		return strings.EqualFold(fk.Name, name)
+	})
+	table.Indexes = slices.DeleteFunc(table.Indexes, func(idx Index) bool {
+		return strings.EqualFold(idx.Name, name)
+	})
+
+	var dropped []Constraint
+
+	p.constraints = slices.DeleteFunc(p.constraints, func(con Constraint) bool {
+		if strings.EqualFold(con.Table, table.Name) && strings.EqualFold(con.Name, name) {
+			dropped = append(dropped, con)
+
+			return true
+		}
+
+		return false
+	})
+
+	for _, con := range dropped {
+		if con.Type == ConstraintTypeUnique && len(con.Columns) == 1 {
+			if column := p.column(table, con.Columns[0]); column != nil {
+				column.Unique = false
+			}
+		}
+	}
+
+	return found || len(table.ForeignKeys)+len(table.Indexes)+len(p.constraints) != before
+}
+
+func (p *Parser) dropPrimaryKey(table *Table) {
+	if table.PrimaryKey == nil {
+		return
+	}
+
+	for i := range table.Columns {
+		if containsFold(table.PrimaryKey.Columns, table.Columns[i].Name) {
+			table.Columns[i].PrimaryKey = false
+		}
+	}
+
+	table.PrimaryKey = nil
+}
@@ -0,0 +1,426 @@
+package schema
+
+import (
+	"slices"
+	"strings"
+)
+
+// SchemaDiff describes the changes that turn one schema into another.
+// Objects are matched by name; a renamed object shows up as dropped and added.
+// Changed views, indexes, foreign keys and constraints are listed as dropped and
+// added, since most engines cannot alter them in place.
+type SchemaDiff struct {
+	From *Schema
+	To   *Schema
+
+	AddedTables   []Table
+	DroppedTables []Table
+	AlteredTables []TableDiff
+
+	AddedEnums   []Enum
+	DroppedEnums []Enum
+	AlteredEnums []EnumDiff
+
+	AddedViews   []View
+	DroppedViews []View
+}
+
+// TableDiff describes the changes to a table present in both schemas.
+type TableDiff struct {
+	From *Table
+	To   *Table
+
+	AddedColumns   []Column
+	DroppedColumns []Column
+	AlteredColumns []ColumnDiff
+
+	// PrimaryKeyChanged is set when the primary key was added, dropped or changed.
+	PrimaryKeyChanged bool
+
+	AddedIndexes   []Index
+	DroppedIndexes []Index
+
+	AddedForeignKeys   []ForeignKeyConstraint
+	DroppedForeignKeys []ForeignKeyConstraint
+
+	// AddedConstraints and DroppedConstraints hold unique and check constraints.
+	AddedConstraints   []Constraint
+	DroppedConstraints []Constraint
+}
+
+// ColumnDiff holds both versions of a column whose definition changed.
+type ColumnDiff struct {
+	From Column
+	To   Column
+}
+
+// TypeChanged reports whether the declared type changed.
+func (d ColumnDiff) TypeChanged() bool {
+	return !strings.EqualFold(columnSQLType(d.From), columnSQLType(d.To))
+}
+
+// NullableChanged reports whether NOT NULL was added or removed.
+func (d ColumnDiff) NullableChanged() bool {
+	return d.From.Nullable != d.To.Nullable
+}
+
+// DefaultChanged reports whether the default expression changed.
+func (d ColumnDiff) DefaultChanged() bool {
+	return defaultValue(d.From) != defaultValue(d.To)
+}
+
+// EnumDiff holds both versions of an enum whose values changed.
+type EnumDiff struct {
+	From Enum
+	To   Enum
+}
+
+// AddedValues returns the values of To that From lacks.
+func (d EnumDiff) AddedValues() []string {
+	return missingValues(d.To.Values, d.From.Values)
+}
+
+// DroppedValues returns the values of From that To lacks.
+func (d EnumDiff) DroppedValues() []string {
+	return missingValues(d.From.Values, d.To.Values)
+}
+
+// OnlyAppends reports whether To is From with values added at the end,
+// which engines can apply without rewriting the type.
+func (d EnumDiff) OnlyAppends() bool {
+	return len(d.To.Values) >= len(d.From.Values) && slices.Equal(d.From.Values, d.To.Values[:len(d.From.Values)])
+}
+
+// ForeignKeyConstraint is a foreign key with all its column pairs. The Table model
+// stores one ForeignKey per column pair; multi-column keys share a name.
+type ForeignKeyConstraint struct {
+	Name          string
+	Table         string
+	Columns       []string
+	TargetTable   string
+	TargetColumns []string
+	OnDelete      string
+	OnUpdate      string
+}
+
+// ForeignKeyConstraints groups the per-column foreign keys of a table by constraint name.
+func (t *Table) ForeignKeyConstraints() []ForeignKeyConstraint {
+	var constraints []ForeignKeyConstraint
+
+	for _, fk := range t.ForeignKeys {
+		i := slices.IndexFunc(constraints, func(c ForeignKeyConstraint) bool {
+			return fk.Name != "" && strings.EqualFold(c.Name, fk.Name)
+		})
+		if i < 0 {
+			constraints = append(constraints, ForeignKeyConstraint{
+				Name:        fk.Name,
+				Table:       t.Name,
+				TargetTable: fk.TargetTable,
+				OnDelete:    fk.OnDeleteAction,
+				OnUpdate:    fk.OnUpdateAction,
+			})
+			i = len(constraints) - 1
+		}
+
+		constraints[i].Columns = append(constraints[i].Columns, fk.SourceColumn)
+		constraints[i].TargetColumns = append(constraints[i].TargetColumns, fk.TargetColumn)
+	}
+
+	return constraints
+}
+
+// IsEmpty reports whether the schemas are equivalent.
+func (d *SchemaDiff) IsEmpty() bool {
+	return len(d.AddedTables) == 0 && len(d.DroppedTables) == 0 && len(d.AlteredTables) == 0 &&
+		len(d.AddedEnums) == 0 && len(d.DroppedEnums) == 0 && len(d.AlteredEnums) == 0 &&
+		len(d.AddedViews) == 0 && len(d.DroppedViews) == 0
+}
+
+// Diff compares two schemas. A nil schema is treated as empty, so Diff(nil, s)
+// describes creating s from scratch.
+func Diff(from, to *Schema) *SchemaDiff {
+	if from == nil {
+		from = &Schema{}
+	}
+
+	if to == nil {
+		to = &Schema{}
+	}
+
+	diff := &SchemaDiff{From: from, To: to}
+
+	for i := range to.Tables {
+		table := &to.Tables[i]
+
+		old := findTable(from, table.Name)
+		if old == nil {
+			diff.AddedTables = append(diff.AddedTables, *table)
+
+			continue
+		}
+
+		if tableDiff := diffTable(from, to, old, table); !tableDiff.isEmpty() {
+			diff.AlteredTables = append(diff.AlteredTables, tableDiff)
+		}
+	}
+
+	for _, table := range from.Tables {
+		if findTable(to, table.Name) == nil {
+			diff.DroppedTables = append(diff.DroppedTables, table)
+		}
+	}
+
+	for _, enum := range to.Enums {
+		old := findByName(from.Enums, enum.Name, func(e Enum) string { return e.Name })
+
+		switch {
+		case old == nil:
+			diff.AddedEnums = append(diff.AddedEnums, enum)
+		case !slices.Equal(old.Values, enum.Values):
+			diff.AlteredEnums = append(diff.AlteredEnums, EnumDiff{From: *old, To: enum})
+		}
+	}
+
+	for _, enum := range from.Enums {
+		if findByName(to.Enums, enum.Name, func(e Enum) string { return e.Name }) == nil {
+			diff.DroppedEnums = append(diff.DroppedEnums, enum)
+		}
+	}
+
+	for _, view := range to.Views {
+		old := findByName(from.Views, view.Name, func(v View) string { return v.Name })
+		if old != nil && normalizeSQL(old.Definition) == normalizeSQL(view.Definition) {
+			continue
+		}
+
+		if old != nil {
+			diff.DroppedViews = append(diff.DroppedViews, *old)
+		}
+
+		diff.AddedViews = append(diff.AddedViews, view)
+	}
+
+	for _, view := range from.Views {
+		if findByName(to.Views, view.Name, func(v View) string { return v.Name }) == nil {
+			diff.DroppedViews = append(diff.DroppedViews, view)
+		}
+	}
+
+	return diff
+}
+
+func diffTable(fromSchema, toSchema *Schema, from, to *Table) TableDiff {
+	diff := TableDiff{From: from, To: to}
+
+	for _, column := range to.Columns {
+		old := findByName(from.Columns, column.Name, func(c Column) string { return c.Name })
+
+		switch {
+		case old == nil:
+			diff.AddedColumns = append(diff.AddedColumns, column)
+		case !columnsEqual(*old, column):
+			diff.AlteredColumns = append(diff.AlteredColumns, ColumnDiff{From: *old, To: column})
+		}
+	}
+
+	for _, column := range from.Columns {
+		if findByName(to.Columns, column.Name, func(c Column) string { return c.Name }) == nil {
+			diff.DroppedColumns = append(diff.DroppedColumns, column)
+		}
+	}
+
+	diff.PrimaryKeyChanged = !primaryKeysEqual(from.PrimaryKey, to.PrimaryKey)
+
+	diff.AddedIndexes, diff.DroppedIndexes = diffNamed(from.Indexes, to.Indexes,
+		func(i Index) string { return i.Name }, indexesEqual)
+
+	diff.AddedForeignKeys, diff.DroppedForeignKeys = diffNamed(from.ForeignKeyConstraints(), to.ForeignKeyConstraints(),
+		func(fk ForeignKeyConstraint) string { return fk.Name }, foreignKeysEqual)
+
+	diff.AddedConstraints, diff.DroppedConstraints = diffNamed(
+		fromSchema.tableConstraints(from), toSchema.tableConstraints(to),
+		func(c Constraint) string { return c.Name }, constraintsEqual)
+
+	return diff
+}
+
+func (d *TableDiff) isEmpty() bool {
+	return len(d.AddedColumns) == 0 && len(d.DroppedColumns) == 0 && len(d.AlteredColumns) == 0 &&
+		!d.PrimaryKeyChanged &&
+		len(d.AddedIndexes) == 0 && len(d.DroppedIndexes) == 0 &&
+		len(d.AddedForeignKeys) == 0 && len(d.DroppedForeignKeys) == 0 &&
+		len(d.AddedConstraints) == 0 && len(d.DroppedConstraints) == 0
+}
+
+// tableConstraints returns the unique and check constraints of a table. Columns
+// flagged Unique without a matching constraint get one named like PostgreSQL would.
+func (s *Schema) tableConstraints(table *Table) []Constraint {
+	var constraints []Constraint
+
+	for _, c := range s.Constraints {
+		if strings.EqualFold(c.Table, table.Name) && (c.Type == ConstraintTypeUnique || c.Type == ConstraintTypeCheck) {
+			constraints = append(constraints, c)
+		}
+	}
+
+	for _, column := range table.Columns {
+		if !column.Unique || column.PrimaryKey {
+			continue
+		}
+
+		covered := slices.ContainsFunc(constraints, func(c Constraint) bool {
+			return c.Type == ConstraintTypeUnique && len(c.Columns) == 1 && strings.EqualFold(c.Columns[0], column.Name)
+		})
+		if !covered {
+			constraints = append(constraints, Constraint{
+				Name:    table.Name + "_" + column.Name + "_key",
+				Type:    ConstraintTypeUnique,
+				Table:   table.Name,
+				Columns: []string{column.Name},
+			})
+		}
+	}
+
+	return constraints
+}
+
+// diffNamed matches items by name and returns the added and dropped ones;
+// items whose definition changed are in both lists.
+func diffNamed[T any](from, to []T, name func(T) string, equal func(a, b T) bool) (added, dropped []T) {
+	for _, item := range to {
+		old := findByName(from, name(item), name)
+		if old == nil || !equal(*old, item) {
+			added = append(added, item)
+		}
+
+		if old != nil && !equal(*old, item) {
+			dropped = append(dropped, *old)
+		}
+	}
+
+	for _, item := range from {
+		if findByName(to, name(item), name) == nil {
+			dropped = append(dropped, item)
+		}
+	}
+
+	return added, dropped
+}
+
+func findByName[T any](items []T, name string, nameOf func(T) string) *T {
+	for i := range items {
+		if strings.EqualFold(nameOf(items[i]), name) {
+			return &items[i]
+		}
+	}
+
+	return nil
+}
+
+func findTable(s *Schema, name string) *Table {
+	return findByName(s.Tables, name, func(t Table) string { return t.Name })
+}
+
+func columnsEqual(a, b Column) bool {
+	return !ColumnDiff{From: a, To: b}.TypeChanged() &&
+		a.Nullable == b.Nullable &&
+		defaultValue(a) == defaultValue(b) &&
+		a.AutoIncrement == b.AutoIncrement &&
+		a.SQLiteAutoincrement == b.SQLiteAutoincrement
+}
+
+func primaryKeysEqual(a, b *Index) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return equalFold(a.Columns, b.Columns)
+}
+
+func indexesEqual(a, b Index) bool {
+	return equalFold(a.Columns, b.Columns) && a.Unique == b.Unique &&
+		indexType(a) == indexType(b) && normalizeSQL(a.Where) == normalizeSQL(b.Where)
+}
+
+func foreignKeysEqual(a, b ForeignKeyConstraint) bool {
+	return equalFold(a.Columns, b.Columns) && strings.EqualFold(a.TargetTable, b.TargetTable) &&
+		equalFold(a.TargetColumns, b.TargetColumns) &&
+		referentialAction(a.OnDelete) == referentialAction(b.OnDelete) &&
+		referentialAction(a.OnUpdate) == referentialAction(b.OnUpdate)
+}
+
+func constraintsEqual(a, b Constraint) bool {
+	if a.Type != b.Type {
+		return false
+	}
+
+	if a.Type == ConstraintTypeCheck {
+		return normalizeSQL(checkCondition(a)) == normalizeSQL(checkCondition(b))
+	}
+
+	return equalFold(a.Columns, b.Columns)
+}
+
+func missingValues(values, other []string) []string {
+	var missing []string
+
+	for _, value := range values {
+		if !slices.Contains(other, value) {
+			missing = append(missing, value)
+		}
+	}
+
+	return missing
+}
+
+func equalFold(a, b []string) bool {
+	return slices.EqualFunc(a, b, strings.EqualFold)
+}
+
+// indexType returns the index method, treating an empty type as the default B-tree.
+func indexType(index Index) IndexType {
+	if index.Type == "" {
+		return IndexTypeBTree
+	}
+
+	return index.Type
+}
+
+// referentialAction returns an ON DELETE/UPDATE action, treating empty as NO ACTION.
+func referentialAction(action string) string {
+	if action == "" {
+		return "NO ACTION"
+	}
+
+	return strings.ToUpper(action)
+}
+
+// columnSQLType returns the declared type, or the engine-neutral type when none was declared.
+func columnSQLType(column Column) string {
+	if column.SQLType != "" {
+		return column.SQLType
+	}
+
+	return string(column.Type)
+}
+
+func defaultValue(column Column) string {
+	if column.Default == nil {
+		return ""
+	}
+
+	return normalizeSQL(*column.Default)
+}
+
+func checkCondition(c Constraint) string {
+	if c.Check != nil {
+		return c.Check.Condition
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(c.Definition, "CHECK ("), ")")
+}
+
+// normalizeSQL collapses whitespace so formatting changes are not reported.
+func normalizeSQL(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
@@ -0,0 +1,739 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+)
+
+// rebuildSuffix names the temporary table used to rebuild SQLite tables.
+const rebuildSuffix = "_new"
+
+// plainIdentifier matches names that never need quoting.
+var plainIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// reservedWords are common column and table names that must be quoted in DDL.
+var reservedWords = map[string]bool{
+	"all": true, "check": true, "column": true, "constraint": true, "default": true,
+	"desc": true, "from": true, "group": true, "index": true, "key": true, "limit": true,
+	"order": true, "primary": true, "references": true, "select": true, "table": true,
+	"to": true, "unique": true, "user": true, "where": true,
+}
+
+// MigrationScript holds the statements that apply a schema change and revert it.
+type MigrationScript struct {
+	Up   []string
+	Down []string
+}
+
+// IsEmpty reports whether there is nothing to migrate.
+func (m *MigrationScript) IsEmpty() bool {
+	return len(m.Up) == 0 && len(m.Down) == 0
+}
+
+// UpSQL renders the up statements as a migration file body.
+func (m *MigrationScript) UpSQL() string {
+	return joinStatements(m.Up)
+}
+
+// DownSQL renders the down statements as a migration file body.
+func (m *MigrationScript) DownSQL() string {
+	return joinStatements(m.Down)
+}
+
+func joinStatements(statements []string) string {
+	if len(statements) == 0 {
+		return ""
+	}
+
+	return strings.Join(statements, ";\n\n") + ";\n"
+}
+
+// GenerateMigration diffs two schemas and renders the statements that migrate a
+// database from one to the other (up) and back (down) for the given sqlc engine.
+func GenerateMigration(from, to *Schema, engine string) (*MigrationScript, error) {
+	up, err := Diff(from, to).Statements(engine)
+	if err != nil {
+		return nil, err
+	}
+
+	down, err := Diff(to, from).Statements(engine)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MigrationScript{Up: up, Down: down}, nil
+}
+
+// Statements renders the diff as DDL for engine, ordered so that every statement
+// only depends on objects that exist at that point. SQLite cannot alter columns or
+// constraints in place, so such tables are rebuilt by copying them into a new table.
+func (d *SchemaDiff) Statements(engine string) ([]string, error) {
+	if !slices.Contains([]string{EnginePostgreSQL, EngineMySQL, EngineSQLite}, engine) {
+		return nil, apperrors.Newf(
+			apperrors.ErrorCodeDatabaseNotSupported,
+			"unsupported schema engine %q (must be one of: postgresql, mysql, sqlite)",
+			engine,
+		)
+	}
+
+	w := &ddlWriter{engine: engine, diff: d}
+
+	w.createEnums()
+	w.dropViews()
+	w.dropForeignKeys()
+	w.dropIndexes()
+	w.dropTables()
+	w.alterTables()
+	w.createTables()
+	w.createIndexes()
+	w.addForeignKeys()
+	w.dropEnums()
+	w.createViews()
+
+	return w.statements, nil
+}
+
+// ddlWriter renders a SchemaDiff for one engine.
+type ddlWriter struct {
+	engine     string
+	diff       *SchemaDiff
+	statements []string
+
+	// deferred holds foreign keys of new tables that reference tables created later.
+	deferred []ForeignKeyConstraint
+	// rebuilt lists the SQLite tables recreated by alterTables.
+	rebuilt []string
+}
+
+func (w *ddlWriter) add(format string, args ...any) {
+	w.statements = append(w.statements, fmt.Sprintf(format, args...))
+}
+
+func (w *ddlWriter) isPostgres() bool { return w.engine == EnginePostgreSQL }
+func (w *ddlWriter) isMySQL() bool    { return w.engine == EngineMySQL }
+func (w *ddlWriter) isSQLite() bool   { return w.engine == EngineSQLite }
+
+// createEnums creates and alters PostgreSQL enum types. MySQL and SQLite have no
+// standalone enum types; their enum columns change through column definitions.
+func (w *ddlWriter) createEnums() {
+	if !w.isPostgres() {
+		return
+	}
+
+	for _, enum := range w.diff.AddedEnums {
+		w.add("CREATE TYPE %s AS ENUM (%s)", w.ident(enum.Name), quoteLiterals(enum.Values))
+	}
+
+	for _, change := range w.diff.AlteredEnums {
+		if change.OnlyAppends() {
+			for _, value := range change.AddedValues() {
+				w.add("ALTER TYPE %s ADD VALUE %s", w.ident(change.To.Name), quoteLiteral(value))
+			}
+
+			continue
+		}
+
+		// Values cannot be removed or reordered: swap in a new type and convert the columns.
+		old := change.To.Name + "_old"
+		w.add("ALTER TYPE %s RENAME TO %s", w.ident(change.From.Name), w.ident(old))
+		w.add("CREATE TYPE %s AS ENUM (%s)", w.ident(change.To.Name), quoteLiterals(change.To.Values))
+
+		for _, table := range w.diff.To.Tables {
+			if findTable(w.diff.From, table.Name) == nil {
+				continue
+			}
+
+			for _, column := range table.Columns {
+				if strings.EqualFold(column.SQLType, change.To.Name) {
+					w.convertEnumColumn(table.Name, column, change.To.Name)
+				}
+			}
+		}
+
+		w.add("DROP TYPE %s", w.ident(old))
+	}
+}
+
+func (w *ddlWriter) convertEnumColumn(table string, column Column, enum string) {
+	prefix := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", w.ident(table), w.ident(column.Name))
+
+	if column.Default != nil {
+		w.add("%s DROP DEFAULT", prefix)
+	}
+
+	w.add("%s TYPE %s USING %s::text::%s", prefix, w.ident(enum), w.ident(column.Name), w.ident(enum))
+
+	if column.Default != nil {
+		w.add("%s SET DEFAULT %s", prefix, *column.Default)
+	}
+}
+
+func (w *ddlWriter) dropEnums() {
+	if !w.isPostgres() {
+		return
+	}
+
+	for _, enum := range w.diff.DroppedEnums {
+		w.add("DROP TYPE %s", w.ident(enum.Name))
+	}
+}
+
+func (w *ddlWriter) dropViews() {
+	for _, view := range slices.Backward(w.diff.DroppedViews) {
+		w.add("DROP VIEW %s", w.ident(view.Name))
+	}
+}
+
+func (w *ddlWriter) createViews() {
+	for _, view := range w.diff.AddedViews {
+		w.add("CREATE VIEW %s AS %s", w.ident(view.Name), strings.TrimSpace(view.Definition))
+	}
+}
+
+// dropForeignKeys removes changed and dropped foreign keys of altered tables before
+// the tables they reference are dropped. SQLite drops them by rebuilding the table.
+func (w *ddlWriter) dropForeignKeys() {
+	if w.isSQLite() {
+		return
+	}
+
+	for _, table := range w.diff.AlteredTables {
+		for _, fk := range table.DroppedForeignKeys {
+			w.dropForeignKey(fk)
+		}
+	}
+
+	// Foreign keys between dropped tables that form a cycle.
+	_, cyclic := orderTables(w.diff.DroppedTables, nil)
+	for _, fk := range cyclic {
+		w.dropForeignKey(fk)
+	}
+}
+
+func (w *ddlWriter) dropForeignKey(fk ForeignKeyConstraint) {
+	if w.isMySQL() {
+		w.add("ALTER TABLE %s DROP FOREIGN KEY %s", w.ident(fk.Table), w.ident(fk.Name))
+
+		return
+	}
+
+	w.add("ALTER TABLE %s DROP CONSTRAINT %s", w.ident(fk.Table), w.ident(fk.Name))
+}
+
+func (w *ddlWriter) dropIndexes() {
+	for _, table := range w.diff.AlteredTables {
+		if w.needsRebuild(&table) {
+			continue // the rebuild drops every index of the table
+		}
+
+		for _, index := range table.DroppedIndexes {
+			w.dropIndex(index)
+		}
+	}
+}
+
+func (w *ddlWriter) dropIndex(index Index) {
+	if w.isMySQL() {
+		w.add("DROP INDEX %s ON %s", w.ident(index.Name), w.ident(index.Table))
+
+		return
+	}
+
+	w.add("DROP INDEX %s", w.ident(index.Name))
+}
+
+// dropTables drops tables after the tables that reference them.
+func (w *ddlWriter) dropTables() {
+	ordered, _ := orderTables(w.diff.DroppedTables, nil)
+
+	for _, table := range slices.Backward(ordered) {
+		w.add("DROP TABLE %s", w.ident(table.Name))
+	}
+}
+
+// createTables creates new tables after the tables they reference. Foreign keys
+// that cannot be declared inline because of cycles are added once all tables exist.
+func (w *ddlWriter) createTables() {
+	existing := func(name string) bool {
+		return findTable(w.diff.From, name) != nil && findTable(w.diff.To, name) != nil
+	}
+
+	ordered, deferred := orderTables(w.diff.AddedTables, existing)
+	if w.isSQLite() {
+		// SQLite resolves foreign keys lazily, so they can always be declared inline.
+		deferred = nil
+	}
+
+	w.deferred = deferred
+
+	for _, table := range ordered {
+		w.add("%s", w.createTable(&table, table.Name, false))
+
+		for _, index := range table.Indexes {
+			w.createIndex(index)
+		}
+	}
+}
+
+// createTable renders CREATE TABLE for table under the given name. Constraint names
+// matching the engine default are omitted unless explicitNames is set, as when a
+// SQLite table is rebuilt under a temporary name.
+func (w *ddlWriter) createTable(table *Table, name string, explicitNames bool) string {
+	var lines []string
+
+	rowid := w.rowidColumn(table)
+
+	for _, column := range table.Columns {
+		def := w.columnDef(column)
+		if column.Name == rowid {
+			def += " PRIMARY KEY"
+
+			if column.SQLiteAutoincrement {
+				def += " AUTOINCREMENT"
+			}
+		}
+
+		lines = append(lines, def)
+	}
+
+	if table.PrimaryKey != nil && rowid == "" && len(table.PrimaryKey.Columns) > 0 {
+		lines = append(lines, w.primaryKeyDef(table, explicitNames))
+	}
+
+	for _, c := range w.diff.To.tableConstraints(table) {
+		lines = append(lines, w.constraintPrefix(c.Name, w.defaultConstraintName(table.Name, c), explicitNames)+w.constraintBody(c))
+	}
+
+	for _, fk := range table.ForeignKeyConstraints() {
+		if slices.ContainsFunc(w.deferred, func(d ForeignKeyConstraint) bool {
+			return strings.EqualFold(d.Table, fk.Table) && strings.EqualFold(d.Name, fk.Name)
+		}) {
+			continue
+		}
+
+		lines = append(lines, w.constraintPrefix(fk.Name, table.Name+"_"+strings.Join(fk.Columns, "_")+"_fkey", explicitNames)+w.foreignKeyBody(fk))
+	}
+
+	return fmt.Sprintf("CREATE TABLE %s (\n    %s\n)", w.ident(name), strings.Join(lines, ",\n    "))
+}
+
+// rowidColumn returns the column declared INTEGER PRIMARY KEY in SQLite, which must
+// stay inline to remain an alias of the rowid.
+func (w *ddlWriter) rowidColumn(table *Table) string {
+	if !w.isSQLite() || table.PrimaryKey == nil || len(table.PrimaryKey.Columns) != 1 {
+		return ""
+	}
+
+	for _, column := range table.Columns {
+		if strings.EqualFold(column.Name, table.PrimaryKey.Columns[0]) && strings.EqualFold(w.columnType(column), "INTEGER") {
+			return column.Name
+		}
+	}
+
+	return ""
+}
+
+func (w *ddlWriter) columnDef(column Column) string {
+	var b strings.Builder
+
+	b.WriteString(w.ident(column.Name) + " " + w.columnType(column))
+
+	if column.AutoIncrement && !isSerialType(column.SQLType) {
+		switch w.engine {
+		case EnginePostgreSQL:
+			b.WriteString(" GENERATED BY DEFAULT AS IDENTITY")
+		case EngineMySQL:
+			b.WriteString(" AUTO_INCREMENT")
+		}
+	}
+
+	if !column.Nullable {
+		b.WriteString(" NOT NULL")
+	}
+
+	if column.Default != nil {
+		b.WriteString(" DEFAULT " + *column.Default)
+	}
+
+	return b.String()
+}
+
+// columnType returns the declared type, or a type for the engine-neutral ColumnType.
+func (w *ddlWriter) columnType(column Column) string {
+	if column.SQLType != "" {
+		return column.SQLType
+	}
+
+	types := map[string]map[ColumnType]string{
+		EnginePostgreSQL: {
+			ColumnTypeString: "varchar(255)", ColumnTypeInteger: "integer", ColumnTypeBigInt: "bigint",
+			ColumnTypeFloat: "real", ColumnTypeDouble: "double precision", ColumnTypeBoolean: "boolean",
+			ColumnTypeDate: "date", ColumnTypeDateTime: "timestamp", ColumnTypeTimestamp: "timestamptz",
+			ColumnTypeJSON: "jsonb", ColumnTypeUUID: "uuid", ColumnTypeText: "text", ColumnTypeBlob: "bytea",
+		},
+		EngineMySQL: {
+			ColumnTypeString: "varchar(255)", ColumnTypeInteger: "int", ColumnTypeBigInt: "bigint",
+			ColumnTypeFloat: "float", ColumnTypeDouble: "double", ColumnTypeBoolean: "tinyint(1)",
+			ColumnTypeDate: "date", ColumnTypeDateTime: "datetime", ColumnTypeTimestamp: "timestamp",
+			ColumnTypeJSON: "json", ColumnTypeUUID: "char(36)", ColumnTypeText: "text", ColumnTypeBlob: "blob",
+		},
+		EngineSQLite: {
+			ColumnTypeString: "TEXT", ColumnTypeInteger: "INTEGER", ColumnTypeBigInt: "INTEGER",
+			ColumnTypeFloat: "REAL", ColumnTypeDouble: "REAL", ColumnTypeBoolean: "BOOLEAN",
+			ColumnTypeDate: "DATE", ColumnTypeDateTime: "DATETIME", ColumnTypeTimestamp: "TIMESTAMP",
+			ColumnTypeJSON: "TEXT", ColumnTypeUUID: "TEXT", ColumnTypeText: "TEXT", ColumnTypeBlob: "BLOB",
+		},
+	}
+
+	if sqlType, ok := types[w.engine][column.Type]; ok {
+		return sqlType
+	}
+
+	return types[w.engine][ColumnTypeText]
+}
+
+func (w *ddlWriter) primaryKeyDef(table *Table, explicitNames bool) string {
+	body := "PRIMARY KEY (" + w.identList(table.PrimaryKey.Columns) + ")"
+
+	if w.isMySQL() {
+		return body // MySQL always names the primary key PRIMARY
+	}
+
+	return w.constraintPrefix(table.PrimaryKey.Name, table.Name+"_pkey", explicitNames) + body
+}
+
+func (w *ddlWriter) defaultConstraintName(table string, c Constraint) string {
+	if c.Type == ConstraintTypeCheck {
+		if len(c.Columns) == 1 {
+			return table + "_" + c.Columns[0] + "_check"
+		}
+
+		return table + "_check"
+	}
+
+	return table + "_" + strings.Join(c.Columns, "_") + "_key"
+}
+
+// constraintPrefix returns "CONSTRAINT name " unless name is what the engine would pick anyway.
+func (w *ddlWriter) constraintPrefix(name, defaultName string, explicit bool) string {
+	if name == "" || (!explicit && strings.EqualFold(name, defaultName)) {
+		return ""
+	}
+
+	return "CONSTRAINT " + w.ident(name) + " "
+}
+
+func (w *ddlWriter) constraintBody(c Constraint) string {
+	if c.Type == ConstraintTypeCheck {
+		return "CHECK (" + checkCondition(c) + ")"
+	}
+
+	return "UNIQUE (" + w.identList(c.Columns) + ")"
+}
+
+func (w *ddlWriter) foreignKeyBody(fk ForeignKeyConstraint) string {
+	body := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)",
+		w.identList(fk.Columns), w.ident(fk.TargetTable), w.identList(fk.TargetColumns))
+
+	if action := referentialAction(fk.OnDelete); action != "NO ACTION" {
+		body += " ON DELETE " + action
+	}
+
+	if action := referentialAction(fk.OnUpdate); action != "NO ACTION" {
+		body += " ON UPDATE " + action
+	}
+
+	return body
+}
+
+// alterTables applies column, key and constraint changes to tables present in both schemas.
+func (w *ddlWriter) alterTables() {
+	for i := range w.diff.AlteredTables {
+		table := &w.diff.AlteredTables[i]
+
+		if w.needsRebuild(table) {
+			w.rebuildTable(table)
+
+			continue
+		}
+
+		w.alterTable(table)
+	}
+}
+
+// needsRebuild reports whether SQLite must recreate a table to apply its changes.
+// SQLite can only add plain columns in place.
+func (w *ddlWriter) needsRebuild(table *TableDiff) bool {
+	if !w.isSQLite() {
+		return false
+	}
+
+	if len(table.DroppedColumns) > 0 || len(table.AlteredColumns) > 0 || table.PrimaryKeyChanged ||
+		len(table.AddedForeignKeys) > 0 || len(table.DroppedForeignKeys) > 0 ||
+		len(table.AddedConstraints) > 0 || len(table.DroppedConstraints) > 0 {
+		return true
+	}
+
+	// ADD COLUMN cannot add NOT NULL columns without a default.
+	return slices.ContainsFunc(table.AddedColumns, func(c Column) bool {
+		return !c.Nullable && c.Default == nil
+	})
+}
+
+// rebuildTable follows the SQLite procedure for schema changes ALTER TABLE cannot make:
+// create the new definition under a temporary name, copy the rows, swap the tables.
+func (w *ddlWriter) rebuildTable(table *TableDiff) {
+	temp := table.To.Name + rebuildSuffix
+
+	var shared []string
+
+	for _, column := range table.To.Columns {
+		if findByName(table.From.Columns, column.Name, func(c Column) string { return c.Name }) != nil {
+			shared = append(shared, column.Name)
+		}
+	}
+
+	w.add("%s", w.createTable(table.To, temp, true))
+
+	if len(shared) > 0 {
+		w.add("INSERT INTO %s (%s) SELECT %s FROM %s",
+			w.ident(temp), w.identList(shared), w.identList(shared), w.ident(table.From.Name))
+	}
+
+	w.add("DROP TABLE %s", w.ident(table.From.Name))
+	w.add("ALTER TABLE %s RENAME TO %s", w.ident(temp), w.ident(table.To.Name))
+
+	w.rebuilt = append(w.rebuilt, table.To.Name)
+}
+
+func (w *ddlWriter) alterTable(table *TableDiff) {
+	name := w.ident(table.To.Name)
+
+	for _, c := range table.DroppedConstraints {
+		switch {
+		case w.isMySQL() && c.Type == ConstraintTypeUnique:
+			w.add("ALTER TABLE %s DROP INDEX %s", name, w.ident(c.Name))
+		case w.isSQLite():
+			// unreachable: constraint changes rebuild SQLite tables
+		default:
+			w.add("ALTER TABLE %s DROP CONSTRAINT %s", name, w.ident(c.Name))
+		}
+	}
+
+	if table.PrimaryKeyChanged && table.From.PrimaryKey != nil {
+		if w.isMySQL() {
+			w.add("ALTER TABLE %s DROP PRIMARY KEY", name)
+		} else {
+			w.add("ALTER TABLE %s DROP CONSTRAINT %s", name, w.ident(table.From.PrimaryKey.Name))
+		}
+	}
+
+	for _, column := range table.DroppedColumns {
+		w.add("ALTER TABLE %s DROP COLUMN %s", name, w.ident(column.Name))
+	}
+
+	for _, column := range table.AddedColumns {
+		w.add("ALTER TABLE %s ADD COLUMN %s", name, w.columnDef(column))
+	}
+
+	for _, change := range table.AlteredColumns {
+		w.alterColumn(table.To.Name, change)
+	}
+
+	if table.PrimaryKeyChanged && table.To.PrimaryKey != nil {
+		w.add("ALTER TABLE %s ADD %s", name, w.primaryKeyDef(table.To, true))
+	}
+
+	for _, c := range table.AddedConstraints {
+		w.add("ALTER TABLE %s ADD %s%s", name, w.constraintPrefix(c.Name, "", true), w.constraintBody(c))
+	}
+}
+
+func (w *ddlWriter) alterColumn(table string, change ColumnDiff) {
+	if w.isMySQL() {
+		// MODIFY restates the whole column definition.
+		w.add("ALTER TABLE %s MODIFY COLUMN %s", w.ident(table), w.columnDef(change.To))
+
+		return
+	}
+
+	prefix := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", w.ident(table), w.ident(change.To.Name))
+
+	if change.TypeChanged() {
+		w.add("%s TYPE %s", prefix, w.columnType(change.To))
+	}
+
+	if change.NullableChanged() {
+		if change.To.Nullable {
+			w.add("%s DROP NOT NULL", prefix)
+		} else {
+			w.add("%s SET NOT NULL", prefix)
+		}
+	}
+
+	if change.DefaultChanged() {
+		if change.To.Default == nil {
+			w.add("%s DROP DEFAULT", prefix)
+		} else {
+			w.add("%s SET DEFAULT %s", prefix, *change.To.Default)
+		}
+	}
+}
+
+// createIndexes creates new and changed indexes, and every index of rebuilt tables.
+func (w *ddlWriter) createIndexes() {
+	for _, table := range w.diff.AlteredTables {
+		indexes := table.AddedIndexes
+		if slices.Contains(w.rebuilt, table.To.Name) {
+			indexes = table.To.Indexes
+		}
+
+		for _, index := range indexes {
+			w.createIndex(index)
+		}
+	}
+}
+
+func (w *ddlWriter) createIndex(index Index) {
+	var kind string
+
+	switch {
+	case w.isMySQL() && index.Type == IndexTypeFullText:
+		kind = "FULLTEXT "
+	case index.Unique:
+		kind = "UNIQUE "
+	}
+
+	using := ""
+	if w.isPostgres() && indexType(index) != IndexTypeBTree {
+		using = " USING " + string(index.Type)
+	}
+
+	columns := make([]string, len(index.Columns))
+	for i, column := range index.Columns {
+		columns[i] = w.columnRef(column)
+	}
+
+	statement := fmt.Sprintf("CREATE %sINDEX %s ON %s%s (%s)",
+		kind, w.ident(index.Name), w.ident(index.Table), using, strings.Join(columns, ", "))
+
+	if index.Where != "" && !w.isMySQL() {
+		statement += " WHERE " + index.Where
+	}
+
+	w.add("%s", statement)
+}
+
+// addForeignKeys adds new and changed foreign keys once all referenced tables exist.
+func (w *ddlWriter) addForeignKeys() {
+	var fks []ForeignKeyConstraint
+
+	if !w.isSQLite() {
+		for _, table := range w.diff.AlteredTables {
+			fks = append(fks, table.AddedForeignKeys...)
+		}
+	}
+
+	fks = append(fks, w.deferred...)
+
+	for _, fk := range fks {
+		w.add("ALTER TABLE %s ADD %s%s", w.ident(fk.Table), w.constraintPrefix(fk.Name, "", true), w.foreignKeyBody(fk))
+	}
+}
+
+// orderTables sorts tables so that referenced tables come first. References to
+// tables outside the list, or for which exists returns true, need no ordering.
+// Foreign keys that close a cycle are returned separately.
+func orderTables(tables []Table, exists func(string) bool) (ordered []Table, cyclic []ForeignKeyConstraint) {
+	remaining := slices.Clone(tables)
+	created := map[string]bool{}
+
+	ready := func(fk ForeignKeyConstraint) bool {
+		target := strings.ToLower(fk.TargetTable)
+
+		return target == strings.ToLower(fk.Table) || created[target] ||
+			!slices.ContainsFunc(tables, func(t Table) bool { return strings.EqualFold(t.Name, fk.TargetTable) }) ||
+			(exists != nil && exists(fk.TargetTable))
+	}
+
+	for len(remaining) > 0 {
+		next := slices.IndexFunc(remaining, func(t Table) bool {
+			return !slices.ContainsFunc(t.ForeignKeyConstraints(), func(fk ForeignKeyConstraint) bool { return !ready(fk) })
+		})
+
+		if next < 0 {
+			// A cycle: create the first table and add its unresolved keys afterwards.
+			next = 0
+
+			for _, fk := range remaining[0].ForeignKeyConstraints() {
+				if !ready(fk) {
+					cyclic = append(cyclic, fk)
+				}
+			}
+		}
+
+		ordered = append(ordered, remaining[next])
+		created[strings.ToLower(remaining[next].Name)] = true
+		remaining = slices.Delete(remaining, next, next+1)
+	}
+
+	return ordered, cyclic
+}
+
+// ident quotes a name for the writer's engine.
+func (w *ddlWriter) ident(name string) string {
+	return QuoteIdentifier(w.engine, name)
+}
+
+// QuoteIdentifier quotes a table or column name for engine when it is not a plain
+// lower-case identifier or is a reserved word: with backticks on MySQL and double
+// quotes elsewhere. PostgreSQL names with upper-case letters are quoted to keep their case.
+func QuoteIdentifier(engine, name string) string {
+	needsQuotes := !plainIdentifier.MatchString(name) || reservedWords[strings.ToLower(name)] ||
+		(engine == EnginePostgreSQL && strings.ToLower(name) != name)
+
+	if !needsQuotes {
+		return name
+	}
+
+	if engine == EngineMySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (w *ddlWriter) identList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = w.ident(name)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// columnRef quotes an index column, leaving expressions and ordering as written.
+func (w *ddlWriter) columnRef(column string) string {
+	if plainIdentifier.MatchString(column) {
+		return w.ident(column)
+	}
+
+	return column
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func quoteLiterals(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = quoteLiteral(value)
+	}
+
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,306 @@
+package schema_test
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/schema"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// applyStatements parses base followed by the generated statements, the way a
+// database would apply a migration on top of the existing schema.
+func applyStatements(engine, base string, statements []string) *schema.Schema {
+	return mustParseSQL(engine, base+"\n"+strings.Join(statements, ";\n")+";\n")
+}
+
+// expectRoundTrip checks that up turns from into to and down turns to back into from.
+func expectRoundTrip(engine, fromDDL, toDDL string) *schema.MigrationScript {
+	from := mustParseSQL(engine, fromDDL)
+	to := mustParseSQL(engine, toDDL)
+
+	script, err := schema.GenerateMigration(from, to, engine)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(script.IsEmpty()).To(BeFalse())
+
+	migrated := applyStatements(engine, fromDDL, script.Up)
+	Expect(schema.Diff(migrated, to).IsEmpty()).To(BeTrue(), "up does not reach the target schema:\n%s", script.UpSQL())
+
+	reverted := applyStatements(engine, toDDL, script.Down)
+	Expect(schema.Diff(reverted, from).IsEmpty()).To(BeTrue(), "down does not restore the schema:\n%s", script.DownSQL())
+
+	return script
+}
+
+func indexOfStatement(statements []string, prefix string) int {
+	return slices.IndexFunc(statements, func(s string) bool { return strings.HasPrefix(s, prefix) })
+}
+
+var _ = Describe("Schema diff", func() {
+	It("should report no changes between identical schemas", func() {
+		ddl := `CREATE TABLE users (id integer PRIMARY KEY, email text NOT NULL UNIQUE);`
+
+		diff := schema.Diff(mustParseSQL(schema.EnginePostgreSQL, ddl), mustParseSQL(schema.EnginePostgreSQL, "\n\n"+ddl))
+		Expect(diff.IsEmpty()).To(BeTrue())
+
+		script, err := schema.GenerateMigration(mustParseSQL(schema.EnginePostgreSQL, ddl), mustParseSQL(schema.EnginePostgreSQL, ddl), schema.EnginePostgreSQL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(script.IsEmpty()).To(BeTrue())
+		Expect(script.UpSQL()).To(BeEmpty())
+	})
+
+	It("should create a schema from scratch and drop it again", func() {
+		to := mustParseSQL(schema.EnginePostgreSQL, `
+CREATE TABLE comments (id serial PRIMARY KEY, post_id integer NOT NULL REFERENCES posts(id));
+CREATE TABLE posts (id serial PRIMARY KEY, title text NOT NULL);`)
+
+		script, err := schema.GenerateMigration(nil, to, schema.EnginePostgreSQL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(script.Up).To(HaveLen(2))
+		Expect(script.Up[0]).To(HavePrefix("CREATE TABLE posts"))
+		Expect(script.Up[1]).To(HavePrefix("CREATE TABLE comments"))
+		Expect(script.Up[1]).To(ContainSubstring("FOREIGN KEY (post_id) REFERENCES posts (id)"))
+		Expect(script.Down).To(Equal([]string{"DROP TABLE comments", "DROP TABLE posts"}))
+	})
+
+	It("should reject unknown engines", func() {
+		_, err := schema.GenerateMigration(nil, &schema.Schema{}, "oracle")
+		Expect(err).To(MatchError(ContainSubstring("oracle")))
+	})
+
+	It("should group multi-column foreign keys", func() {
+		s := mustParseSQL(schema.EnginePostgreSQL, `
+CREATE TABLE a (x int, y int, PRIMARY KEY (x, y));
+CREATE TABLE b (x int, y int, CONSTRAINT b_a_fkey FOREIGN KEY (x, y) REFERENCES a (x, y) ON DELETE CASCADE);`)
+
+		fks := tableNamed(s, "b").ForeignKeyConstraints()
+		Expect(fks).To(HaveLen(1))
+		Expect(fks[0].Columns).To(Equal([]string{"x", "y"}))
+		Expect(fks[0].TargetColumns).To(Equal([]string{"x", "y"}))
+		Expect(fks[0].OnDelete).To(Equal("CASCADE"))
+	})
+
+	Context("PostgreSQL", func() {
+		const fromDDL = `
+CREATE TYPE status AS ENUM ('active', 'inactive');
+CREATE TYPE legacy_kind AS ENUM ('a', 'b');
+CREATE TABLE users (
+    id bigserial PRIMARY KEY,
+    email text NOT NULL UNIQUE,
+    name text,
+    status status NOT NULL DEFAULT 'active'
+);
+CREATE TABLE posts (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users(id),
+    title text NOT NULL,
+    legacy text
+);
+CREATE INDEX posts_user_idx ON posts (user_id);
+CREATE TABLE audit (id serial PRIMARY KEY, note text);
+CREATE VIEW active_users AS SELECT id, email FROM users WHERE status = 'active';
+`
+
+		const toDDL = `
+CREATE TYPE status AS ENUM ('active', 'inactive', 'banned');
+CREATE TYPE visibility AS ENUM ('public', 'private');
+CREATE TABLE users (
+    id bigserial PRIMARY KEY,
+    email varchar(320) NOT NULL UNIQUE,
+    name text NOT NULL DEFAULT '',
+    status status NOT NULL DEFAULT 'active',
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+CREATE TABLE posts (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title text NOT NULL,
+    visibility visibility NOT NULL DEFAULT 'public',
+    CHECK (length(title) > 0)
+);
+CREATE INDEX posts_user_idx ON posts (user_id, id);
+CREATE UNIQUE INDEX posts_public_title_idx ON posts (title) WHERE visibility = 'public';
+CREATE TABLE post_tags (
+    post_id bigint NOT NULL REFERENCES posts(id),
+    tag_id bigint NOT NULL REFERENCES tags(id),
+    PRIMARY KEY (post_id, tag_id)
+);
+CREATE TABLE tags (id bigserial PRIMARY KEY, name text NOT NULL UNIQUE);
+CREATE VIEW active_users AS SELECT id, email, name FROM users WHERE status = 'active';
+`
+
+		It("should migrate up and down", func() {
+			script := expectRoundTrip(schema.EnginePostgreSQL, fromDDL, toDDL)
+
+			Expect(script.Up).To(ContainElements(
+				"ALTER TYPE status ADD VALUE 'banned'",
+				"CREATE TYPE visibility AS ENUM ('public', 'private')",
+				"ALTER TABLE users ALTER COLUMN email TYPE varchar(320)",
+				"ALTER TABLE users ALTER COLUMN name SET NOT NULL",
+				"ALTER TABLE users ALTER COLUMN name SET DEFAULT ''",
+				"ALTER TABLE users ADD COLUMN created_at timestamptz NOT NULL DEFAULT now()",
+				"ALTER TABLE posts DROP COLUMN legacy",
+				"ALTER TABLE posts ADD CONSTRAINT posts_check CHECK (length(title) > 0)",
+				"DROP INDEX posts_user_idx",
+				"CREATE INDEX posts_user_idx ON posts (user_id, id)",
+				"CREATE UNIQUE INDEX posts_public_title_idx ON posts (title) WHERE visibility = 'public'",
+				"DROP TABLE audit",
+				"DROP TYPE legacy_kind",
+			))
+
+			// Changed foreign keys are dropped before and re-added after the table changes.
+			Expect(indexOfStatement(script.Up, "ALTER TABLE posts DROP CONSTRAINT posts_user_id_fkey")).
+				To(BeNumerically("<", indexOfStatement(script.Up, "ALTER TABLE posts ADD CONSTRAINT posts_user_id_fkey")))
+
+			// Tables are created after the tables they reference, views last.
+			Expect(indexOfStatement(script.Up, "CREATE TABLE tags")).
+				To(BeNumerically("<", indexOfStatement(script.Up, "CREATE TABLE post_tags")))
+			Expect(indexOfStatement(script.Up, "DROP VIEW active_users")).
+				To(BeNumerically("<", indexOfStatement(script.Up, "ALTER TABLE users")))
+			Expect(script.Up[len(script.Up)-1]).To(HavePrefix("CREATE VIEW active_users AS SELECT id, email, name"))
+
+			// Removing an enum value needs a new type.
+			Expect(script.Down).To(ContainElements(
+				"ALTER TYPE status RENAME TO status_old",
+				"CREATE TYPE status AS ENUM ('active', 'inactive')",
+				"ALTER TABLE users ALTER COLUMN status TYPE status USING status::text::status",
+				"DROP TYPE status_old",
+				"DROP TABLE post_tags",
+				"CREATE TYPE legacy_kind AS ENUM ('a', 'b')",
+			))
+			Expect(indexOfStatement(script.Down, "DROP TABLE post_tags")).
+				To(BeNumerically("<", indexOfStatement(script.Down, "DROP TABLE tags")))
+		})
+
+		It("should quote identifiers that need it", func() {
+			to := mustParseSQL(schema.EnginePostgreSQL, `CREATE TABLE "Order" ("user" text, "group" int);`)
+
+			script, err := schema.GenerateMigration(nil, to, schema.EnginePostgreSQL)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(script.Up[0]).To(Equal("CREATE TABLE \"Order\" (\n    \"user\" text,\n    \"group\" int\n)"))
+		})
+
+		It("should swap primary keys", func() {
+			expectRoundTrip(schema.EnginePostgreSQL,
+				`CREATE TABLE memberships (org_id int NOT NULL, user_id int NOT NULL, PRIMARY KEY (org_id));`,
+				`CREATE TABLE memberships (org_id int NOT NULL, user_id int NOT NULL, PRIMARY KEY (org_id, user_id));`,
+			)
+		})
+	})
+
+	Context("MySQL", func() {
+		const fromDDL = "" +
+			"CREATE TABLE users (\n" +
+			"  id BIGINT AUTO_INCREMENT PRIMARY KEY,\n" +
+			"  email VARCHAR(255) NOT NULL,\n" +
+			"  role ENUM('admin','member') NOT NULL DEFAULT 'member',\n" +
+			"  UNIQUE KEY users_email_key (email)\n" +
+			");\n" +
+			"CREATE TABLE sessions (\n" +
+			"  id BIGINT AUTO_INCREMENT PRIMARY KEY,\n" +
+			"  user_id BIGINT NOT NULL,\n" +
+			"  CONSTRAINT sessions_user_fk FOREIGN KEY (user_id) REFERENCES users (id)\n" +
+			");\n"
+
+		const toDDL = "" +
+			"CREATE TABLE users (\n" +
+			"  id BIGINT AUTO_INCREMENT PRIMARY KEY,\n" +
+			"  email VARCHAR(320) NOT NULL,\n" +
+			"  role ENUM('admin','member','guest') NOT NULL DEFAULT 'member',\n" +
+			"  `order` INT NULL,\n" +
+			"  FULLTEXT KEY users_email_ft (email)\n" +
+			");\n" +
+			"CREATE TABLE sessions (\n" +
+			"  id BIGINT AUTO_INCREMENT PRIMARY KEY,\n" +
+			"  user_id BIGINT NOT NULL,\n" +
+			"  CONSTRAINT sessions_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE\n" +
+			");\n"
+
+		It("should migrate up and down", func() {
+			script := expectRoundTrip(schema.EngineMySQL, fromDDL, toDDL)
+
+			Expect(script.Up).To(ContainElements(
+				"ALTER TABLE sessions DROP FOREIGN KEY sessions_user_fk",
+				"ALTER TABLE users DROP INDEX users_email_key",
+				"ALTER TABLE users ADD COLUMN `order` INT",
+				"ALTER TABLE users MODIFY COLUMN email VARCHAR(320) NOT NULL",
+				"ALTER TABLE users MODIFY COLUMN role ENUM('admin','member','guest') NOT NULL DEFAULT 'member'",
+				"CREATE FULLTEXT INDEX users_email_ft ON users (email)",
+				"ALTER TABLE sessions ADD CONSTRAINT sessions_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE",
+			))
+			Expect(script.Down).To(ContainElements(
+				"DROP INDEX users_email_ft ON users",
+				"ALTER TABLE users DROP COLUMN `order`",
+				"ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email)",
+			))
+		})
+	})
+
+	Context("SQLite", func() {
+		const fromDDL = `
+CREATE TABLE users (
+    id INTEGER PRIMARY KEY,
+    email TEXT NOT NULL,
+    nickname TEXT
+);
+CREATE INDEX users_email_idx ON users (email);
+CREATE TABLE notes (id INTEGER PRIMARY KEY, body TEXT);
+`
+
+		const toDDL = `
+CREATE TABLE users (
+    id INTEGER PRIMARY KEY,
+    email TEXT NOT NULL UNIQUE,
+    bio TEXT
+);
+CREATE INDEX users_email_idx ON users (email);
+CREATE TABLE notes (id INTEGER PRIMARY KEY, body TEXT, pinned BOOLEAN NOT NULL DEFAULT 0);
+`
+
+		It("should add plain columns in place and rebuild tables for other changes", func() {
+			script := expectRoundTrip(schema.EngineSQLite, fromDDL, toDDL)
+
+			Expect(script.Up).To(ContainElement("ALTER TABLE notes ADD COLUMN pinned BOOLEAN NOT NULL DEFAULT 0"))
+			Expect(script.Up).To(ContainElements(
+				"CREATE TABLE users_new (\n"+
+					"    id INTEGER NOT NULL PRIMARY KEY,\n"+
+					"    email TEXT NOT NULL,\n"+
+					"    bio TEXT,\n"+
+					"    CONSTRAINT users_email_key UNIQUE (email)\n"+
+					")",
+				"INSERT INTO users_new (id, email) SELECT id, email FROM users",
+				"DROP TABLE users",
+				"ALTER TABLE users_new RENAME TO users",
+				"CREATE INDEX users_email_idx ON users (email)",
+			))
+			Expect(script.Up).NotTo(ContainElement("DROP INDEX users_email_idx"))
+			Expect(indexOfStatement(script.Up, "ALTER TABLE users_new RENAME TO users")).
+				To(BeNumerically("<", indexOfStatement(script.Up, "CREATE INDEX users_email_idx")))
+		})
+
+		It("should keep AUTOINCREMENT on created and rebuilt tables", func() {
+			script := expectRoundTrip(schema.EngineSQLite,
+				"CREATE TABLE events (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT);",
+				`CREATE TABLE events (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL);
+CREATE TABLE logs (id INTEGER PRIMARY KEY AUTOINCREMENT, line TEXT);`)
+
+			Expect(script.Up).To(ContainElements(
+				"CREATE TABLE events_new (\n"+
+					"    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,\n"+
+					"    name TEXT NOT NULL\n"+
+					")",
+				"CREATE TABLE logs (\n"+
+					"    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,\n"+
+					"    line TEXT\n"+
+					")",
+			))
+			Expect(script.Down).To(ContainElement(ContainSubstring("id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT")))
+
+			plain := expectRoundTrip(schema.EngineSQLite,
+				"CREATE TABLE events (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT);",
+				"CREATE TABLE events (id INTEGER PRIMARY KEY, name TEXT);")
+			Expect(plain.Up).To(ContainElement(HavePrefix("CREATE TABLE events_new (\n    id INTEGER NOT NULL PRIMARY KEY,\n")))
+		})
+	})
+})
@@ -0,0 +1,242 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+)
+
+// Supported DDL dialects, matching the sqlc engine names.
+const (
+	EnginePostgreSQL = "postgresql"
+	EngineMySQL      = "mysql"
+	EngineSQLite     = "sqlite"
+)
+
+// defaultSchemaName is used when the sql[] entry has no name.
+const defaultSchemaName = "default"
+
+// ParseError reports a DDL problem at a file position.
+type ParseError struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+}
+
+// ParseErrors collects every ParseError found while reading schema files.
+type ParseErrors []*ParseError
+
+func (e ParseErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Parser builds a Schema from CREATE/ALTER/DROP statements for a single engine.
+// Statements are applied in order, so a directory of migrations yields the final schema.
+// Statements the model does not cover (functions, triggers, grants, DML) are ignored.
+type Parser struct {
+	engine      string
+	tables      []Table
+	views       []View
+	enums       []Enum
+	constraints []Constraint
+	errs        ParseErrors
+
+	// file is the name of the file currently being parsed, used for error positions.
+	file string
+	// src is the source currently being parsed, used to slice raw expressions.
+	src string
+}
+
+// NewParser creates a parser for the given sqlc engine (postgresql, mysql or sqlite).
+func NewParser(engine string) (*Parser, error) {
+	if !slices.Contains([]string{EnginePostgreSQL, EngineMySQL, EngineSQLite}, engine) {
+		return nil, apperrors.Newf(
+			apperrors.ErrorCodeDatabaseNotSupported,
+			"unsupported schema engine %q (must be one of: postgresql, mysql, sqlite)",
+			engine,
+		)
+	}
+
+	return &Parser{engine: engine}, nil
+}
+
+// ParseFile reads and parses a single schema file.
+func (p *Parser) ParseFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return apperrors.FileNotFoundError(path)
+		}
+
+		return apperrors.FileReadError(path, err)
+	}
+
+	return p.ParseSQL(path, string(data))
+}
+
+// ParseSQL parses DDL text. file is only used to label error positions.
+// Parsing continues past malformed statements; all problems are returned as ParseErrors.
+func (p *Parser) ParseSQL(file, src string) error {
+	p.file = file
+	p.src = src
+	before := len(p.errs)
+
+	statements, err := sqlparse.Split(src)
+	if err != nil {
+		p.addSyntaxError(err, sqlparse.Position{Line: 1, Column: 1})
+
+		return p.errs[before:]
+	}
+
+	for i := range statements {
+		stmtErr := p.parseStatement(&statements[i])
+		if stmtErr != nil {
+			p.addSyntaxError(stmtErr, statements[i].Pos)
+		}
+	}
+
+	if len(p.errs) > before {
+		return p.errs[before:]
+	}
+
+	return nil
+}
+
+// Errors returns every error collected so far.
+func (p *Parser) Errors() ParseErrors {
+	return p.errs
+}
+
+// Schema returns the schema built from all parsed statements.
+func (p *Parser) Schema(name string) *Schema {
+	s := Assemble(name, p.engine, slices.Clone(p.tables), slices.Clone(p.views), p.constraints)
+	s.Enums = slices.Clone(p.enums)
+
+	return s
+}
+
+// Assemble builds a schema from its tables and views. The indexes, primary keys and
+// foreign keys of the tables are collected into the schema-level Indexes and
+// Constraints, followed by the unique and check constraints passed in.
+func Assemble(name, engine string, tables []Table, views []View, constraints []Constraint) *Schema {
+	if strings.TrimSpace(name) == "" {
+		name = defaultSchemaName
+	}
+
+	s := &Schema{
+		Name:   name,
+		Tables: tables,
+		Views:  views,
+		Metadata: SchemaMetadata{
+			DatabaseEngine: engine,
+			Version:        "1.0.0",
+		},
+	}
+
+	for _, table := range tables {
+		s.Indexes = append(s.Indexes, table.Indexes...)
+
+		if table.PrimaryKey != nil {
+			s.Constraints = append(s.Constraints, Constraint{
+				Name:    table.PrimaryKey.Name,
+				Type:    ConstraintTypePrimaryKey,
+				Table:   table.Name,
+				Columns: slices.Clone(table.PrimaryKey.Columns),
+			})
+		}
+
+		for _, fk := range table.ForeignKeys {
+			s.Constraints = append(s.Constraints, Constraint{
+				Name:    fk.Name,
+				Type:    ConstraintTypeForeignKey,
+				Table:   table.Name,
+				Columns: []string{fk.SourceColumn},
+				Definition: fmt.Sprintf(
+					"REFERENCES %s(%s)",
+					fk.TargetTable,
+					fk.TargetColumn,
+				),
+			})
+		}
+	}
+
+	s.Constraints = append(s.Constraints, constraints...)
+
+	return s
+}
+
+// addSyntaxError converts an error into a ParseError positioned in the current file.
+func (p *Parser) addSyntaxError(err error, fallback sqlparse.Position) {
+	pos := fallback
+
+	var syntaxErr *sqlparse.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		pos = syntaxErr.Pos
+		err = errors.New(syntaxErr.Message)
+	}
+
+	p.errs = append(p.errs, &ParseError{
+		File:    p.file,
+		Line:    pos.Line,
+		Column:  pos.Column,
+		Message: err.Error(),
+	})
+}
+
+// ParseFiles parses schema files in order and returns the resulting schema.
+// Down migrations (*.down.sql) are skipped, as sqlc does.
+func ParseFiles(engine, name string, files []string) (*Schema, error) {
+	parser, err := NewParser(engine)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		if config.IsDownMigration(file) {
+			continue
+		}
+
+		err := parser.ParseFile(file)
+		if err != nil && !errors.As(err, new(ParseErrors)) {
+			return nil, err
+		}
+	}
+
+	if len(parser.Errors()) > 0 {
+		return parser.Schema(name), parser.Errors()
+	}
+
+	return parser.Schema(name), nil
+}
+
+// ParseConfig parses the schema files referenced by a sql[] entry of sqlc.yaml.
+// Relative paths are resolved against baseDir, normally the directory holding sqlc.yaml.
+// On ParseErrors the partially built schema is returned alongside the error.
+func ParseConfig(cfg *config.SQLConfig, baseDir string) (*Schema, error) {
+	if cfg == nil {
+		return nil, apperrors.NewError(apperrors.ErrorCodeConfigValidation, "sql config is nil")
+	}
+
+	files, err := cfg.Schema.SQLFiles(baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseFiles(cfg.Engine, cfg.Name, files)
+}
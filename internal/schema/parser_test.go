@@ -0,0 +1,357 @@
+package schema_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/schema"
+	"github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func parseSQL(engine, src string) (*schema.Schema, error) {
+	parser, err := schema.NewParser(engine)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = parser.ParseSQL("schema.sql", src)
+
+	return parser.Schema("test"), err
+}
+
+func mustParseSQL(engine, src string) *schema.Schema {
+	s, err := parseSQL(engine, src)
+	Expect(err).NotTo(HaveOccurred())
+
+	return s
+}
+
+func tableNamed(s *schema.Schema, name string) *schema.Table {
+	table, ok := s.GetTable(name)
+	Expect(ok).To(BeTrue(), "table %s not found", name)
+
+	return table
+}
+
+func columnNamed(table *schema.Table, name string) schema.Column {
+	for _, column := range table.Columns {
+		if column.Name == name {
+			return column
+		}
+	}
+
+	Fail("column " + name + " not found in " + table.Name)
+
+	return schema.Column{}
+}
+
+var _ = Describe("Parser", func() {
+	It("should reject unknown engines", func() {
+		_, err := schema.NewParser("oracle")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("oracle"))
+	})
+
+	Context("PostgreSQL", func() {
+		const ddl = `
+-- users of the system
+CREATE TYPE user_role AS ENUM ('admin', 'member');
+
+CREATE TABLE public.Users (
+    id BIGSERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    role user_role NOT NULL DEFAULT 'member',
+    tags text[],
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT users_email_check CHECK (email <> '')
+);
+
+CREATE TABLE posts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    published BOOLEAN
+);
+
+CREATE UNIQUE INDEX CONCURRENTLY posts_title_idx ON posts USING btree (title) WHERE published;
+ALTER TABLE posts ADD CONSTRAINT posts_title_not_empty CHECK (title <> '');
+ALTER TYPE user_role ADD VALUE 'owner' BEFORE 'admin';
+
+CREATE VIEW published_posts AS
+    SELECT p.id, p.title, u.email AS author FROM posts p JOIN users u ON u.id = p.user_id;
+
+CREATE FUNCTION noop() RETURNS void AS $$ BEGIN END; $$ LANGUAGE plpgsql;
+`
+
+		It("should build tables, columns and keys", func() {
+			s := mustParseSQL(schema.EnginePostgreSQL, ddl)
+
+			Expect(s.Tables).To(HaveLen(2))
+
+			users := tableNamed(s, "users")
+			Expect(users.PrimaryKey.Columns).To(Equal([]string{"id"}))
+
+			id := columnNamed(users, "id")
+			Expect(id.Type).To(Equal(schema.ColumnTypeBigInt))
+			Expect(id.AutoIncrement).To(BeTrue())
+			Expect(id.Nullable).To(BeFalse())
+
+			email := columnNamed(users, "email")
+			Expect(email.SQLType).To(Equal("VARCHAR(255)"))
+			Expect(email.Type).To(Equal(schema.ColumnTypeString))
+			Expect(email.Unique).To(BeTrue())
+
+			role := columnNamed(users, "role")
+			Expect(*role.Default).To(Equal("'member'"))
+			Expect(columnNamed(users, "tags").SQLType).To(Equal("text[]"))
+			Expect(columnNamed(users, "created_at").Type).To(Equal(schema.ColumnTypeTimestamp))
+
+			posts := tableNamed(s, "posts")
+			Expect(posts.ForeignKeys).To(ConsistOf(schema.ForeignKey{
+				Name:           "posts_user_id_fkey",
+				SourceTable:    "posts",
+				SourceColumn:   "user_id",
+				TargetTable:    "users",
+				TargetColumn:   "id",
+				OnDeleteAction: "CASCADE",
+				OnUpdateAction: "NO ACTION",
+			}))
+			Expect(columnNamed(posts, "published").Nullable).To(BeTrue())
+		})
+
+		It("should collect indexes, constraints and enums", func() {
+			s := mustParseSQL(schema.EnginePostgreSQL, ddl)
+
+			Expect(s.Indexes).To(ConsistOf(schema.Index{
+				Name:    "posts_title_idx",
+				Table:   "posts",
+				Columns: []string{"title"},
+				Unique:  true,
+				Type:    schema.IndexTypeBTree,
+				Where:   "published",
+			}))
+
+			names := make([]string, 0, len(s.Constraints))
+			for _, constraint := range s.Constraints {
+				names = append(names, constraint.Name)
+			}
+
+			Expect(names).To(ContainElements(
+				"users_pkey", "users_email_key", "users_email_check",
+				"posts_pkey", "posts_user_id_fkey", "posts_title_not_empty",
+			))
+
+			Expect(s.Enums).To(ConsistOf(schema.Enum{
+				Name:   "user_role",
+				Values: []string{"owner", "admin", "member"},
+			}))
+		})
+
+		It("should resolve view columns from the joined tables", func() {
+			s := mustParseSQL(schema.EnginePostgreSQL, ddl)
+
+			Expect(s.Views).To(HaveLen(1))
+			view := s.Views[0]
+			Expect(view.Name).To(Equal("published_posts"))
+			Expect(view.Definition).To(HavePrefix("SELECT p.id"))
+			Expect(view.Columns).To(HaveLen(3))
+			Expect(view.Columns[0].Type).To(Equal(schema.ColumnTypeUUID))
+			Expect(view.Columns[2].Name).To(Equal("author"))
+			Expect(view.Columns[2].SQLType).To(Equal("VARCHAR(255)"))
+		})
+
+		It("should apply ALTER and DROP statements in order", func() {
+			s := mustParseSQL(schema.EnginePostgreSQL, `
+CREATE TABLE accounts (id INT PRIMARY KEY, name TEXT, legacy TEXT);
+ALTER TABLE accounts ADD COLUMN email TEXT NOT NULL, DROP COLUMN legacy;
+ALTER TABLE accounts RENAME COLUMN name TO display_name;
+ALTER TABLE accounts ALTER COLUMN display_name SET NOT NULL;
+ALTER TABLE accounts ALTER COLUMN id TYPE bigint USING id::bigint;
+ALTER TABLE accounts RENAME TO members;
+CREATE TABLE scratch (id INT);
+DROP TABLE scratch;
+`)
+
+			Expect(s.Tables).To(HaveLen(1))
+
+			members := tableNamed(s, "members")
+			Expect(members.Columns).To(HaveLen(3))
+			Expect(columnNamed(members, "display_name").Nullable).To(BeFalse())
+			Expect(columnNamed(members, "email").Nullable).To(BeFalse())
+			Expect(columnNamed(members, "id").SQLType).To(Equal("bigint"))
+		})
+
+		It("should drop every column of a composite foreign key with one of its columns", func() {
+			s := mustParseSQL(schema.EnginePostgreSQL, `
+CREATE TABLE a (x int, y int, PRIMARY KEY (x, y));
+CREATE TABLE c (id int PRIMARY KEY);
+CREATE TABLE b (ax int, ay int, c int REFERENCES c (id), FOREIGN KEY (ax, ay) REFERENCES a (x, y));
+ALTER TABLE b DROP COLUMN ay;
+`)
+
+			b := tableNamed(s, "b")
+			Expect(b.ForeignKeys).To(HaveLen(1))
+			Expect(b.ForeignKeys[0].SourceColumn).To(Equal("c"))
+			Expect(b.ForeignKeyConstraints()).To(HaveLen(1))
+		})
+	})
+
+	Context("MySQL", func() {
+		It("should handle backticks, AUTO_INCREMENT and inline keys", func() {
+			s := mustParseSQL(schema.EngineMySQL, "CREATE TABLE `orders` (\n"+
+				"  `id` INT UNSIGNED NOT NULL AUTO_INCREMENT,\n"+
+				"  `paid` TINYINT(1) NOT NULL DEFAULT 0,\n"+
+				"  `customer_id` INT NOT NULL,\n"+
+				"  `note` TEXT COMMENT 'free text',\n"+
+				"  PRIMARY KEY (`id`),\n"+
+				"  KEY `idx_customer` (`customer_id`),\n"+
+				"  FULLTEXT KEY `ft_note` (`note`)\n"+
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;")
+
+			orders := tableNamed(s, "orders")
+			Expect(orders.PrimaryKey.Columns).To(Equal([]string{"id"}))
+
+			id := columnNamed(orders, "id")
+			Expect(id.AutoIncrement).To(BeTrue())
+			Expect(id.PrimaryKey).To(BeTrue())
+			Expect(columnNamed(orders, "paid").Type).To(Equal(schema.ColumnTypeBoolean))
+
+			Expect(orders.Indexes).To(HaveLen(2))
+			Expect(orders.Indexes[0].Name).To(Equal("idx_customer"))
+			Expect(orders.Indexes[1].Type).To(Equal(schema.IndexTypeFullText))
+		})
+	})
+
+	Context("SQLite", func() {
+		It("should treat INTEGER PRIMARY KEY as auto-increment", func() {
+			s := mustParseSQL(schema.EngineSQLite, `
+CREATE TABLE IF NOT EXISTS notes (
+    id INTEGER PRIMARY KEY,
+    body TEXT NOT NULL,
+    parent_id INTEGER,
+    FOREIGN KEY (parent_id) REFERENCES notes (id) ON DELETE SET NULL
+);
+CREATE TABLE IF NOT EXISTS notes (id INTEGER);
+CREATE TRIGGER notes_touch AFTER UPDATE ON notes BEGIN
+    UPDATE notes SET body = body WHERE id = NEW.id;
+END;
+`)
+
+			Expect(s.Tables).To(HaveLen(1))
+
+			notes := tableNamed(s, "notes")
+			Expect(columnNamed(notes, "id").AutoIncrement).To(BeTrue())
+			Expect(notes.ForeignKeys).To(HaveLen(1))
+			Expect(notes.ForeignKeys[0].OnDeleteAction).To(Equal("SET NULL"))
+		})
+
+		It("should accept key and index as column names", func() {
+			for _, engine := range []string{schema.EngineSQLite, schema.EnginePostgreSQL} {
+				s := mustParseSQL(engine, "CREATE TABLE t (id INT, key TEXT, value TEXT, index INT);")
+
+				t := tableNamed(s, "t")
+				Expect(t.Columns).To(HaveLen(4), engine)
+				Expect(columnNamed(t, "key").SQLType).To(Equal("TEXT"), engine)
+				Expect(t.Indexes).To(BeEmpty(), engine)
+			}
+		})
+
+		It("should treat a table-level INTEGER primary key as the rowid", func() {
+			s := mustParseSQL(schema.EngineSQLite, `
+CREATE TABLE tags (id INTEGER, name TEXT, PRIMARY KEY (id));
+CREATE TABLE pairs (a INTEGER, b INTEGER, PRIMARY KEY (a, b));
+`)
+
+			Expect(columnNamed(tableNamed(s, "tags"), "id").AutoIncrement).To(BeTrue())
+			Expect(columnNamed(tableNamed(s, "pairs"), "a").AutoIncrement).To(BeFalse())
+		})
+	})
+
+	Context("errors", func() {
+		It("should report the file, line and column of each problem", func() {
+			s, err := parseSQL(schema.EnginePostgreSQL, `CREATE TABLE users (id INT);
+CREATE TABLE users (id INT);
+
+CREATE INDEX missing_idx ON missing (id);
+CREATE TABLE ok (id INT);
+`)
+
+			var parseErrs schema.ParseErrors
+			Expect(errors.As(err, &parseErrs)).To(BeTrue())
+			Expect(parseErrs).To(HaveLen(2))
+
+			Expect(parseErrs[0].File).To(Equal("schema.sql"))
+			Expect(parseErrs[0].Line).To(Equal(2))
+			Expect(parseErrs[0].Column).To(Equal(14))
+			Expect(parseErrs[0].Message).To(ContainSubstring(`"users" already exists`))
+
+			Expect(parseErrs[1].Line).To(Equal(4))
+			Expect(parseErrs[1].Error()).To(HavePrefix("schema.sql:4:"))
+
+			// Parsing continues past broken statements.
+			_, ok := s.GetTable("ok")
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should not keep a table whose body fails to parse", func() {
+			s, err := parseSQL(schema.EnginePostgreSQL, `CREATE TABLE broken (id INT UNIQUE, name TEXT, PRIMARY KEY);
+CREATE TABLE broken (id INT);
+`)
+			Expect(err).To(MatchError(ContainSubstring(`expected "("`)))
+
+			// The second statement creates the table instead of reporting it as existing.
+			var parseErrs schema.ParseErrors
+			Expect(errors.As(err, &parseErrs)).To(BeTrue())
+			Expect(parseErrs).To(HaveLen(1))
+
+			broken, ok := s.GetTable("broken")
+			Expect(ok).To(BeTrue())
+			Expect(broken.Columns).To(HaveLen(1))
+			Expect(s.Constraints).To(BeEmpty())
+		})
+
+		It("should report unterminated strings", func() {
+			_, err := parseSQL(schema.EngineSQLite, "CREATE TABLE t (\n  name TEXT DEFAULT 'oops\n);")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("schema.sql:2:"))
+		})
+	})
+
+	Describe("ParseConfig", func() {
+		It("should read schema directories and skip down migrations", func() {
+			dir := GinkgoT().TempDir()
+			migrations := filepath.Join(dir, "migrations")
+			Expect(os.MkdirAll(migrations, 0o755)).To(Succeed())
+
+			files := map[string]string{
+				"001_users.up.sql":   "CREATE TABLE users (id INTEGER PRIMARY KEY);",
+				"001_users.down.sql": "DROP TABLE users;",
+				"002_posts.up.sql":   "CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users);",
+				"README.md":          "not sql",
+			}
+			for name, content := range files {
+				Expect(os.WriteFile(filepath.Join(migrations, name), []byte(content), 0o644)).To(Succeed())
+			}
+
+			s, err := schema.ParseConfig(&config.SQLConfig{
+				Name:   "app",
+				Engine: schema.EngineSQLite,
+				Schema: config.NewSinglePath("migrations"),
+			}, dir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(s.Name).To(Equal("app"))
+			Expect(s.Tables).To(HaveLen(2))
+			Expect(tableNamed(s, "posts").ForeignKeys[0].TargetColumn).To(Equal("id"))
+		})
+
+		It("should fail for missing schema paths", func() {
+			_, err := schema.ParseConfig(&config.SQLConfig{
+				Engine: schema.EngineSQLite,
+				Schema: config.NewSinglePath("does-not-exist"),
+			}, GinkgoT().TempDir())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
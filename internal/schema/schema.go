@@ -40,6 +40,13 @@ type Column struct {
 	Default    *string    `json:"default,omitempty"`
 	PrimaryKey bool       `json:"primary_key"`
 	Unique     bool       `json:"unique"`
+	// SQLType is the column type exactly as declared, e.g. "VARCHAR(255)" or "text[]".
+	SQLType string `json:"sql_type,omitempty"`
+	// AutoIncrement marks serial, identity, AUTO_INCREMENT and SQLite rowid columns.
+	AutoIncrement bool `json:"auto_increment,omitempty"`
+	// SQLiteAutoincrement marks a SQLite rowid column declared AUTOINCREMENT, whose
+	// values are never reused.
+	SQLiteAutoincrement bool `json:"sqlite_autoincrement,omitempty"`
 }
 
 // ColumnType represents strongly-typed column types.
@@ -87,6 +94,8 @@ type Index struct {
 	Columns []string  `json:"columns"`
 	Unique  bool      `json:"unique"`
 	Type    IndexType `json:"type"`
+	// Where is the predicate of a partial index, without the WHERE keyword.
+	Where string `json:"where,omitempty"`
 }
 
 // IndexType represents index types.
@@ -98,6 +107,9 @@ const (
 	IndexTypeGin   IndexType = "gin"
 	IndexTypeGiST  IndexType = "gist"
 	IndexTypeBRIN  IndexType = "brin"
+
+	// IndexTypeFullText is a MySQL FULLTEXT index.
+	IndexTypeFullText IndexType = "fulltext"
 )
 
 // ForeignKey represents a foreign key constraint.
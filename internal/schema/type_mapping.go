@@ -0,0 +1,68 @@
+package schema
+
+import (
+	"regexp"
+	"strings"
+)
+
+// typeArgsPattern strips length/precision arguments such as "(255)" or "(10, 2)".
+var typeArgsPattern = regexp.MustCompile(`\s*\([^)]*\)`)
+
+// ColumnTypeFromSQL maps a declared SQL column type onto the engine-neutral ColumnType.
+// Unknown types (user-defined enums, domains, arrays) map to ColumnTypeString;
+// the declared type is preserved separately in Column.SQLType.
+func ColumnTypeFromSQL(sqlType string) ColumnType {
+	normalized := strings.ToLower(strings.TrimSpace(sqlType))
+
+	// MySQL uses TINYINT(1) as its boolean type.
+	if strings.HasPrefix(normalized, "tinyint(1)") {
+		return ColumnTypeBoolean
+	}
+
+	if strings.HasSuffix(normalized, "[]") || strings.HasSuffix(normalized, " array") {
+		return ColumnTypeString
+	}
+
+	base := typeArgsPattern.ReplaceAllString(normalized, "")
+	base = strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(base, " unsigned"), " zerofill"))
+
+	switch base {
+	case "bool", "boolean":
+		return ColumnTypeBoolean
+	case "int", "integer", "int4", "int2", "smallint", "mediumint", "tinyint",
+		"serial", "serial4", "smallserial", "serial2":
+		return ColumnTypeInteger
+	case "bigint", "int8", "bigserial", "serial8":
+		return ColumnTypeBigInt
+	case "real", "float", "float4":
+		return ColumnTypeFloat
+	case "double", "double precision", "float8", "numeric", "decimal", "money":
+		return ColumnTypeDouble
+	case "date":
+		return ColumnTypeDate
+	case "datetime":
+		return ColumnTypeDateTime
+	case "timestamp", "timestamptz", "timestamp with time zone", "timestamp without time zone":
+		return ColumnTypeTimestamp
+	case "json", "jsonb":
+		return ColumnTypeJSON
+	case "uuid":
+		return ColumnTypeUUID
+	case "text", "tinytext", "mediumtext", "longtext", "clob", "citext":
+		return ColumnTypeText
+	case "blob", "tinyblob", "mediumblob", "longblob", "bytea", "binary", "varbinary":
+		return ColumnTypeBlob
+	default:
+		return ColumnTypeString
+	}
+}
+
+// isSerialType reports whether a PostgreSQL type implies an auto-incrementing sequence.
+func isSerialType(sqlType string) bool {
+	switch strings.ToLower(strings.TrimSpace(sqlType)) {
+	case "serial", "serial2", "serial4", "serial8", "smallserial", "bigserial":
+		return true
+	default:
+		return false
+	}
+}
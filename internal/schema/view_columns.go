@@ -0,0 +1,213 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/sqlparse"
+)
+
+// viewColumns resolves the output columns of a view's SELECT on a best-effort basis.
+// Columns taken directly from known tables keep their type; expressions become
+// nullable string columns named after their alias.
+func (p *Parser) viewColumns(query []sqlparse.Token) []Column {
+	c := sqlparse.NewCursor(p.src, query)
+
+	// Skip a leading WITH clause; its CTEs are not resolved.
+	// Groups are skipped whole, so only a top-level SELECT stops the loop.
+	if !c.Accept("SELECT") {
+		for !c.Done() && !c.Peek().Is("SELECT") {
+			if c.Peek().IsPunct("(") {
+				_ = c.SkipGroupIfPresent()
+
+				continue
+			}
+
+			c.Next()
+		}
+
+		if !c.Accept("SELECT") {
+			return []Column{}
+		}
+	}
+
+	_ = c.Accept("DISTINCT") || c.Accept("ALL")
+
+	selectList, from := splitAtFrom(c.Rest())
+	aliases := p.fromTables(from)
+	columns := []Column{}
+
+	for _, item := range sqlparse.SplitTopLevel(selectList) {
+		columns = append(columns, p.selectItemColumns(item, aliases)...)
+	}
+
+	return columns
+}
+
+// splitAtFrom splits a select body at its top-level FROM keyword.
+func splitAtFrom(tokens []sqlparse.Token) ([]sqlparse.Token, []sqlparse.Token) {
+	depth := 0
+
+	for i, tok := range tokens {
+		switch {
+		case tok.IsPunct("("):
+			depth++
+		case tok.IsPunct(")"):
+			depth--
+		case depth == 0 && tok.Is("FROM"):
+			return tokens[:i], tokens[i+1:]
+		}
+	}
+
+	return tokens, nil
+}
+
+// fromClauseTerminators end the table list of a FROM clause.
+var fromClauseTerminators = map[string]bool{
+	"WHERE": true, "GROUP": true, "HAVING": true, "ORDER": true, "LIMIT": true,
+	"UNION": true, "INTERSECT": true, "EXCEPT": true, "WINDOW": true, "OFFSET": true,
+}
+
+// fromTables maps every table alias (and name) in a FROM clause to its table.
+// The empty key holds the tables in order, for resolving unqualified columns.
+func (p *Parser) fromTables(from []sqlparse.Token) map[string][]*Table {
+	aliases := map[string][]*Table{}
+	c := sqlparse.NewCursor(p.src, from)
+
+	for !c.Done() {
+		tok := c.Peek()
+		if tok.Kind == sqlparse.TokenWord && fromClauseTerminators[tok.Upper()] {
+			break
+		}
+
+		if tok.IsPunct("(") {
+			_ = c.SkipGroupIfPresent()
+
+			continue
+		}
+
+		if !tok.IsIdent() || isJoinKeyword(tok) {
+			c.Next()
+
+			continue
+		}
+
+		name, err := p.objectName(c)
+		if err != nil {
+			c.Next()
+
+			continue
+		}
+
+		table := p.table(name)
+		if table == nil {
+			continue
+		}
+
+		aliases[""] = append(aliases[""], table)
+		aliases[strings.ToLower(name)] = []*Table{table}
+
+		_ = c.Accept("AS")
+		if alias := c.Peek(); alias.IsIdent() && !isJoinKeyword(alias) &&
+			!(alias.Kind == sqlparse.TokenWord && fromClauseTerminators[alias.Upper()]) {
+			c.Next()
+
+			aliases[strings.ToLower(alias.Value)] = []*Table{table}
+		}
+
+		// Skip the join condition, which may mention other identifiers.
+		if c.Accept("ON") {
+			for !c.Done() && !c.Peek().IsPunct(",") && !isJoinKeyword(c.Peek()) &&
+				!(c.Peek().Kind == sqlparse.TokenWord && fromClauseTerminators[c.Peek().Upper()]) {
+				if c.Peek().IsPunct("(") {
+					_ = c.SkipGroupIfPresent()
+
+					continue
+				}
+
+				c.Next()
+			}
+		}
+	}
+
+	return aliases
+}
+
+func isJoinKeyword(tok sqlparse.Token) bool {
+	switch tok.Upper() {
+	case "JOIN", "INNER", "LEFT", "RIGHT", "FULL", "OUTER", "CROSS", "NATURAL", "LATERAL", "USING", "ON":
+		return tok.Kind == sqlparse.TokenWord
+	default:
+		return false
+	}
+}
+
+// selectItemColumns resolves a single select-list item.
+func (p *Parser) selectItemColumns(item []sqlparse.Token, aliases map[string][]*Table) []Column {
+	if len(item) == 0 {
+		return nil
+	}
+
+	// "*" or "t.*"
+	last := item[len(item)-1]
+	if last.IsPunct("*") && (len(item) == 1 || (len(item) == 3 && item[1].IsPunct("."))) {
+		qualifier := ""
+		if len(item) == 3 {
+			qualifier = strings.ToLower(p.identValue(item[0]))
+		}
+
+		var columns []Column
+		for _, table := range aliases[qualifier] {
+			columns = append(columns, table.Columns...)
+		}
+
+		return columns
+	}
+
+	alias := ""
+	expr := item
+
+	if n := len(item); n >= 2 && item[n-1].IsIdent() && !item[n-1].Is("END") {
+		switch {
+		case item[n-2].Is("AS"):
+			alias, expr = p.identValue(item[n-1]), item[:n-2]
+		case item[n-2].Kind != sqlparse.TokenPunct || item[n-2].IsPunct(")"):
+			alias, expr = p.identValue(item[n-1]), item[:n-1]
+		}
+	}
+
+	if column, ok := p.resolveColumnRef(expr, aliases); ok {
+		if alias != "" {
+			column.Name = alias
+		}
+
+		return []Column{column}
+	}
+
+	if alias == "" {
+		alias = sqlparse.SourceText(p.src, expr)
+	}
+
+	return []Column{{Name: alias, Type: ColumnTypeString, Nullable: true}}
+}
+
+// resolveColumnRef looks up "col" or "alias.col" among the FROM tables.
+func (p *Parser) resolveColumnRef(expr []sqlparse.Token, aliases map[string][]*Table) (Column, bool) {
+	var qualifier, name string
+
+	switch {
+	case len(expr) == 1 && expr[0].IsIdent():
+		name = p.identValue(expr[0])
+	case len(expr) == 3 && expr[0].IsIdent() && expr[1].IsPunct(".") && expr[2].IsIdent():
+		qualifier, name = strings.ToLower(p.identValue(expr[0])), p.identValue(expr[2])
+	default:
+		return Column{}, false
+	}
+
+	for _, table := range aliases[qualifier] {
+		if column := p.column(table, name); column != nil {
+			return *column, true
+		}
+	}
+
+	return Column{}, false
+}
@@ -0,0 +1,227 @@
+package sqlparse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Cursor walks the tokens of a statement with keyword-oriented helpers.
+type Cursor struct {
+	tokens []Token
+	pos    int
+	// src is the source the tokens were read from, used to slice raw text.
+	src string
+}
+
+// NewCursor creates a cursor over tokens read from src.
+func NewCursor(src string, tokens []Token) *Cursor {
+	return &Cursor{tokens: tokens, src: src}
+}
+
+// Done reports whether all tokens were consumed.
+func (c *Cursor) Done() bool {
+	return c.pos >= len(c.tokens)
+}
+
+// Peek returns the current token without consuming it.
+// At the end of input it returns a zero token positioned after the last token.
+func (c *Cursor) Peek() Token {
+	return c.PeekAt(0)
+}
+
+// PeekAt returns the token n positions ahead of the current one.
+func (c *Cursor) PeekAt(n int) Token {
+	if c.pos+n < len(c.tokens) {
+		return c.tokens[c.pos+n]
+	}
+
+	if len(c.tokens) == 0 {
+		return Token{Kind: TokenPunct}
+	}
+
+	last := c.tokens[len(c.tokens)-1]
+
+	return Token{Kind: TokenPunct, Pos: last.Pos, End: last.End}
+}
+
+// Next consumes and returns the current token.
+func (c *Cursor) Next() Token {
+	tok := c.Peek()
+	if !c.Done() {
+		c.pos++
+	}
+
+	return tok
+}
+
+// Accept consumes the given keyword sequence if it is next, case-insensitively.
+func (c *Cursor) Accept(keywords ...string) bool {
+	for i, kw := range keywords {
+		if !c.PeekAt(i).Is(kw) {
+			return false
+		}
+	}
+
+	c.pos += len(keywords)
+
+	return true
+}
+
+// AcceptPunct consumes the given punctuation if it is next.
+func (c *Cursor) AcceptPunct(punct string) bool {
+	if c.Peek().IsPunct(punct) {
+		c.pos++
+
+		return true
+	}
+
+	return false
+}
+
+// Expect consumes the keyword sequence or returns an error positioned at the mismatch.
+func (c *Cursor) Expect(keywords ...string) error {
+	if c.Accept(keywords...) {
+		return nil
+	}
+
+	return c.Errorf("expected %s", strings.Join(keywords, " "))
+}
+
+// ExpectPunct consumes the punctuation or returns an error.
+func (c *Cursor) ExpectPunct(punct string) error {
+	if c.AcceptPunct(punct) {
+		return nil
+	}
+
+	return c.Errorf("expected %q", punct)
+}
+
+// Ident consumes an identifier and returns its unquoted value.
+func (c *Cursor) Ident() (string, error) {
+	tok := c.Peek()
+	if c.Done() || !tok.IsIdent() {
+		return "", c.Errorf("expected identifier")
+	}
+
+	c.pos++
+
+	return tok.Value, nil
+}
+
+// QualifiedIdent consumes a possibly dotted name such as schema.table.
+func (c *Cursor) QualifiedIdent() ([]string, error) {
+	first, err := c.Ident()
+	if err != nil {
+		return nil, err
+	}
+
+	parts := []string{first}
+
+	for c.Peek().IsPunct(".") && c.PeekAt(1).IsIdent() {
+		c.pos++
+
+		parts = append(parts, c.Next().Value)
+	}
+
+	return parts, nil
+}
+
+// Group consumes a parenthesised group and returns the tokens inside it.
+// The cursor must be positioned on the opening parenthesis.
+func (c *Cursor) Group() ([]Token, error) {
+	open := c.Peek()
+	if !open.IsPunct("(") {
+		return nil, c.Errorf("expected \"(\"")
+	}
+
+	depth := 0
+
+	for i := c.pos; i < len(c.tokens); i++ {
+		switch {
+		case c.tokens[i].IsPunct("("):
+			depth++
+		case c.tokens[i].IsPunct(")"):
+			depth--
+			if depth == 0 {
+				inner := c.tokens[c.pos+1 : i]
+				c.pos = i + 1
+
+				return inner, nil
+			}
+		}
+	}
+
+	return nil, &SyntaxError{Pos: open.Pos, Message: "unbalanced parentheses"}
+}
+
+// SkipGroupIfPresent consumes a parenthesised group when the cursor is on "(".
+func (c *Cursor) SkipGroupIfPresent() error {
+	if !c.Peek().IsPunct("(") {
+		return nil
+	}
+
+	_, err := c.Group()
+
+	return err
+}
+
+// Rest consumes and returns all remaining tokens.
+func (c *Cursor) Rest() []Token {
+	rest := c.tokens[c.pos:]
+	c.pos = len(c.tokens)
+
+	return rest
+}
+
+// Text returns the source text spanned by tokens.
+func (c *Cursor) Text(tokens []Token) string {
+	return SourceText(c.src, tokens)
+}
+
+// Errorf returns a SyntaxError at the current token.
+func (c *Cursor) Errorf(format string, args ...any) error {
+	tok := c.Peek()
+
+	found := "end of statement"
+	if !c.Done() {
+		found = fmt.Sprintf("%q", tok.Text)
+	}
+
+	return &SyntaxError{Pos: tok.Pos, Message: fmt.Sprintf(format, args...) + ", found " + found}
+}
+
+// SourceText returns the source text spanned by tokens, or "" for no tokens.
+func SourceText(src string, tokens []Token) string {
+	if len(tokens) == 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(src[tokens[0].Pos.Offset:tokens[len(tokens)-1].End])
+}
+
+// SplitTopLevel splits tokens on commas that are not nested inside parentheses.
+func SplitTopLevel(tokens []Token) [][]Token {
+	var (
+		parts [][]Token
+		start int
+		depth int
+	)
+
+	for i, tok := range tokens {
+		switch {
+		case tok.IsPunct("("):
+			depth++
+		case tok.IsPunct(")"):
+			depth--
+		case tok.IsPunct(",") && depth == 0:
+			parts = append(parts, tokens[start:i])
+			start = i + 1
+		}
+	}
+
+	if start < len(tokens) {
+		parts = append(parts, tokens[start:])
+	}
+
+	return parts
+}
@@ -0,0 +1,3 @@
+// Package sqlparse provides a small, dialect-tolerant SQL tokenizer and statement splitter
+// shared by the schema, query and lint tooling.
+package sqlparse
@@ -0,0 +1,448 @@
+package sqlparse
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenKind classifies a lexical token.
+type TokenKind int
+
+const (
+	// TokenWord is an unquoted identifier or keyword.
+	TokenWord TokenKind = iota
+	// TokenQuotedIdent is a quoted identifier ("name", `name` or [name]).
+	TokenQuotedIdent
+	// TokenString is a string literal ('text', E'text' or $tag$text$tag$).
+	TokenString
+	// TokenNumber is a numeric literal.
+	TokenNumber
+	// TokenParam is a query placeholder ($1, ?, ?1, @name or :name).
+	TokenParam
+	// TokenPunct is punctuation or an operator.
+	TokenPunct
+	// TokenComment is a line or block comment.
+	TokenComment
+)
+
+// String returns a readable name for the token kind.
+func (k TokenKind) String() string {
+	switch k {
+	case TokenWord:
+		return "word"
+	case TokenQuotedIdent:
+		return "quoted identifier"
+	case TokenString:
+		return "string"
+	case TokenNumber:
+		return "number"
+	case TokenParam:
+		return "parameter"
+	case TokenPunct:
+		return "punctuation"
+	case TokenComment:
+		return "comment"
+	default:
+		return "unknown"
+	}
+}
+
+// Position is a 1-based line/column location inside a source text.
+type Position struct {
+	Offset int `json:"offset"`
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// String formats the position as line:column.
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Token is a single lexical token with its source position.
+type Token struct {
+	Kind TokenKind
+	// Text is the raw source text of the token, including quotes.
+	Text string
+	// Value is the unquoted value for quoted identifiers and strings,
+	// otherwise identical to Text.
+	Value string
+	Pos   Position
+	// End is the byte offset just past the token.
+	End int
+}
+
+// Is reports whether the token is a word matching keyword case-insensitively.
+func (t Token) Is(keyword string) bool {
+	return t.Kind == TokenWord && strings.EqualFold(t.Text, keyword)
+}
+
+// IsPunct reports whether the token is the given punctuation.
+func (t Token) IsPunct(punct string) bool {
+	return t.Kind == TokenPunct && t.Text == punct
+}
+
+// IsIdent reports whether the token can name an object (word or quoted identifier).
+func (t Token) IsIdent() bool {
+	return t.Kind == TokenWord || t.Kind == TokenQuotedIdent
+}
+
+// Upper returns the upper-cased token text, handy for keyword switches.
+func (t Token) Upper() string {
+	return strings.ToUpper(t.Text)
+}
+
+// SyntaxError reports a lexical error at a position.
+type SyntaxError struct {
+	Pos     Position
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Message)
+}
+
+// lexer walks a source string producing tokens.
+type lexer struct {
+	src    string
+	offset int
+	line   int
+	column int
+	tokens []Token
+}
+
+// Tokenize splits src into tokens, including comments.
+// Whitespace is dropped. An unterminated string, identifier or comment yields a SyntaxError.
+func Tokenize(src string) ([]Token, error) {
+	l := &lexer{src: src, line: 1, column: 1}
+
+	for l.offset < len(l.src) {
+		err := l.next()
+		if err != nil {
+			return l.tokens, err
+		}
+	}
+
+	return l.tokens, nil
+}
+
+func (l *lexer) pos() Position {
+	return Position{Offset: l.offset, Line: l.line, Column: l.column}
+}
+
+func (l *lexer) peekByte(n int) byte {
+	if l.offset+n >= len(l.src) {
+		return 0
+	}
+
+	return l.src[l.offset+n]
+}
+
+// advance moves the cursor n bytes forward, tracking line and column.
+func (l *lexer) advance(n int) {
+	for range n {
+		if l.offset >= len(l.src) {
+			return
+		}
+
+		// Continuation bytes of multi-byte runes do not advance the column.
+		if l.src[l.offset] == '\n' {
+			l.line++
+			l.column = 1
+		} else if utf8.RuneStart(l.src[l.offset]) {
+			l.column++
+		}
+
+		l.offset++
+	}
+}
+
+func (l *lexer) emit(kind TokenKind, start Position, value string) {
+	text := l.src[start.Offset:l.offset]
+	if kind != TokenQuotedIdent && kind != TokenString {
+		value = text
+	}
+
+	l.tokens = append(l.tokens, Token{Kind: kind, Text: text, Value: value, Pos: start, End: l.offset})
+}
+
+func (l *lexer) next() error {
+	c := l.src[l.offset]
+
+	switch {
+	case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v':
+		l.advance(1)
+
+		return nil
+	case c == '-' && l.peekByte(1) == '-', c == '#' && l.peekByte(1) != '>':
+		l.lineComment()
+
+		return nil
+	case c == '/' && l.peekByte(1) == '*':
+		return l.blockComment()
+	case c == '\'':
+		return l.quoted(TokenString, '\'', '\'', false)
+	case (c == 'E' || c == 'e' || c == 'N' || c == 'n' || c == 'X' || c == 'x' || c == 'B' || c == 'b') &&
+		l.peekByte(1) == '\'':
+		return l.prefixedString()
+	case c == '"':
+		return l.quoted(TokenQuotedIdent, '"', '"', false)
+	case c == '`':
+		return l.quoted(TokenQuotedIdent, '`', '`', false)
+	case c == '[' && l.looksLikeBracketIdent():
+		return l.quoted(TokenQuotedIdent, '[', ']', false)
+	case c == '$':
+		return l.dollar()
+	case c == '?':
+		l.param(1)
+
+		return nil
+	case (c == '@' || c == ':') && isIdentStart(l.peekByte(1)) && !l.prevIsColon():
+		l.param(1)
+
+		return nil
+	case isDigit(c) || (c == '.' && isDigit(l.peekByte(1))):
+		l.number()
+
+		return nil
+	case isIdentStart(c) || c >= utf8.RuneSelf:
+		l.word()
+
+		return nil
+	default:
+		l.punct()
+
+		return nil
+	}
+}
+
+func (l *lexer) lineComment() {
+	start := l.pos()
+	for l.offset < len(l.src) && l.src[l.offset] != '\n' {
+		l.advance(1)
+	}
+
+	l.emit(TokenComment, start, "")
+}
+
+func (l *lexer) blockComment() error {
+	start := l.pos()
+	depth := 0
+
+	for l.offset < len(l.src) {
+		switch {
+		case l.src[l.offset] == '/' && l.peekByte(1) == '*':
+			depth++
+
+			l.advance(2)
+		case l.src[l.offset] == '*' && l.peekByte(1) == '/':
+			depth--
+
+			l.advance(2)
+
+			if depth == 0 {
+				l.emit(TokenComment, start, "")
+
+				return nil
+			}
+		default:
+			l.advance(1)
+		}
+	}
+
+	return &SyntaxError{Pos: start, Message: "unterminated block comment"}
+}
+
+// quoted lexes a quoted token where a doubled closing quote escapes itself.
+// When backslash is set, backslash escapes are honoured as in PostgreSQL escape (E-prefixed) strings.
+func (l *lexer) quoted(kind TokenKind, open, closing byte, backslash bool) error {
+	start := l.pos()
+
+	var value strings.Builder
+
+	l.advance(1) // opening quote
+
+	for l.offset < len(l.src) {
+		c := l.src[l.offset]
+
+		switch {
+		case c == closing && l.peekByte(1) == closing && open != '[':
+			value.WriteByte(c)
+			l.advance(2)
+		case c == closing:
+			l.advance(1)
+			l.emit(kind, start, value.String())
+
+			return nil
+		case c == '\\' && backslash && l.peekByte(1) != 0:
+			value.WriteByte(c)
+			value.WriteByte(l.peekByte(1))
+			l.advance(2)
+		default:
+			value.WriteByte(c)
+			l.advance(1)
+		}
+	}
+
+	return &SyntaxError{Pos: start, Message: "unterminated " + kind.String()}
+}
+
+func (l *lexer) prefixedString() error {
+	start := l.pos()
+
+	backslash := l.src[l.offset] == 'E' || l.src[l.offset] == 'e'
+
+	l.advance(1) // prefix letter
+
+	err := l.quoted(TokenString, '\'', '\'', backslash)
+	if err != nil {
+		return &SyntaxError{Pos: start, Message: "unterminated string"}
+	}
+
+	last := &l.tokens[len(l.tokens)-1]
+	last.Pos = start
+	last.Text = l.src[start.Offset:l.offset]
+
+	return nil
+}
+
+// looksLikeBracketIdent distinguishes SQL Server style [ident] from PostgreSQL array subscripts.
+func (l *lexer) looksLikeBracketIdent() bool {
+	if len(l.tokens) > 0 {
+		prev := l.tokens[len(l.tokens)-1]
+		if prev.IsIdent() || prev.IsPunct(")") || prev.IsPunct("]") {
+			return false
+		}
+	}
+
+	end := strings.IndexByte(l.src[l.offset:], ']')
+
+	return end > 1 && isIdentStart(l.peekByte(1))
+}
+
+func (l *lexer) prevIsColon() bool {
+	return l.offset > 0 && l.src[l.offset-1] == ':'
+}
+
+// dollar lexes $1 placeholders and $tag$...$tag$ dollar-quoted strings.
+func (l *lexer) dollar() error {
+	if isDigit(l.peekByte(1)) {
+		l.param(1)
+
+		return nil
+	}
+
+	start := l.pos()
+
+	end := 1
+	for l.offset+end < len(l.src) && isIdentChar(l.src[l.offset+end]) {
+		end++
+	}
+
+	if l.offset+end >= len(l.src) || l.src[l.offset+end] != '$' {
+		l.punct()
+
+		return nil
+	}
+
+	tag := l.src[l.offset : l.offset+end+1]
+	bodyStart := l.offset + len(tag)
+
+	closeIdx := strings.Index(l.src[bodyStart:], tag)
+	if closeIdx < 0 {
+		return &SyntaxError{Pos: start, Message: "unterminated dollar-quoted string"}
+	}
+
+	value := l.src[bodyStart : bodyStart+closeIdx]
+	l.advance(len(tag) + closeIdx + len(tag))
+	l.emit(TokenString, start, value)
+
+	return nil
+}
+
+func (l *lexer) param(prefix int) {
+	start := l.pos()
+
+	l.advance(prefix)
+
+	for l.offset < len(l.src) && isIdentChar(l.src[l.offset]) {
+		l.advance(1)
+	}
+
+	l.emit(TokenParam, start, "")
+}
+
+func (l *lexer) number() {
+	start := l.pos()
+
+	for l.offset < len(l.src) {
+		c := l.src[l.offset]
+		if isDigit(c) || c == '.' || c == '_' {
+			l.advance(1)
+
+			continue
+		}
+
+		if (c == 'e' || c == 'E') && (isDigit(l.peekByte(1)) ||
+			((l.peekByte(1) == '+' || l.peekByte(1) == '-') && isDigit(l.peekByte(2)))) {
+			l.advance(2)
+
+			continue
+		}
+
+		break
+	}
+
+	l.emit(TokenNumber, start, "")
+}
+
+func (l *lexer) word() {
+	start := l.pos()
+
+	for l.offset < len(l.src) {
+		r, size := utf8.DecodeRuneInString(l.src[l.offset:])
+		if r != '_' && r != '$' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+
+		l.advance(size)
+	}
+
+	l.emit(TokenWord, start, "")
+}
+
+// multiCharPuncts lists operators that are emitted as a single token.
+var multiCharPuncts = []string{
+	"::", "<=", ">=", "<>", "!=", "||", "->>", "->", "#>>", "#>", "@>", "<@", "&&", ":=", "=>",
+}
+
+func (l *lexer) punct() {
+	start := l.pos()
+
+	for _, op := range multiCharPuncts {
+		if strings.HasPrefix(l.src[l.offset:], op) {
+			l.advance(len(op))
+			l.emit(TokenPunct, start, "")
+
+			return
+		}
+	}
+
+	_, size := utf8.DecodeRuneInString(l.src[l.offset:])
+	l.advance(size)
+	l.emit(TokenPunct, start, "")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
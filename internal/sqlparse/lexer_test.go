@@ -0,0 +1,134 @@
+package sqlparse
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		kinds  []TokenKind
+		values []string
+	}{
+		{
+			name:   "words and punctuation",
+			src:    "SELECT a.b, c FROM t;",
+			kinds:  []TokenKind{TokenWord, TokenWord, TokenPunct, TokenWord, TokenPunct, TokenWord, TokenWord, TokenWord, TokenPunct},
+			values: []string{"SELECT", "a", ".", "b", ",", "c", "FROM", "t", ";"},
+		},
+		{
+			name:   "doubled quotes in strings",
+			src:    "'it''s'",
+			kinds:  []TokenKind{TokenString},
+			values: []string{"it's"},
+		},
+		{
+			name:   "backslash is literal in standard strings",
+			src:    `'C:\path'`,
+			kinds:  []TokenKind{TokenString},
+			values: []string{`C:\path`},
+		},
+		{
+			name:   "escaped quote does not end an escape string",
+			src:    `E'a\'b'`,
+			kinds:  []TokenKind{TokenString},
+			values: []string{`a\'b`},
+		},
+		{
+			name:   "quoted identifiers",
+			src:    "\"Order\" `key`",
+			kinds:  []TokenKind{TokenQuotedIdent, TokenQuotedIdent},
+			values: []string{"Order", "key"},
+		},
+		{
+			name:   "dollar quoted body",
+			src:    "$fn$ SELECT 1; $fn$",
+			kinds:  []TokenKind{TokenString},
+			values: []string{" SELECT 1; "},
+		},
+		{
+			name:   "parameters and casts",
+			src:    "$1 ? @name :id x::text",
+			kinds:  []TokenKind{TokenParam, TokenParam, TokenParam, TokenParam, TokenWord, TokenPunct, TokenWord},
+			values: []string{"$1", "?", "@name", ":id", "x", "::", "text"},
+		},
+		{
+			name:   "comments",
+			src:    "-- line\n/* block /* nested */ */ x",
+			kinds:  []TokenKind{TokenComment, TokenComment, TokenWord},
+			values: []string{"-- line", "/* block /* nested */ */", "x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := Tokenize(tt.src)
+			if err != nil {
+				t.Fatalf("Tokenize() error = %v", err)
+			}
+
+			if len(tokens) != len(tt.kinds) {
+				t.Fatalf("Tokenize() returned %d tokens, want %d: %+v", len(tokens), len(tt.kinds), tokens)
+			}
+
+			for i, tok := range tokens {
+				if tok.Kind != tt.kinds[i] {
+					t.Errorf("token %d kind = %s, want %s", i, tok.Kind, tt.kinds[i])
+				}
+
+				if tok.Value != tt.values[i] {
+					t.Errorf("token %d value = %q, want %q", i, tok.Value, tt.values[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTokenizeUnterminated(t *testing.T) {
+	_, err := Tokenize("SELECT\n  'open")
+
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("Tokenize() error = %v, want SyntaxError", err)
+	}
+
+	if syntaxErr.Pos.Line != 2 || syntaxErr.Pos.Column != 3 {
+		t.Errorf("error position = %s, want 2:3", syntaxErr.Pos)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	src := `-- name: first
+SELECT 1;
+CREATE TRIGGER trg AFTER INSERT ON t BEGIN
+  UPDATE t SET x = 1;
+END;
+SELECT ';' /* ; */;`
+
+	statements, err := Split(src)
+	if err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+
+	if len(statements) != 3 {
+		t.Fatalf("Split() returned %d statements, want 3", len(statements))
+	}
+
+	if !statements[0].HasComment("name: first") {
+		t.Error("leading comment was not attached to the first statement")
+	}
+
+	if statements[0].Pos.Line != 2 {
+		t.Errorf("first statement line = %d, want 2", statements[0].Pos.Line)
+	}
+
+	if statements[1].Keyword() != "CREATE" || statements[1].Pos.Line != 3 {
+		t.Errorf("trigger statement = %q at line %d", statements[1].Keyword(), statements[1].Pos.Line)
+	}
+
+	if statements[2].Text != "SELECT ';' /* ; */" {
+		t.Errorf("last statement text = %q", statements[2].Text)
+	}
+}
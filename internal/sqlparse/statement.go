@@ -0,0 +1,130 @@
+package sqlparse
+
+import "strings"
+
+// Statement is a single SQL statement split from a source text.
+type Statement struct {
+	// Text is the statement source without the terminating semicolon.
+	Text string
+	// Tokens are the statement tokens without comments.
+	Tokens []Token
+	// Comments are the comments that appear inside or directly before the statement.
+	Comments []Token
+	// Pos is the position of the first non-comment token.
+	Pos Position
+}
+
+// Keyword returns the upper-cased first word of the statement, or "" when empty.
+func (s *Statement) Keyword() string {
+	if len(s.Tokens) == 0 || s.Tokens[0].Kind != TokenWord {
+		return ""
+	}
+
+	return s.Tokens[0].Upper()
+}
+
+// HasComment reports whether any comment attached to the statement contains substr.
+func (s *Statement) HasComment(substr string) bool {
+	for _, c := range s.Comments {
+		if strings.Contains(c.Text, substr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Split tokenizes src and splits it into statements on top-level semicolons.
+// Trigger bodies (BEGIN ... END) are kept inside their CREATE TRIGGER statement.
+// Statements consisting only of comments are dropped; their comments are attached
+// to the following statement.
+func Split(src string) ([]Statement, error) {
+	tokens, err := Tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	return SplitTokens(src, tokens), nil
+}
+
+// SplitTokens splits an already tokenized source into statements.
+func SplitTokens(src string, tokens []Token) []Statement {
+	var (
+		statements []Statement
+		current    Statement
+		start      = -1
+		blockDepth int
+	)
+
+	flush := func(end int) {
+		if len(current.Tokens) > 0 {
+			current.Text = strings.TrimSpace(src[start:end])
+			current.Pos = current.Tokens[0].Pos
+			statements = append(statements, current)
+			current = Statement{}
+		}
+
+		start = -1
+		blockDepth = 0
+	}
+
+	for _, tok := range tokens {
+		if tok.Kind == TokenComment {
+			current.Comments = append(current.Comments, tok)
+
+			continue
+		}
+
+		if tok.IsPunct(";") && blockDepth == 0 {
+			flush(tok.Pos.Offset)
+
+			continue
+		}
+
+		if start < 0 {
+			start = tok.Pos.Offset
+		}
+
+		current.Tokens = append(current.Tokens, tok)
+		blockDepth = trackBlockDepth(current.Tokens, blockDepth)
+	}
+
+	if start >= 0 {
+		flush(len(src))
+	}
+
+	return statements
+}
+
+// trackBlockDepth follows BEGIN/CASE ... END nesting inside CREATE TRIGGER bodies
+// so that their inner semicolons do not end the statement.
+func trackBlockDepth(tokens []Token, depth int) int {
+	if len(tokens) < 2 || !tokens[0].Is("CREATE") || !isTriggerHeader(tokens) {
+		return depth
+	}
+
+	last := tokens[len(tokens)-1]
+
+	switch {
+	case last.Is("BEGIN"), last.Is("CASE"):
+		return depth + 1
+	case last.Is("END") && depth > 0:
+		return depth - 1
+	default:
+		return depth
+	}
+}
+
+// isTriggerHeader reports whether a CREATE statement defines a trigger,
+// allowing for OR REPLACE and TEMP modifiers before the TRIGGER keyword.
+func isTriggerHeader(tokens []Token) bool {
+	const maxHeaderWords = 5
+
+	for i := 1; i < len(tokens) && i <= maxHeaderWords; i++ {
+		if tokens[i].Is("TRIGGER") {
+			return true
+		}
+	}
+
+	return false
+}
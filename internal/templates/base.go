@@ -198,7 +198,7 @@ func (t *BaseTemplate) ApplyValidationRules(
 		config.ApplyEmitOptions(&data.Validation.EmitOptions, cfg.SQL[0].Gen.Go)
 
 		// Convert rule types using the centralized transformer
-		cfg.SQL[0].Rules = TransformSafetyRulesToConfig(&data.Validation.SafetyRules)
+		cfg.SetSQLRules(0, TransformSafetyRulesToConfig(&data.Validation.SafetyRules))
 	}
 
 	return cfg, nil
@@ -48,7 +48,7 @@ func (cb *ConfigBuilder) Build() (*config.SqlcConfig, error) {
 				Gen: config.GenConfig{
 					Go: base.BuildGoGenConfig(cb.Data, base.GetSQLPackage(cb.Data.Database.Engine)),
 				},
-				Rules: []config.RuleConfig{},
+				Rules: []string{},
 			},
 		},
 	}, nil
@@ -86,7 +86,7 @@ func (t *MicroserviceTemplate) Generate(data generated.TemplateData) (*config.Sq
 				Gen: config.GenConfig{
 					Go: t.BuildGoGenConfig(data, sqlPackage),
 				},
-				Rules: []config.RuleConfig{}, // Will be set after conversion
+				Rules: []string{}, // Will be set after conversion
 			},
 		},
 	}
@@ -95,7 +95,7 @@ func (t *MicroserviceTemplate) Generate(data generated.TemplateData) (*config.Sq
 	config.ApplyEmitOptions(&data.Validation.EmitOptions, cfg.SQL[0].Gen.Go)
 
 	// Convert rule types using the centralized transformer
-	cfg.SQL[0].Rules = TransformSafetyRulesToConfig(&data.Validation.SafetyRules)
+	cfg.SetSQLRules(0, TransformSafetyRulesToConfig(&data.Validation.SafetyRules))
 
 	return cfg, nil
 }
@@ -70,6 +70,29 @@ func TestAllTemplates_GenerateValidConfig(t *testing.T) {
 	}
 }
 
+func TestAllTemplates_ReferenceTopLevelRules(t *testing.T) {
+	// sql[].rules must only name rules defined at the top level (sqlc v2 schema)
+	registry := templates.NewRegistry()
+
+	for _, tmpl := range registry.List() {
+		t.Run(tmpl.Name(), func(t *testing.T) {
+			data := tmpl.DefaultData()
+			data.Validation.SafetyRules.NoSelectStar = true
+			data.Validation.SafetyRules.RequireWhere = true
+
+			cfg, err := tmpl.Generate(data)
+			require.NoError(t, err)
+			require.NotEmpty(t, cfg.SQL[0].Rules, "Template %s should reference safety rules", tmpl.Name())
+
+			for _, name := range cfg.SQL[0].Rules {
+				rule := cfg.Rule(name)
+				require.NotNil(t, rule, "Template %s references undefined rule %s", tmpl.Name(), name)
+				assert.NotEmpty(t, rule.Rule)
+			}
+		})
+	}
+}
+
 func TestAllTemplates_GenerateWithCustomData(t *testing.T) {
 	// Test that all templates handle custom data correctly
 	registry := templates.NewRegistry()
@@ -143,8 +143,16 @@ func Singularize(word string) string {
 		return word[:len(word)-3] + "y"
 	}
 
-	if strings.HasSuffix(lowered, "es") {
-		return word[:len(word)-2]
+	// Only sibilants take "es" in the plural: boxes, matches, statuses, but files.
+	for _, suffix := range []string{"ses", "xes", "zes", "ches", "shes"} {
+		if strings.HasSuffix(lowered, suffix) {
+			return word[:len(word)-2]
+		}
+	}
+
+	// Words such as status and address are already singular.
+	if strings.HasSuffix(lowered, "ss") || strings.HasSuffix(lowered, "us") {
+		return word
 	}
 
 	if strings.HasSuffix(lowered, "s") {
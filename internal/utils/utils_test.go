@@ -217,6 +217,17 @@ var _ = Describe("Pluralize and Singularize", func() {
 		runStringTests(utils.Singularize, singularCases)
 	})
 
+	It("should only strip -es after sibilants when singularizing", func() {
+		runStringTests(utils.Singularize, []stringTestCase{
+			{"files", "file"},
+			{"roles", "role"},
+			{"addresses", "address"},
+			{"wishes", "wish"},
+			{"status", "status"},
+			{"address", "address"},
+		})
+	})
+
 	runPluralizeSingularizeEdgeCaseTests()
 })
 
@@ -328,3 +339,15 @@ var _ = Describe("Performance", func() {
 		Expect(utils.StringToKebabCase(largeCamel)).NotTo(BeEmpty())
 	})
 })
+
+var _ = Describe("UnifiedDiff", func() {
+	It("should return nothing for equal texts", func() {
+		Expect(utils.UnifiedDiff("a", "b", "x\n", "x\n")).To(BeEmpty())
+	})
+
+	It("should render changed lines with headers", func() {
+		diff := utils.UnifiedDiff("before", "after", "one\ntwo\n", "one\nthree\n")
+		Expect(diff).To(ContainSubstring("--- before\n+++ after\n"))
+		Expect(diff).To(ContainSubstring("-two\n+three\n"))
+	})
+})
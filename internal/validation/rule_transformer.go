@@ -157,13 +157,13 @@ func (rt *RuleTransformer) TransformTypeSafeSafetyRules(
 	case domain.DestructiveWithConfirmation:
 		a.add(
 			"drop-table-requires-confirmation",
-			"query.contains('DROP TABLE') && query.hasComment('CONFIRMED')",
-			"DROP TABLE requires explicit confirmation (add comment: -- CONFIRMED)",
+			"query.contains('DROP TABLE') && query.hasComment('sqlc-wizard:allow drop_table')",
+			"DROP TABLE requires explicit confirmation (acknowledge with: -- sqlc-wizard:allow drop_table)",
 		)
 		a.add(
 			"truncate-requires-confirmation",
-			"query.contains('TRUNCATE') && query.hasComment('CONFIRMED')",
-			"TRUNCATE requires explicit confirmation (add comment: -- CONFIRMED)",
+			"query.contains('TRUNCATE') && query.hasComment('sqlc-wizard:allow truncate')",
+			"TRUNCATE requires explicit confirmation (acknowledge with: -- sqlc-wizard:allow truncate)",
 		)
 
 	case domain.DestructiveAllowed:
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Database engines supported by sqlc.
+const (
+	EnginePostgreSQL = "postgresql"
+	EngineMySQL      = "mysql"
+	EngineSQLite     = "sqlite"
+)
+
+// Go driver packages accepted by sql_package.
+const (
+	SQLPackageDatabaseSQL = "database/sql"
+	SQLPackagePgxV4       = "pgx/v4"
+	SQLPackagePgxV5       = "pgx/v5"
+)
+
+// validSQLPackages lists the sql_package values sqlc accepts; empty means database/sql.
+var validSQLPackages = []string{SQLPackageDatabaseSQL, SQLPackagePgxV4, SQLPackagePgxV5}
+
+// IsPgxPackage returns true if sqlPackage selects one of the pgx drivers.
+func IsPgxPackage(sqlPackage string) bool {
+	return sqlPackage == SQLPackagePgxV4 || sqlPackage == SQLPackagePgxV5
+}
+
+// CommandCompatibility explains why sqlc cannot generate a query command such as
+// ":copyfrom" for the engine and sql_package. The message is empty when the command
+// is supported; isError distinguishes hard failures from caveats worth a warning.
+func CommandCompatibility(command, engine, sqlPackage string) (message string, isError bool) {
+	pgx := IsPgxPackage(sqlPackage)
+
+	switch command {
+	case ":batchexec", ":batchmany", ":batchone":
+		if engine != EnginePostgreSQL || !pgx {
+			return command + " requires engine postgresql with sql_package pgx/v4 or pgx/v5", true
+		}
+	case ":copyfrom":
+		switch {
+		case engine == EngineSQLite:
+			return ":copyfrom is not supported by the sqlite engine", true
+		case engine == EnginePostgreSQL && !pgx:
+			return ":copyfrom requires sql_package pgx/v4 or pgx/v5 (database/sql has no COPY support)", true
+		case engine == EngineMySQL:
+			return ":copyfrom on mysql uses LOAD DATA LOCAL INFILE and needs github.com/go-sql-driver/mysql with local infile enabled", false
+		}
+	case ":execlastid":
+		if engine == EnginePostgreSQL {
+			return ":execlastid is not supported by postgresql - use RETURNING with :one instead", true
+		}
+	}
+
+	return "", false
+}
+
+// validateGoCompatibility checks Go generation options against the engine and sql_package.
+// sqlc silently ignores several combinations, so each one is explained here.
+func validateGoCompatibility(cfg *GoGenConfig, engine string, index int, result *ValidationResult) {
+	prefix := fmt.Sprintf("sql[%d].gen.go", index)
+
+	if cfg.SQLPackage != "" && !slices.Contains(validSQLPackages, cfg.SQLPackage) {
+		result.AddError(prefix+".sql_package", fmt.Sprintf(
+			"invalid sql_package: %s (must be one of: %s)",
+			cfg.SQLPackage, strings.Join(validSQLPackages, ", "),
+		))
+
+		return
+	}
+
+	pgx := IsPgxPackage(cfg.SQLPackage)
+
+	if pgx && engine != "" && engine != EnginePostgreSQL {
+		result.AddError(prefix+".sql_package", fmt.Sprintf(
+			"%s is only supported with the postgresql engine, not %s - use database/sql",
+			cfg.SQLPackage, engine,
+		))
+	}
+
+	if pgx && cfg.EmitPreparedQueries {
+		result.AddFixableWarning(prefix+".emit_prepared_queries", fmt.Sprintf(
+			"emit_prepared_queries is ignored with %s - pgx prepares and caches statements automatically",
+			cfg.SQLPackage,
+		), goGenFix(index, "disable emit_prepared_queries", func(gen *GoGenConfig) {
+			gen.EmitPreparedQueries = false
+		}))
+	}
+
+	if cfg.EmitPreparedQueries && cfg.EmitMethodsWithDBArgument {
+		result.AddError(prefix+".emit_methods_with_db_argument",
+			"emit_methods_with_db_argument cannot be combined with emit_prepared_queries - prepared statements are bound to a single connection",
+		)
+	}
+
+	if cfg.EmitPointersForNullTypes && !pgx && engine != EngineSQLite {
+		result.AddWarning(prefix+".emit_pointers_for_null_types",
+			"emit_pointers_for_null_types only takes effect with sql_package pgx/v4 or pgx/v5 (or the sqlite engine) - database/sql null types will be generated",
+		)
+	}
+
+	if !pgx && cfg.OutputBatchFileName != "" {
+		result.AddWarning(prefix+".output_batch_file_name",
+			"output_batch_file_name has no effect without pgx - batch queries are only generated for pgx/v4 and pgx/v5",
+		)
+	}
+
+	if cfg.OutputCopyfromFileName != "" && (engine == EngineSQLite || engine == EnginePostgreSQL && !pgx) {
+		result.AddWarning(prefix+".output_copyfrom_file_name",
+			"output_copyfrom_file_name has no effect - :copyfrom is not available for this engine and sql_package",
+		)
+	}
+
+	if cfg.QueryParameterLimit < 0 {
+		result.AddError(prefix+".query_parameter_limit", fmt.Sprintf(
+			"query_parameter_limit must not be negative (got %d)",
+			cfg.QueryParameterLimit,
+		))
+	}
+}
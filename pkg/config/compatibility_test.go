@@ -0,0 +1,93 @@
+package config_test
+
+import (
+	. "github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Engine and driver compatibility", func() {
+	validate := func(engine string, modify func(*GoGenConfig)) *ValidationResult {
+		cfg := createBasicSqlcConfig(engine)
+		gen := cfg.SQL[0].Gen.Go
+		gen.EmitInterface = true
+		gen.EmitJSONTags = true
+		modify(gen)
+
+		return Validate(cfg)
+	}
+
+	fields := func(items []ValidationError) []string {
+		out := make([]string, 0, len(items))
+		for _, item := range items {
+			out = append(out, item.Field)
+		}
+
+		return out
+	}
+
+	It("should accept pgx on postgresql without extra warnings", func() {
+		result := validate(EnginePostgreSQL, func(gen *GoGenConfig) {
+			gen.SQLPackage = SQLPackagePgxV5
+			gen.EmitPointersForNullTypes = true
+			gen.OutputBatchFileName = "batch.go"
+		})
+
+		Expect(result.Errors).To(BeEmpty())
+		Expect(result.Warnings).To(BeEmpty())
+	})
+
+	DescribeTable("incompatible options",
+		func(engine string, modify func(*GoGenConfig), errorFields, warningFields []string) {
+			result := validate(engine, modify)
+			Expect(fields(result.Errors)).To(ConsistOf(errorFields))
+			Expect(fields(result.Warnings)).To(ConsistOf(warningFields))
+		},
+		Entry("unknown sql_package", EnginePostgreSQL,
+			func(gen *GoGenConfig) { gen.SQLPackage = "lib/pq"; gen.EmitPreparedQueries = true },
+			[]string{"sql[0].gen.go.sql_package"}, []string{}),
+		Entry("pgx with mysql", EngineMySQL,
+			func(gen *GoGenConfig) { gen.SQLPackage = SQLPackagePgxV5 },
+			[]string{"sql[0].gen.go.sql_package"}, []string{}),
+		Entry("prepared queries under pgx", EnginePostgreSQL,
+			func(gen *GoGenConfig) { gen.SQLPackage = SQLPackagePgxV4; gen.EmitPreparedQueries = true },
+			[]string{}, []string{"sql[0].gen.go.emit_prepared_queries"}),
+		Entry("prepared queries with db argument", EngineMySQL,
+			func(gen *GoGenConfig) { gen.EmitPreparedQueries = true; gen.EmitMethodsWithDBArgument = true },
+			[]string{"sql[0].gen.go.emit_methods_with_db_argument"}, []string{}),
+		Entry("null pointers with database/sql", EnginePostgreSQL,
+			func(gen *GoGenConfig) {
+				gen.SQLPackage = SQLPackageDatabaseSQL
+				gen.EmitPreparedQueries = true
+				gen.EmitPointersForNullTypes = true
+			},
+			[]string{}, []string{"sql[0].gen.go.emit_pointers_for_null_types"}),
+		Entry("null pointers on sqlite", EngineSQLite,
+			func(gen *GoGenConfig) { gen.EmitPreparedQueries = true; gen.EmitPointersForNullTypes = true },
+			[]string{}, []string{}),
+		Entry("batch and copyfrom file names without pgx", EngineSQLite,
+			func(gen *GoGenConfig) {
+				gen.EmitPreparedQueries = true
+				gen.OutputBatchFileName = "batch.go"
+				gen.OutputCopyfromFileName = "copyfrom.go"
+			},
+			[]string{}, []string{"sql[0].gen.go.output_batch_file_name", "sql[0].gen.go.output_copyfrom_file_name"}),
+		Entry("negative query_parameter_limit", EnginePostgreSQL,
+			func(gen *GoGenConfig) { gen.SQLPackage = SQLPackagePgxV5; gen.QueryParameterLimit = -1 },
+			[]string{"sql[0].gen.go.query_parameter_limit"}, []string{}),
+	)
+
+	DescribeTable("CommandCompatibility",
+		func(command, engine, sqlPackage string, supported, isError bool) {
+			message, failed := CommandCompatibility(command, engine, sqlPackage)
+			Expect(message == "").To(Equal(supported))
+			Expect(failed).To(Equal(isError))
+		},
+		Entry(":one everywhere", ":one", EngineSQLite, "", true, false),
+		Entry(":copyfrom with pgx", ":copyfrom", EnginePostgreSQL, SQLPackagePgxV5, true, false),
+		Entry(":copyfrom with database/sql", ":copyfrom", EnginePostgreSQL, SQLPackageDatabaseSQL, false, true),
+		Entry(":copyfrom on mysql", ":copyfrom", EngineMySQL, "", false, false),
+		Entry(":batchone on sqlite", ":batchone", EngineSQLite, "", false, true),
+		Entry(":execlastid on postgresql", ":execlastid", EnginePostgreSQL, SQLPackagePgxV5, false, true),
+	)
+})
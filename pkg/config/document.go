@@ -0,0 +1,582 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"gopkg.in/yaml.v3"
+)
+
+// Indentation bounds accepted when detecting the style of an existing file.
+const (
+	minDocumentIndent = 2
+	maxDocumentIndent = 8
+)
+
+// Document is a sqlc.yaml file opened for editing. Changes are made through the typed
+// Config and written back onto the original YAML node tree, so comments, key order,
+// quoting, anchors and keys the wizard does not model survive a load/save cycle.
+// Only the nodes whose values actually changed are rewritten; a change reached through
+// an alias is written to the anchored node, and refused when that would also change
+// another key sharing the anchor.
+type Document struct {
+	// Config is the typed view of the file; edit it and call Bytes or WriteFile.
+	Config *SqlcConfig
+
+	root   yaml.Node // the original document, updated in place on save
+	base   yaml.Node // Config as it was when loaded or last saved
+	source []string  // original lines, used to restore blank lines yaml.v3 drops
+	indent int
+}
+
+// LoadDocument reads a sqlc.yaml file for round-trip editing.
+func LoadDocument(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, apperrors.FileNotFoundError(path)
+		}
+
+		return nil, apperrors.FileReadError(path, err)
+	}
+
+	doc, err := ParseDocument(data)
+	if err != nil {
+		return nil, apperrors.ConfigParseError(path, err)
+	}
+
+	return doc, nil
+}
+
+// ParseDocument parses YAML data for round-trip editing.
+// Legacy rule objects are upgraded like in Parse.
+func ParseDocument(data []byte) (*Document, error) {
+	doc := &Document{
+		Config: &SqlcConfig{},
+		source: strings.Split(string(data), "\n"),
+		indent: detectIndent(data),
+	}
+
+	if err := yaml.Unmarshal(data, &doc.root); err != nil {
+		return nil, apperrors.Wrapf(err, apperrors.ErrConfigParseFailed, "failed to parse YAML")
+	}
+
+	if documentRoot(&doc.root) != nil {
+		if _, err := upgradeLegacyRules(&doc.root); err != nil {
+			return nil, err
+		}
+
+		if err := doc.root.Decode(doc.Config); err != nil {
+			return nil, apperrors.Wrapf(err, apperrors.ErrConfigParseFailed, "failed to parse YAML")
+		}
+
+		untagMergeKeys(&doc.root)
+	}
+
+	if err := doc.base.Encode(doc.Config); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	return doc, nil
+}
+
+// Bytes applies the changes made to Config and renders the document.
+func (d *Document) Bytes() ([]byte, error) {
+	var current yaml.Node
+	if err := current.Encode(d.Config); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if root := documentRoot(&d.root); root == nil {
+		d.root = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{cloneNode(&current)}}
+	} else {
+		backup := cloneTree(&d.root, map[*yaml.Node]*yaml.Node{})
+		header := firstKey(root)
+		d.root.Content[0] = applyChanges(root, &d.base, &current)
+		keepFileHeader(d.root.Content[0], header)
+
+		if err := checkWritten(&d.root, &current); err != nil {
+			d.root = *backup
+
+			return nil, err
+		}
+	}
+
+	d.base = current
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(d.indent)
+
+	if err := encoder.Encode(&d.root); err != nil {
+		return nil, fmt.Errorf("failed to encode config to YAML: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close encoder: %w", err)
+	}
+
+	return d.restoreBlankLines(buf.Bytes()), nil
+}
+
+// WriteFile applies the changes made to Config and writes the document to path.
+func (d *Document) WriteFile(path string) error {
+	data, err := d.Bytes()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write config file to %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Position returns the line and column of a validation field such as
+// "sql[0].gen.go.package" in the loaded file. When the field itself is absent,
+// the position of its closest existing parent is returned; 0, 0 means unknown.
+func (d *Document) Position(field string) (line, column int) {
+	node := documentRoot(&d.root)
+	if node == nil {
+		return 0, 0
+	}
+
+	line, column = node.Line, node.Column
+
+	for segment := range strings.SplitSeq(field, ".") {
+		key, index, hasIndex := strings.Cut(segment, "[")
+
+		keyNode := mappingKey(node, key)
+		if keyNode == nil {
+			return line, column
+		}
+
+		line, column = keyNode.Line, keyNode.Column
+		node = mappingValue(node, key)
+
+		if !hasIndex {
+			continue
+		}
+
+		i, err := strconv.Atoi(strings.TrimSuffix(index, "]"))
+		if err != nil || node.Kind != yaml.SequenceNode || i < 0 || i >= len(node.Content) {
+			return line, column
+		}
+
+		node = node.Content[i]
+		line, column = node.Line, node.Column
+	}
+
+	return line, column
+}
+
+// firstKey returns the first key of a mapping node, or nil.
+func firstKey(mapping *yaml.Node) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode || len(mapping.Content) == 0 {
+		return nil
+	}
+
+	return mapping.Content[0]
+}
+
+// keepFileHeader keeps a comment at the very top of the file in place when a new key
+// was inserted before the key it was attached to.
+func keepFileHeader(root, header *yaml.Node) {
+	first := firstKey(root)
+	if header == nil || first == nil || first == header || header.HeadComment == "" || startLine(header) != 1 {
+		return
+	}
+
+	first.HeadComment, header.HeadComment = header.HeadComment, ""
+}
+
+// mappingKey returns the key node for key in a mapping node, or nil.
+func mappingKey(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	if index := mappingIndex(mapping, key); index >= 0 {
+		return mapping.Content[index]
+	}
+
+	return nil
+}
+
+// restoreBlankLines re-inserts the blank lines that separated keys and list items in
+// the original file. yaml.v3 does not keep them, so the rendered output is parsed
+// again and matched node by node against the original tree.
+func (d *Document) restoreBlankLines(out []byte) []byte {
+	var rendered yaml.Node
+	if err := yaml.Unmarshal(out, &rendered); err != nil {
+		return out
+	}
+
+	blankBefore := map[int]bool{}
+	d.collectBlankLines(&d.root, &rendered, blankBefore)
+
+	if len(blankBefore) == 0 {
+		return out
+	}
+
+	lines := strings.Split(string(out), "\n")
+	result := make([]string, 0, len(lines)+len(blankBefore))
+
+	for i, line := range lines {
+		if blankBefore[i+1] && len(result) > 0 && result[len(result)-1] != "" {
+			result = append(result, "")
+		}
+
+		result = append(result, line)
+	}
+
+	return []byte(strings.Join(result, "\n"))
+}
+
+// collectBlankLines records the rendered start lines of nodes that were preceded by
+// a blank line in the original source.
+func (d *Document) collectBlankLines(original, rendered *yaml.Node, blankBefore map[int]bool) {
+	if original.Kind != rendered.Kind || len(original.Content) != len(rendered.Content) {
+		return
+	}
+
+	for i := range original.Content {
+		child, renderedChild := original.Content[i], rendered.Content[i]
+
+		isItem := original.Kind == yaml.SequenceNode || (original.Kind == yaml.MappingNode && i%2 == 0)
+		if isItem && child.Line > 0 && d.blankLineBefore(child) {
+			blankBefore[startLine(renderedChild)] = true
+		}
+
+		d.collectBlankLines(child, renderedChild, blankBefore)
+	}
+}
+
+// blankLineBefore reports whether the source line above a node and its head comment is empty.
+func (d *Document) blankLineBefore(node *yaml.Node) bool {
+	above := startLine(node) - 2 // 0-based index of the preceding line
+	if above < 0 || above >= len(d.source) {
+		return false
+	}
+
+	return strings.TrimSpace(d.source[above]) == ""
+}
+
+// startLine returns the first line of a node, including its head comment.
+func startLine(node *yaml.Node) int {
+	if node.HeadComment == "" {
+		return node.Line
+	}
+
+	return node.Line - strings.Count(node.HeadComment, "\n") - 1
+}
+
+// checkWritten verifies that the updated document decodes to the typed config. Edits
+// are written to the anchored node of an alias, so they also reach the other aliases of
+// that anchor; when those were not meant to change, the edit is refused.
+func checkWritten(root, current *yaml.Node) error {
+	var (
+		written SqlcConfig
+		encoded yaml.Node
+	)
+
+	if err := root.Decode(&written); err != nil {
+		return apperrors.Wrapf(err, apperrors.ErrConfigParseFailed, "failed to decode the updated config")
+	}
+
+	if err := encoded.Encode(&written); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if field, ok := firstDifference(&encoded, current, ""); ok {
+		return apperrors.Newf(apperrors.ErrorCodeConfigValidation,
+			"cannot write the changes: %s shares a YAML anchor with a value that was edited; "+
+				"edit the anchored value in the file by hand", field)
+	}
+
+	return nil
+}
+
+// firstDifference returns the field, such as "sql[0].schema", of the first value that
+// differs between two encoded nodes.
+func firstDifference(a, b *yaml.Node, field string) (string, bool) {
+	if a.Kind != b.Kind || a.Value != b.Value || a.Tag != b.Tag || len(a.Content) != len(b.Content) {
+		if field == "" {
+			field = "the config"
+		}
+
+		return field, !nodesEqual(a, b)
+	}
+
+	for i := range a.Content {
+		child := field
+
+		switch {
+		case a.Kind == yaml.MappingNode && i%2 == 0:
+			continue
+		case a.Kind == yaml.MappingNode:
+			child = strings.TrimPrefix(field+"."+a.Content[i-1].Value, ".")
+		case a.Kind == yaml.SequenceNode:
+			child = fmt.Sprintf("%s[%d]", field, i)
+		}
+
+		if diff, ok := firstDifference(a.Content[i], b.Content[i], child); ok {
+			return diff, true
+		}
+	}
+
+	return "", false
+}
+
+// applyChanges rewrites dst, a node of the original document, with the difference
+// between base (what the typed config encoded to when loaded) and next (what it
+// encodes to now). Unchanged subtrees are returned untouched. Changes below an alias
+// are written to its anchored node, so the alias stays in place.
+func applyChanges(dst, base, next *yaml.Node) *yaml.Node {
+	if nodesEqual(base, next) {
+		return dst
+	}
+
+	if dst.Kind == yaml.AliasNode && dst.Alias != nil {
+		applyChanges(dst.Alias, base, next)
+
+		return dst
+	}
+
+	switch {
+	case dst.Kind == yaml.MappingNode && next.Kind == yaml.MappingNode && base.Kind == yaml.MappingNode:
+		applyMappingChanges(dst, base, next)
+
+		return dst
+	case dst.Kind == yaml.SequenceNode && next.Kind == yaml.SequenceNode && base.Kind == yaml.SequenceNode:
+		applySequenceChanges(dst, base, next)
+
+		return dst
+	case dst.Kind == yaml.ScalarNode && next.Kind == yaml.ScalarNode:
+		if dst.Tag != next.Tag {
+			dst.Style = next.Style
+		}
+
+		dst.Tag, dst.Value = next.Tag, next.Value
+
+		return dst
+	default:
+		// The shape changed: replace the node but keep its comments. An anchored node is
+		// replaced in place so that its aliases follow.
+		replacement := cloneNode(next)
+		replacement.HeadComment = dst.HeadComment
+		replacement.LineComment = dst.LineComment
+		replacement.FootComment = dst.FootComment
+
+		if dst.Anchor != "" {
+			replacement.Anchor = dst.Anchor
+			*dst = *replacement
+
+			return dst
+		}
+
+		return replacement
+	}
+}
+
+func applyMappingChanges(dst, base, next *yaml.Node) {
+	// New keys go after the closest preceding key in the typed field order.
+	insertAt := 0
+
+	for i := 0; i+1 < len(next.Content); i += 2 {
+		key, value := next.Content[i].Value, next.Content[i+1]
+
+		baseValue := mappingValue(base, key)
+
+		index := mappingIndex(dst, key)
+		if index < 0 && baseValue != nil && nodesEqual(baseValue, value) {
+			// Unchanged and not in dst: the key comes from a merge key (<<: *anchor)
+			continue
+		}
+
+		if index < 0 {
+			dst.Content = slices.Insert(dst.Content, insertAt, cloneNode(next.Content[i]), cloneNode(value))
+			insertAt += 2
+
+			continue
+		}
+
+		insertAt = index + 2
+
+		if baseValue == nil {
+			baseValue = &yaml.Node{}
+		}
+
+		dst.Content[index+1] = applyChanges(dst.Content[index+1], baseValue, value)
+	}
+
+	// Keys the typed config used to produce but no longer does were cleared.
+	// Keys it never produced are unknown to the wizard and are kept.
+	for i := 0; i+1 < len(base.Content); i += 2 {
+		key := base.Content[i].Value
+		if mappingValue(next, key) != nil {
+			continue
+		}
+
+		if index := mappingIndex(dst, key); index >= 0 {
+			dst.Content = append(dst.Content[:index], dst.Content[index+2:]...)
+		}
+	}
+}
+
+func applySequenceChanges(dst, base, next *yaml.Node) {
+	matches := matchSequenceItems(base, next)
+	content := make([]*yaml.Node, 0, len(next.Content))
+
+	for i, item := range next.Content {
+		switch j := matches[i]; {
+		case j >= 0 && j < len(dst.Content):
+			content = append(content, applyChanges(dst.Content[j], base.Content[j], item))
+		default:
+			content = append(content, cloneNode(item))
+		}
+	}
+
+	dst.Content = content
+}
+
+// matchSequenceItems returns, for each item of next, the index of the base item it
+// continues, or -1 for a new item. Items are matched by value, then by name, then in
+// order, so removing or reordering items keeps each survivor on its own node with its
+// comments, anchors and unmodeled keys.
+func matchSequenceItems(base, next *yaml.Node) []int {
+	matches := make([]int, len(next.Content))
+	used := make([]bool, len(base.Content))
+
+	match := func(same func(a, b *yaml.Node) bool) {
+		for i, item := range next.Content {
+			if matches[i] >= 0 {
+				continue
+			}
+
+			for j, candidate := range base.Content {
+				if !used[j] && same(candidate, item) {
+					matches[i], used[j] = j, true
+
+					break
+				}
+			}
+		}
+	}
+
+	for i := range matches {
+		matches[i] = -1
+	}
+
+	match(nodesEqual)
+	match(func(a, b *yaml.Node) bool {
+		name, other := mappingValue(a, "name"), mappingValue(b, "name")
+
+		return name != nil && other != nil && name.Value != "" && nodesEqual(name, other)
+	})
+	match(func(*yaml.Node, *yaml.Node) bool { return true })
+
+	return matches
+}
+
+// mappingIndex returns the index of key in a mapping node's content, or -1.
+func mappingIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// nodesEqual compares the values of two encoded nodes, ignoring style and comments.
+func nodesEqual(a, b *yaml.Node) bool {
+	if a.Kind != b.Kind || a.Value != b.Value || a.Tag != b.Tag || len(a.Content) != len(b.Content) {
+		return false
+	}
+
+	for i := range a.Content {
+		if !nodesEqual(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func cloneNode(node *yaml.Node) *yaml.Node {
+	clone := *node
+	clone.Content = make([]*yaml.Node, len(node.Content))
+
+	for i, child := range node.Content {
+		clone.Content[i] = cloneNode(child)
+	}
+
+	return &clone
+}
+
+// untagMergeKeys clears the resolved !!merge tag of << keys, which yaml.v3 would
+// otherwise write out as "!!merge <<". The tag is resolved again when decoding.
+func untagMergeKeys(node *yaml.Node) {
+	for i, child := range node.Content {
+		if node.Kind == yaml.MappingNode && i%2 == 0 && child.Tag == "!!merge" {
+			child.Tag = ""
+		}
+
+		untagMergeKeys(child)
+	}
+}
+
+// cloneTree deep-copies a node tree and keeps aliases pointing at the copies of their
+// anchored nodes.
+func cloneTree(node *yaml.Node, clones map[*yaml.Node]*yaml.Node) *yaml.Node {
+	if clone, ok := clones[node]; ok {
+		return clone
+	}
+
+	clone := *node
+	clones[node] = &clone
+	clone.Content = make([]*yaml.Node, len(node.Content))
+
+	for i, child := range node.Content {
+		clone.Content[i] = cloneTree(child, clones)
+	}
+
+	if node.Alias != nil {
+		clone.Alias = cloneTree(node.Alias, clones)
+	}
+
+	return &clone
+}
+
+// detectIndent returns the smallest indentation used by the file, defaulting to two spaces.
+func detectIndent(data []byte) int {
+	indent := 0
+
+	for line := range strings.SplitSeq(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		width := len(line) - len(trimmed)
+
+		if width == 0 || trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if indent == 0 || width < indent {
+			indent = width
+		}
+	}
+
+	if indent < minDocumentIndent || indent > maxDocumentIndent {
+		return yamlIndentSpaces
+	}
+
+	return indent
+}
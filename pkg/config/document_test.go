@@ -0,0 +1,256 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const annotatedConfigYAML = `# Team sqlc configuration - keep in sync with the Makefile
+version: "2"
+
+x-go-defaults: &go-defaults
+  emit_json_tags: true
+  emit_interface: true
+
+sql:
+  # primary database
+  - name: main
+    engine: postgresql
+    schema: "db/schema" # single path, not a list
+    queries: db/queries
+    gen:
+      go:
+        out: internal/db
+        package: db
+        sql_package: pgx/v5
+        emit_json_tags: true
+        emit_interface: true
+    plugin_option_we_do_not_model: keep-me
+`
+
+var _ = Describe("Document", func() {
+	It("should write an unchanged document back byte for byte", func() {
+		doc, err := ParseDocument([]byte(annotatedConfigYAML))
+		Expect(err).NotTo(HaveOccurred())
+
+		out, err := doc.Bytes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(Equal(annotatedConfigYAML))
+	})
+
+	It("should change only the edited fields", func() {
+		doc, err := ParseDocument([]byte(annotatedConfigYAML))
+		Expect(err).NotTo(HaveOccurred())
+
+		gen := doc.Config.SQL[0].Gen.Go
+		gen.Package = "store"
+		gen.EmitInterface = false
+		gen.EmitEmptySlices = true
+
+		out, err := doc.Bytes()
+		Expect(err).NotTo(HaveOccurred())
+
+		text := string(out)
+		Expect(text).To(HavePrefix("# Team sqlc configuration"))
+		Expect(text).To(ContainSubstring("# primary database"))
+		Expect(text).To(ContainSubstring(`schema: "db/schema" # single path, not a list`))
+		Expect(text).To(ContainSubstring("x-go-defaults: &go-defaults"))
+		Expect(text).To(ContainSubstring("plugin_option_we_do_not_model: keep-me"))
+		Expect(text).To(ContainSubstring("        package: store\n        sql_package: pgx/v5\n        emit_json_tags: true\n        emit_empty_slices: true\n"))
+		Expect(text).NotTo(ContainSubstring("        emit_interface: true\n    plugin"))
+
+		parsed, err := Parse(out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.SQL[0].Gen.Go.Package).To(Equal("store"))
+		Expect(parsed.SQL[0].Gen.Go.EmitInterface).To(BeFalse())
+		Expect(parsed.SQL[0].Schema.Strings()).To(Equal([]string{"db/schema"}))
+	})
+
+	It("should append new sql entries and keep the file indentation", func() {
+		doc, err := ParseDocument([]byte("version: \"2\"\nsql:\n    - engine: mysql\n      queries: q\n      schema: s\n      gen:\n          go:\n              package: db\n              out: db\n"))
+		Expect(err).NotTo(HaveOccurred())
+
+		doc.Config.SQL = append(doc.Config.SQL, doc.Config.SQL[0])
+		doc.Config.SQL[1].Engine = EngineSQLite
+
+		out, err := doc.Bytes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("\n    - engine: sqlite\n"))
+
+		parsed, err := Parse(out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.SQL).To(HaveLen(2))
+	})
+
+	It("should keep each remaining sql entry on its own node when one is removed", func() {
+		const input = `version: "2"
+x-go: &go_defaults
+  package: db
+  out: db
+sql:
+  # analytics database
+  - name: analytics
+    engine: postgresql
+    queries: analytics/queries
+    schema: analytics/schema
+    gen:
+      go: *go_defaults
+  # main database
+  - name: main
+    engine: mysql
+    queries: q
+    schema: s
+    plugin_extra: keepme
+`
+
+		for _, rename := range []bool{false, true} {
+			doc, err := ParseDocument([]byte(input))
+			Expect(err).NotTo(HaveOccurred())
+
+			doc.Config.SQL = doc.Config.SQL[1:]
+			if rename {
+				doc.Config.SQL[0].Queries = NewSinglePath("queries")
+			}
+
+			out, err := doc.Bytes()
+			Expect(err).NotTo(HaveOccurred())
+
+			text := string(out)
+			Expect(text).NotTo(ContainSubstring("analytics"))
+			Expect(text).To(ContainSubstring("x-go: &go_defaults\n  package: db\n  out: db\n"))
+			Expect(text).To(ContainSubstring("  # main database\n  - name: main\n    engine: mysql\n"))
+			Expect(text).To(ContainSubstring("    plugin_extra: keepme\n"))
+			Expect(text).NotTo(ContainSubstring("*go_defaults"))
+
+			parsed, err := Parse(out)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.SQL).To(HaveLen(1))
+			Expect(parsed.SQL[0].Engine).To(Equal(EngineMySQL))
+			Expect(parsed.SQL[0].Gen.Go).To(BeNil())
+		}
+	})
+
+	It("should refuse an edit through an alias whose anchor keeps its value elsewhere", func() {
+		const input = "version: \"2\"\nsql:\n  - engine: postgresql\n    queries: &paths q\n    schema: *paths\n"
+
+		doc, err := ParseDocument([]byte(input))
+		Expect(err).NotTo(HaveOccurred())
+
+		out, err := doc.Bytes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("schema: *paths"))
+
+		doc.Config.SQL[0].Schema = NewSinglePath("schema")
+
+		_, err = doc.Bytes()
+		Expect(err).To(MatchError(ContainSubstring("sql[0].queries[0] shares a YAML anchor")))
+
+		doc.Config.SQL[0].Schema = NewSinglePath("q")
+
+		out, err = doc.Bytes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(Equal(input))
+	})
+
+	It("should write an edit through an alias to its anchored node", func() {
+		doc, err := ParseDocument([]byte(`version: "2"
+x-go: &gocfg
+  package: db
+  out: db
+  emit_interface: true
+x-base: &base
+  engine: postgresql
+  queries: q
+sql:
+  - schema: s
+    <<: *base
+    gen:
+      go: *gocfg
+`))
+		Expect(err).NotTo(HaveOccurred())
+
+		doc.Config.SQL[0].Gen.Go.Package = "store"
+		doc.Config.SQL[0].Gen.Go.EmitJSONTags = true
+		doc.Config.SQL[0].Schema = NewSinglePath("schema")
+
+		out, err := doc.Bytes()
+		Expect(err).NotTo(HaveOccurred())
+
+		text := string(out)
+		Expect(text).To(ContainSubstring("x-go: &gocfg\n  package: store\n  out: db\n  emit_interface: true\n  emit_json_tags: true\n"))
+		Expect(text).To(ContainSubstring("      go: *gocfg\n"))
+		Expect(text).To(ContainSubstring("    <<: *base\n"))
+		Expect(text).NotTo(ContainSubstring("    engine: postgresql\n    queries"))
+
+		parsed, err := Parse(out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.SQL[0].Gen.Go.Package).To(Equal("store"))
+		Expect(parsed.SQL[0].Engine).To(Equal("postgresql"))
+		Expect(parsed.SQL[0].Schema.Strings()).To(Equal([]string{"schema"}))
+	})
+
+	It("should round-trip through files", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "sqlc.yaml")
+		Expect(os.WriteFile(path, []byte(annotatedConfigYAML), 0o644)).To(Succeed())
+
+		doc, err := LoadDocument(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		doc.Config.SQL[0].Gen.Go.Out = "internal/store"
+		Expect(doc.WriteFile(path)).To(Succeed())
+
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("out: internal/store"))
+		Expect(string(data)).To(ContainSubstring("# primary database"))
+
+		_, err = LoadDocument(filepath.Join(filepath.Dir(path), "missing.yaml"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should build a document from scratch when the input is empty", func() {
+		doc, err := ParseDocument(nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		doc.Config.Version = "2"
+
+		out, err := doc.Bytes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(HavePrefix(`version: "2"`))
+	})
+
+	It("should keep the file header comment on top when a key is inserted first", func() {
+		doc, err := ParseDocument([]byte("# header\nsql:\n  - engine: sqlite\n"))
+		Expect(err).NotTo(HaveOccurred())
+
+		doc.Config.Version = "2"
+
+		out, err := doc.Bytes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(HavePrefix("# header\nversion: \"2\"\nsql:"))
+	})
+
+	It("should report the position of validation fields", func() {
+		doc, err := ParseDocument([]byte(annotatedConfigYAML))
+		Expect(err).NotTo(HaveOccurred())
+
+		line, column := doc.Position("sql[0].gen.go.sql_package")
+		Expect(line).To(Equal(18))
+		Expect(column).To(Equal(9))
+
+		// A missing field points at its closest existing parent.
+		line, column = doc.Position("sql[0].gen.go.emit_db_tags")
+		Expect(line).To(Equal(15))
+		Expect(column).To(Equal(7))
+
+		line, _ = doc.Position("sql[0]")
+		Expect(line).To(Equal(10))
+
+		line, _ = doc.Position("sql[3].engine")
+		Expect(line).To(Equal(8))
+	})
+})
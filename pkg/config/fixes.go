@@ -0,0 +1,59 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// Fix is an automatic remediation attached to a validation finding.
+type Fix struct {
+	// Description says what the fix changes, e.g. `set version to "2"`.
+	Description string
+	// Apply performs the change. It addresses fields by position, so it can be applied
+	// to any config equivalent to the validated one, such as Document.Config.
+	Apply func(cfg *SqlcConfig)
+}
+
+// caseStyleAliases maps common spellings to valid json_tags_case_style values.
+var caseStyleAliases = map[string]string{
+	"camel": "camel", "camelcase": "camel", "lowercamel": "camel", "lowercamelcase": "camel",
+	"pascal": "pascal", "pascalcase": "pascal", "uppercamel": "pascal", "uppercamelcase": "pascal",
+	"snake": "snake", "snakecase": "snake",
+}
+
+// goGenFix builds a fix that changes the Go generation settings of sql[index].
+func goGenFix(index int, description string, apply func(gen *GoGenConfig)) *Fix {
+	return &Fix{
+		Description: description,
+		Apply: func(cfg *SqlcConfig) {
+			if index < len(cfg.SQL) && cfg.SQL[index].Gen.Go != nil {
+				apply(cfg.SQL[index].Gen.Go)
+			}
+		},
+	}
+}
+
+// normalizeCaseStyle maps spellings such as "camelCase" or "snake_case" to the
+// json_tags_case_style value sqlc expects. It returns "" when there is no match.
+func normalizeCaseStyle(style string) string {
+	key := strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(style))
+
+	return caseStyleAliases[key]
+}
+
+// packageFromOut derives a Go package name from the last element of an output directory.
+// It returns "" when no valid identifier remains.
+func packageFromOut(out string) string {
+	base := filepath.Base(filepath.Clean(out))
+
+	var name strings.Builder
+
+	for _, r := range strings.ToLower(base) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_') {
+			name.WriteRune(r)
+		}
+	}
+
+	return strings.TrimLeft(name.String(), "0123456789_")
+}
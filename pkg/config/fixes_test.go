@@ -0,0 +1,90 @@
+package config_test
+
+import (
+	. "github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validation fixes", func() {
+	applyFixes := func(cfg *SqlcConfig) *ValidationResult {
+		for _, fix := range Validate(cfg).Fixes() {
+			fix.Apply(cfg)
+		}
+
+		return Validate(cfg)
+	}
+
+	It("should fix a missing version", func() {
+		cfg := createBasicSqlcConfig("postgresql")
+		cfg.Version = ""
+
+		result := Validate(cfg)
+		Expect(result.Errors[0].Fix).NotTo(BeNil())
+		Expect(result.Errors[0].Fix.Description).To(Equal(`set version to "2"`))
+
+		Expect(applyFixes(cfg).IsValid()).To(BeTrue())
+		Expect(cfg.Version).To(Equal("2"))
+	})
+
+	It("should derive a missing package from the out directory", func() {
+		cfg := createBasicSqlcConfig("postgresql")
+		cfg.SQL[0].Gen.Go.Package = ""
+		cfg.SQL[0].Gen.Go.Out = "internal/user-store"
+
+		Expect(applyFixes(cfg).IsValid()).To(BeTrue())
+		Expect(cfg.SQL[0].Gen.Go.Package).To(Equal("userstore"))
+	})
+
+	It("should not offer a package fix without an out directory", func() {
+		cfg := createBasicSqlcConfig("postgresql")
+		cfg.SQL[0].Gen.Go.Package = ""
+		cfg.SQL[0].Gen.Go.Out = ""
+
+		for _, item := range Validate(cfg).Errors {
+			Expect(item.Fix).To(BeNil(), item.Field)
+		}
+	})
+
+	DescribeTable("json_tags_case_style normalization",
+		func(style, expected string) {
+			cfg := createBasicSqlcConfig("postgresql")
+			cfg.SQL[0].Gen.Go.JSONTagsCaseStyle = style
+
+			applyFixes(cfg)
+			Expect(cfg.SQL[0].Gen.Go.JSONTagsCaseStyle).To(Equal(expected))
+		},
+		Entry("camelCase", "camelCase", "camel"),
+		Entry("PascalCase", "PascalCase", "pascal"),
+		Entry("snake_case", "snake_case", "snake"),
+		Entry("unknown style stays", "kebab", "kebab"),
+	)
+
+	It("should enable emit_interface and drop emit_prepared_queries under pgx", func() {
+		cfg := createBasicSqlcConfig("postgresql")
+		gen := cfg.SQL[0].Gen.Go
+		gen.SQLPackage = SQLPackagePgxV5
+		gen.EmitPreparedQueries = true
+		gen.EmitJSONTags = true
+
+		result := applyFixes(cfg)
+		Expect(result.Warnings).To(BeEmpty())
+		Expect(gen.EmitInterface).To(BeTrue())
+		Expect(gen.EmitPreparedQueries).To(BeFalse())
+	})
+
+	It("should apply fixes to another instance of the same config", func() {
+		cfg := createBasicSqlcConfig("postgresql")
+		cfg.SQL[0].Gen.Go.JSONTagsCaseStyle = "CAMEL"
+
+		other := createBasicSqlcConfig("postgresql")
+		other.SQL[0].Gen.Go.JSONTagsCaseStyle = "CAMEL"
+
+		for _, fix := range Validate(cfg).Fixes() {
+			fix.Apply(other)
+		}
+
+		Expect(other.SQL[0].Gen.Go.JSONTagsCaseStyle).To(Equal("camel"))
+		Expect(cfg.SQL[0].Gen.Go.JSONTagsCaseStyle).To(Equal("CAMEL"))
+	})
+})
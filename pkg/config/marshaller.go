@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 
@@ -67,3 +68,21 @@ func WriteFileFormatted(cfg *SqlcConfig, path string) error {
 
 	return nil
 }
+
+// encodeNode renders a YAML node tree with the standard indentation.
+func encodeNode(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(yamlIndentSpaces)
+
+	if err := encoder.Encode(node); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close encoder: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
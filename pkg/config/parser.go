@@ -27,10 +27,28 @@ func ParseFile(path string) (*SqlcConfig, error) {
 }
 
 // Parse parses YAML data into a SqlcConfig.
+// Rule objects embedded in sql[].rules by earlier wizard versions are
+// moved to the top-level rules and referenced by name (see UpgradeLegacyRules).
 func Parse(data []byte) (*SqlcConfig, error) {
-	var cfg SqlcConfig
+	var (
+		cfg SqlcConfig
+		doc yaml.Node
+	)
 
-	err := yaml.Unmarshal(data, &cfg)
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, apperrors.Wrapf(err, apperrors.ErrConfigParseFailed, "failed to parse YAML")
+	}
+
+	if documentRoot(&doc) == nil {
+		return &cfg, nil
+	}
+
+	if _, err := upgradeLegacyRules(&doc); err != nil {
+		return nil, err
+	}
+
+	err = doc.Decode(&cfg)
 	if err != nil {
 		return nil, apperrors.Wrapf(err, apperrors.ErrConfigParseFailed, "failed to parse YAML")
 	}
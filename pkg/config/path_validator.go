@@ -0,0 +1,153 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Check codes reported by ValidatePaths.
+const (
+	CheckPathNotFound     = "path-not-found"
+	CheckNoSQLFiles       = "path-no-sql-files"
+	CheckOutOverlap       = "out-overlap"
+	CheckOutInsideSources = "out-inside-sources"
+)
+
+// ValidatePaths checks the filesystem paths of every sql[] entry.
+// Queries and schema paths are resolved relative to baseDir (normally the directory
+// of sqlc.yaml) and must exist; directories must contain at least one .sql file.
+// Go output directories must not overlap each other or live inside a queries/schema directory.
+func ValidatePaths(cfg *SqlcConfig, baseDir string) *ValidationResult {
+	result := &ValidationResult{}
+
+	if cfg == nil {
+		return result
+	}
+
+	outs := make([]string, len(cfg.SQL))
+
+	for i := range cfg.SQL {
+		sql := &cfg.SQL[i]
+		prefix := fmt.Sprintf("sql[%d]", i)
+
+		queryDirs := validateSQLPaths(sql.Queries, baseDir, prefix+".queries", result)
+		schemaDirs := validateSQLPaths(sql.Schema, baseDir, prefix+".schema", result)
+
+		if sql.Gen.Go == nil || sql.Gen.Go.Out == "" {
+			continue
+		}
+
+		out := ResolvePath(baseDir, sql.Gen.Go.Out)
+		outs[i] = out
+
+		validateOutPath(out, prefix+".gen.go.out", queryDirs, schemaDirs, result)
+	}
+
+	validateOutOverlap(outs, result)
+
+	return result
+}
+
+// validateSQLPaths checks each path of a queries/schema entry and returns the
+// resolved directories among them.
+func validateSQLPaths(paths PathOrPaths, baseDir, field string, result *ValidationResult) []string {
+	var dirs []string
+
+	for _, path := range paths.Strings() {
+		resolved := ResolvePath(baseDir, path)
+
+		info, err := os.Stat(resolved)
+		if err != nil {
+			if os.IsNotExist(err) {
+				result.AddErrorWithCode(CheckPathNotFound, field, fmt.Sprintf("path does not exist: %s", resolved))
+			} else {
+				result.AddErrorWithCode(CheckPathNotFound, field, fmt.Sprintf("cannot access %s: %v", resolved, err))
+			}
+
+			continue
+		}
+
+		if !info.IsDir() {
+			if !strings.EqualFold(filepath.Ext(resolved), sqlFileExtension) {
+				result.AddWarningWithCode(CheckNoSQLFiles, field, fmt.Sprintf("%s is not a .sql file", resolved))
+			}
+
+			continue
+		}
+
+		dirs = append(dirs, resolved)
+
+		files, err := sqlFilesInDir(resolved)
+		if err != nil {
+			result.AddErrorWithCode(CheckPathNotFound, field, fmt.Sprintf("cannot read directory %s: %v", resolved, err))
+
+			continue
+		}
+
+		if len(files) == 0 {
+			result.AddWarningWithCode(CheckNoSQLFiles, field, fmt.Sprintf("directory %s contains no .sql files", resolved))
+		}
+	}
+
+	return dirs
+}
+
+// validateOutPath warns when generated code would be written into a SQL source directory.
+func validateOutPath(out, field string, queryDirs, schemaDirs []string, result *ValidationResult) {
+	for _, dir := range queryDirs {
+		if isWithin(out, dir) {
+			result.AddWarningWithCode(CheckOutInsideSources, field, fmt.Sprintf(
+				"output directory %s is inside queries directory %s - generated Go files will be mixed with SQL sources",
+				out, dir,
+			))
+		}
+	}
+
+	for _, dir := range schemaDirs {
+		if isWithin(out, dir) {
+			result.AddWarningWithCode(CheckOutInsideSources, field, fmt.Sprintf(
+				"output directory %s is inside schema directory %s - generated Go files will be mixed with SQL sources",
+				out, dir,
+			))
+		}
+	}
+}
+
+// validateOutOverlap reports sql[] entries that generate into the same or nested directories.
+func validateOutOverlap(outs []string, result *ValidationResult) {
+	for i := range outs {
+		for j := i + 1; j < len(outs); j++ {
+			a, b := outs[i], outs[j]
+			if a == "" || b == "" {
+				continue
+			}
+
+			field := fmt.Sprintf("sql[%d].gen.go.out", j)
+
+			switch {
+			case a == b:
+				result.AddErrorWithCode(CheckOutOverlap, field, fmt.Sprintf(
+					"output directory %s is also used by sql[%d] - generated files such as db.go and models.go would overwrite each other",
+					b, i,
+				))
+			case isWithin(a, b) || isWithin(b, a):
+				result.AddWarningWithCode(CheckOutOverlap, field, fmt.Sprintf(
+					"output directory %s overlaps with sql[%d] output %s",
+					b, i, a,
+				))
+			}
+		}
+	}
+}
+
+// isWithin reports whether path is dir or a descendant of it.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
@@ -0,0 +1,104 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidatePaths", func() {
+	var dir string
+
+	write := func(rel, content string) {
+		path := filepath.Join(dir, rel)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+	}
+
+	sqlConfig := func(schema, queries, out string) SQLConfig {
+		return SQLConfig{
+			Engine:  "postgresql",
+			Schema:  NewSinglePath(schema),
+			Queries: NewSinglePath(queries),
+			Gen:     GenConfig{Go: &GoGenConfig{Package: "db", Out: out}},
+		}
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		write("schema/001_users.sql", "CREATE TABLE users (id int);")
+		write("queries/users.sql", "-- name: GetUser :one\nSELECT id FROM users WHERE id = $1;")
+	})
+
+	It("should accept existing paths relative to the config directory", func() {
+		cfg := &SqlcConfig{Version: "2", SQL: []SQLConfig{sqlConfig("schema", "queries/users.sql", "internal/db")}}
+
+		result := ValidatePaths(cfg, dir)
+		Expect(result.Errors).To(BeEmpty())
+		Expect(result.Warnings).To(BeEmpty())
+	})
+
+	It("should report missing paths and directories without .sql files", func() {
+		Expect(os.MkdirAll(filepath.Join(dir, "empty"), 0o755)).To(Succeed())
+		cfg := &SqlcConfig{Version: "2", SQL: []SQLConfig{sqlConfig("missing", "empty", "db")}}
+
+		result := ValidatePaths(cfg, dir)
+		Expect(result.Errors).To(HaveLen(1))
+		Expect(result.Errors[0].Field).To(Equal("sql[0].schema"))
+		Expect(result.Errors[0].Message).To(ContainSubstring("path does not exist: " + filepath.Join(dir, "missing")))
+		Expect(result.Warnings).To(HaveLen(1))
+		Expect(result.Warnings[0].Field).To(Equal("sql[0].queries"))
+		Expect(result.Warnings[0].Message).To(ContainSubstring("contains no .sql files"))
+	})
+
+	It("should report output directories shared or nested across sql entries", func() {
+		cfg := &SqlcConfig{Version: "2", SQL: []SQLConfig{
+			sqlConfig("schema", "queries", "db"),
+			sqlConfig("schema", "queries", "./db"),
+			sqlConfig("schema", "queries", "db/nested"),
+		}}
+
+		result := ValidatePaths(cfg, dir)
+		Expect(result.Errors).To(HaveLen(1))
+		Expect(result.Errors[0].Field).To(Equal("sql[1].gen.go.out"))
+		Expect(result.Errors[0].Message).To(ContainSubstring("also used by sql[0]"))
+		Expect(result.Warnings).To(HaveLen(2))
+		Expect(result.Warnings[0].Field).To(Equal("sql[2].gen.go.out"))
+	})
+
+	It("should warn when output lives inside a queries or schema directory", func() {
+		cfg := &SqlcConfig{Version: "2", SQL: []SQLConfig{sqlConfig("schema", "queries", "queries/gen")}}
+
+		result := ValidatePaths(cfg, dir)
+		Expect(result.Errors).To(BeEmpty())
+		Expect(result.Warnings).To(HaveLen(1))
+		Expect(result.Warnings[0].Message).To(ContainSubstring("inside queries directory"))
+	})
+
+	It("should handle nil config", func() {
+		Expect(ValidatePaths(nil, dir).IsValid()).To(BeTrue())
+	})
+})
+
+var _ = Describe("ValidationResult strict mode", func() {
+	It("should merge results and promote warnings to errors", func() {
+		result := &ValidationResult{}
+		result.AddError("a", "error")
+
+		other := &ValidationResult{}
+		other.AddWarning("b", "warning")
+		result.Merge(other)
+		result.Merge(nil)
+
+		Expect(result.Errors).To(HaveLen(1))
+		Expect(result.Warnings).To(HaveLen(1))
+
+		result.PromoteWarnings()
+		Expect(result.Errors).To(HaveLen(2))
+		Expect(result.Errors[1].Field).To(Equal("b"))
+		Expect(result.Warnings).To(BeEmpty())
+	})
+})
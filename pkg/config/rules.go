@@ -0,0 +1,285 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"gopkg.in/yaml.v3"
+)
+
+// builtinRulePrefix marks rules provided by sqlc itself (e.g. sqlc/db-prepare),
+// which may be referenced without a top-level definition.
+const builtinRulePrefix = "sqlc/"
+
+// IsBuiltinRule returns true if name refers to a rule shipped with sqlc.
+func IsBuiltinRule(name string) bool {
+	return strings.HasPrefix(name, builtinRulePrefix)
+}
+
+// Rule returns the top-level rule definition with the given name, or nil.
+func (c *SqlcConfig) Rule(name string) *RuleConfig {
+	for i := range c.Rules {
+		if c.Rules[i].Name == name {
+			return &c.Rules[i]
+		}
+	}
+
+	return nil
+}
+
+// SetSQLRules defines rules at the top level, replacing definitions with the same name,
+// and makes sql[index] reference exactly those rules.
+func (c *SqlcConfig) SetSQLRules(index int, rules []RuleConfig) {
+	if index < 0 || index >= len(c.SQL) {
+		return
+	}
+
+	names := make([]string, 0, len(rules))
+
+	for _, rule := range rules {
+		if existing := c.Rule(rule.Name); existing != nil {
+			*existing = rule
+		} else {
+			c.Rules = append(c.Rules, rule)
+		}
+
+		names = append(names, rule.Name)
+	}
+
+	c.SQL[index].Rules = names
+}
+
+// validateRules checks top-level rule definitions and the names referenced by sql[] entries.
+func validateRules(cfg *SqlcConfig, result *ValidationResult) {
+	defined := map[string]bool{}
+
+	for i, rule := range cfg.Rules {
+		prefix := fmt.Sprintf("rules[%d]", i)
+
+		switch {
+		case rule.Name == "":
+			result.AddError(prefix+".name", "rule name is required")
+		case defined[rule.Name]:
+			result.AddError(prefix+".name", fmt.Sprintf("duplicate rule name: %s", rule.Name))
+		}
+
+		if rule.Rule == "" {
+			result.AddError(prefix+".rule", "rule expression is required")
+		}
+
+		defined[rule.Name] = true
+	}
+
+	for i, sql := range cfg.SQL {
+		for j, name := range sql.Rules {
+			if !defined[name] && !IsBuiltinRule(name) {
+				result.AddError(
+					fmt.Sprintf("sql[%d].rules[%d]", i, j),
+					fmt.Sprintf("rule %q is not defined in the top-level rules", name),
+				)
+			}
+		}
+	}
+}
+
+// UpgradeLegacyRules rewrites sqlc.yaml data written by earlier wizard versions, which
+// embedded full rule objects in sql[].rules, into the sqlc v2 shape: definitions under
+// the top-level rules key and names in sql[].rules. Comments and key order are kept.
+// It returns the data unchanged and false when no legacy rules were found.
+func UpgradeLegacyRules(data []byte) ([]byte, bool, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, false, apperrors.Wrapf(err, apperrors.ErrConfigParseFailed, "failed to parse YAML")
+	}
+
+	changed, err := upgradeLegacyRules(&doc)
+	if err != nil || !changed {
+		return data, false, err
+	}
+
+	out, err := encodeNode(&doc)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return out, true, nil
+}
+
+// ValidateLegacyRules reports rule objects that earlier wizard versions embedded in
+// sql[].rules of the sqlc.yaml data. Parse and ParseDocument upgrade them in memory, so
+// the fix has nothing left to change in the typed config: writing the Document saves
+// the upgraded shape (see UpgradeLegacyRules).
+func ValidateLegacyRules(data []byte) *ValidationResult {
+	result := &ValidationResult{}
+
+	if _, changed, err := UpgradeLegacyRules(data); err != nil || !changed {
+		return result
+	}
+
+	result.AddFixableWarning(
+		legacyRulesField(data),
+		"rule definitions are embedded in sql[].rules; sqlc expects the names of top-level rules",
+		&Fix{
+			Description: "move the rule definitions to the top-level rules",
+			Apply:       func(*SqlcConfig) {},
+		},
+	)
+
+	return result
+}
+
+// legacyRulesField returns the field of the first sql[].rules sequence holding a rule object.
+func legacyRulesField(data []byte) string {
+	var doc yaml.Node
+	if yaml.Unmarshal(data, &doc) != nil {
+		return "rules"
+	}
+
+	sqlNode := mappingValue(documentRoot(&doc), "sql")
+	if sqlNode == nil || sqlNode.Kind != yaml.SequenceNode {
+		return "rules"
+	}
+
+	for i, entry := range sqlNode.Content {
+		rulesNode := mappingValue(entry, "rules")
+		if rulesNode == nil || rulesNode.Kind != yaml.SequenceNode {
+			continue
+		}
+
+		for j, item := range rulesNode.Content {
+			if item.Kind == yaml.MappingNode {
+				return fmt.Sprintf("sql[%d].rules[%d]", i, j)
+			}
+		}
+	}
+
+	return "rules"
+}
+
+// upgradeLegacyRules moves rule mappings found in sql[].rules of a parsed document
+// to the top-level rules sequence and replaces them with their names.
+func upgradeLegacyRules(doc *yaml.Node) (bool, error) {
+	root := documentRoot(doc)
+	if root == nil || root.Kind != yaml.MappingNode {
+		return false, nil
+	}
+
+	sqlNode := mappingValue(root, "sql")
+	if sqlNode == nil || sqlNode.Kind != yaml.SequenceNode {
+		return false, nil
+	}
+
+	var (
+		definitions []*yaml.Node
+		defined     = map[string]RuleConfig{}
+	)
+
+	if existing := mappingValue(root, "rules"); existing != nil && existing.Kind == yaml.SequenceNode {
+		for _, item := range existing.Content {
+			var rule RuleConfig
+			if item.Decode(&rule) == nil {
+				defined[rule.Name] = rule
+			}
+		}
+	}
+
+	changed := false
+
+	for _, entry := range sqlNode.Content {
+		rulesNode := mappingValue(entry, "rules")
+		if rulesNode == nil || rulesNode.Kind != yaml.SequenceNode {
+			continue
+		}
+
+		for i, item := range rulesNode.Content {
+			if item.Kind != yaml.MappingNode {
+				continue
+			}
+
+			var rule RuleConfig
+			if err := item.Decode(&rule); err != nil {
+				return false, apperrors.Wrapf(err, apperrors.ErrConfigParseFailed, "failed to decode legacy rule")
+			}
+
+			if rule.Name == "" {
+				return false, apperrors.Newf(
+					apperrors.ErrorCodeConfigValidation,
+					"legacy rule at line %d has no name and cannot be referenced", item.Line,
+				)
+			}
+
+			if previous, ok := defined[rule.Name]; ok {
+				if previous != rule {
+					return false, apperrors.Newf(
+						apperrors.ErrorCodeConfigValidation,
+						"legacy rule %q at line %d conflicts with another rule of the same name", rule.Name, item.Line,
+					)
+				}
+			} else {
+				defined[rule.Name] = rule
+				definitions = append(definitions, item)
+			}
+
+			rulesNode.Content[i] = &yaml.Node{
+				Kind:        yaml.ScalarNode,
+				Tag:         "!!str",
+				Value:       rule.Name,
+				HeadComment: item.HeadComment,
+				LineComment: item.LineComment,
+			}
+			changed = true
+		}
+	}
+
+	if len(definitions) > 0 {
+		topLevel := mappingValue(root, "rules")
+		if topLevel == nil {
+			topLevel = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			root.Content = append(root.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "rules"},
+				topLevel,
+			)
+		}
+
+		for _, item := range definitions {
+			item.HeadComment, item.LineComment = "", ""
+		}
+
+		topLevel.Content = append(topLevel.Content, definitions...)
+	}
+
+	return changed, nil
+}
+
+// documentRoot returns the top-level node of a parsed YAML document.
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind == 0 {
+		return nil
+	}
+
+	if doc.Kind == yaml.DocumentNode {
+		if len(doc.Content) == 0 {
+			return nil
+		}
+
+		return doc.Content[0]
+	}
+
+	return doc
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
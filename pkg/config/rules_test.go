@@ -0,0 +1,160 @@
+package config_test
+
+import (
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	. "github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const legacyRulesYAML = `version: "2"
+sql:
+  - engine: postgresql
+    queries: queries
+    schema: schema
+    gen:
+      go:
+        package: db
+        out: db
+    # safety rules from the wizard
+    rules:
+      - name: no-select-star
+        rule: "!query.sql.contains('SELECT *')"
+        message: avoid SELECT *
+      - sqlc/db-prepare
+  - engine: postgresql
+    queries: more
+    schema: schema
+    gen:
+      go:
+        package: more
+        out: more
+    rules:
+      - name: no-select-star
+        rule: "!query.sql.contains('SELECT *')"
+        message: avoid SELECT *
+`
+
+var _ = Describe("Rules", func() {
+	Context("Parse", func() {
+		It("should read top-level definitions and per-sql references", func() {
+			cfg, err := Parse([]byte(`version: "2"
+sql:
+  - engine: postgresql
+    rules: [require-where, sqlc/db-prepare]
+rules:
+  - name: require-where
+    rule: query.cmd == "exec"
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.SQL[0].Rules).To(Equal([]string{"require-where", "sqlc/db-prepare"}))
+			Expect(cfg.Rule("require-where")).NotTo(BeNil())
+			Expect(cfg.Rule("missing")).To(BeNil())
+		})
+
+		It("should upgrade legacy rule objects in sql[].rules", func() {
+			cfg, err := Parse([]byte(legacyRulesYAML))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cfg.Rules).To(HaveLen(1))
+			Expect(cfg.Rules[0].Message).To(Equal("avoid SELECT *"))
+			Expect(cfg.SQL[0].Rules).To(Equal([]string{"no-select-star", "sqlc/db-prepare"}))
+			Expect(cfg.SQL[1].Rules).To(Equal([]string{"no-select-star"}))
+		})
+
+		It("should reject conflicting legacy definitions", func() {
+			_, err := Parse([]byte(`version: "2"
+sql:
+  - rules:
+      - name: r
+        rule: "true"
+  - rules:
+      - name: r
+        rule: "false"
+`))
+			Expect(err).To(HaveOccurred())
+			Expect(apperrors.Is(err, apperrors.NewError(apperrors.ErrorCodeConfigValidation, ""))).To(BeTrue())
+		})
+	})
+
+	Context("UpgradeLegacyRules", func() {
+		It("should rewrite the file shape and keep comments", func() {
+			out, changed, err := UpgradeLegacyRules([]byte(legacyRulesYAML))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+
+			text := string(out)
+			Expect(text).To(ContainSubstring("# safety rules from the wizard"))
+			Expect(text).To(ContainSubstring("rules:\n      - no-select-star\n      - sqlc/db-prepare"))
+			Expect(text).To(MatchRegexp(`(?m)^rules:\n  - name: no-select-star\n`))
+
+			again, changed, err := UpgradeLegacyRules(out)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			Expect(again).To(Equal(out))
+		})
+	})
+
+	Context("ValidateLegacyRules", func() {
+		It("should report legacy rules as a fixable warning", func() {
+			result := ValidateLegacyRules([]byte(legacyRulesYAML))
+			Expect(result.Errors).To(BeEmpty())
+			Expect(result.Warnings).To(HaveLen(1))
+			Expect(result.Warnings[0].Field).To(Equal("sql[0].rules[0]"))
+			Expect(result.Fixes()).To(HaveLen(1))
+
+			doc, err := ParseDocument([]byte(legacyRulesYAML))
+			Expect(err).NotTo(HaveOccurred())
+
+			result.Fixes()[0].Apply(doc.Config)
+			out, err := doc.Bytes()
+			Expect(err).NotTo(HaveOccurred())
+
+			upgraded, _, err := UpgradeLegacyRules([]byte(legacyRulesYAML))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(out)).To(Equal(string(upgraded)))
+			Expect(ValidateLegacyRules(out).Warnings).To(BeEmpty())
+		})
+	})
+
+	Context("SetSQLRules", func() {
+		It("should define rules once and reference them by name", func() {
+			cfg := createBasicSqlcConfig("postgresql")
+			cfg.SQL = append(cfg.SQL, createBasicSQLConfig("postgresql", "other", "other"))
+
+			rules := []RuleConfig{{Name: "a", Rule: "true"}, {Name: "b", Rule: "true"}}
+			cfg.SetSQLRules(0, rules)
+			cfg.SetSQLRules(1, []RuleConfig{{Name: "a", Rule: "false"}})
+
+			Expect(cfg.Rules).To(HaveLen(2))
+			Expect(cfg.Rule("a").Rule).To(Equal("false"))
+			Expect(cfg.SQL[0].Rules).To(Equal([]string{"a", "b"}))
+			Expect(cfg.SQL[1].Rules).To(Equal([]string{"a"}))
+
+			data, err := Marshal(cfg)
+			Expect(err).NotTo(HaveOccurred())
+
+			parsed, err := Parse(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Rules).To(Equal(cfg.Rules))
+			Expect(parsed.SQL[0].Rules).To(Equal([]string{"a", "b"}))
+		})
+	})
+
+	Context("Validate", func() {
+		It("should report undefined references and incomplete definitions", func() {
+			cfg := createBasicSqlcConfig("postgresql")
+			cfg.Rules = []RuleConfig{{Name: "a", Rule: "true"}, {Name: "a", Rule: ""}}
+			cfg.SQL[0].Rules = []string{"a", "sqlc/db-prepare", "missing"}
+
+			result := Validate(cfg)
+
+			var fields []string
+			for _, item := range result.Errors {
+				fields = append(fields, item.Field)
+			}
+
+			Expect(fields).To(ConsistOf("rules[1].name", "rules[1].rule", "sql[0].rules[2]"))
+		})
+	})
+})
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+)
+
+// sqlFileExtension is the extension sqlc uses to discover query and schema files.
+const sqlFileExtension = ".sql"
+
+// ResolvePath resolves path relative to baseDir unless it is already absolute.
+func ResolvePath(baseDir, path string) string {
+	if filepath.IsAbs(path) || baseDir == "" {
+		return filepath.Clean(path)
+	}
+
+	return filepath.Join(baseDir, path)
+}
+
+// SQLFiles expands every path relative to baseDir into the .sql files it refers to.
+// Directories are read non-recursively in lexical order, mirroring how sqlc discovers files;
+// hidden files are skipped. A missing path yields a FileNotFoundError.
+func (p PathOrPaths) SQLFiles(baseDir string) ([]string, error) {
+	var files []string
+
+	for _, path := range p.Strings() {
+		resolved := ResolvePath(baseDir, path)
+
+		info, err := os.Stat(resolved)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, apperrors.FileNotFoundError(resolved)
+			}
+
+			return nil, apperrors.FileReadError(resolved, err)
+		}
+
+		if !info.IsDir() {
+			files = append(files, resolved)
+
+			continue
+		}
+
+		dirFiles, err := sqlFilesInDir(resolved)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, dirFiles...)
+	}
+
+	return files, nil
+}
+
+// sqlFilesInDir lists the .sql files directly inside dir in lexical order.
+func sqlFilesInDir(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, apperrors.FileReadError(dir, err)
+	}
+
+	var files []string
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") ||
+			!strings.EqualFold(filepath.Ext(name), sqlFileExtension) {
+			continue
+		}
+
+		files = append(files, filepath.Join(dir, name))
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// IsDownMigration reports whether a file is a rollback migration that sqlc ignores
+// when reading schema directories (golang-migrate, goose-style *.down.sql files).
+func IsDownMigration(path string) bool {
+	return strings.HasSuffix(strings.ToLower(filepath.Base(path)), ".down.sql")
+}
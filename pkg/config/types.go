@@ -9,7 +9,8 @@ type SqlcConfig struct {
 	Version string       `yaml:"version"`
 	Cloud   *CloudConfig `yaml:"cloud,omitempty"`
 	SQL     []SQLConfig  `yaml:"sql"`
-	Rules   []RuleConfig `yaml:"rules,omitempty"`
+	// Rules defines the named CEL rules that sql[].rules refer to.
+	Rules []RuleConfig `yaml:"rules,omitempty"`
 }
 
 // CloudConfig represents sqlc Cloud integration settings.
@@ -28,7 +29,7 @@ type SQLConfig struct {
 	Schema               PathOrPaths     `yaml:"schema"`  // Paths to SQL schema/migration files
 	Gen                  GenConfig       `yaml:"gen"`
 	Database             *DatabaseConfig `yaml:"database,omitempty"`
-	Rules                []RuleConfig    `yaml:"rules,omitempty"`
+	Rules                []string        `yaml:"rules,omitempty"` // Names of top-level or built-in sqlc/ rules
 	StrictFunctionChecks *bool           `yaml:"strict_function_checks,omitempty"`
 	StrictOrderBy        *bool           `yaml:"strict_order_by,omitempty"`
 	CodegenPlugins       []CodegenPlugin `yaml:"codegen,omitempty"`
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"maps"
+
+	"github.com/LarsArtmann/SQLC-Wizzard/internal/apperrors"
+	"gopkg.in/yaml.v3"
+)
+
+// configV1 is the version 1 sqlc.yaml layout. It is only read to upgrade it to version 2.
+type configV1 struct {
+	Version   string            `yaml:"version"`
+	Packages  []packageV1       `yaml:"packages"`
+	Overrides []overrideV1      `yaml:"overrides"`
+	Rename    map[string]string `yaml:"rename"`
+}
+
+// packageV1 is a version 1 package. Its Go options use the same keys as gen.go
+// in version 2, only the package name and output path are called name and path.
+type packageV1 struct {
+	GoGenConfig `yaml:",inline"`
+
+	Name    string      `yaml:"name"`
+	Path    string      `yaml:"path"`
+	Engine  string      `yaml:"engine"`
+	Queries PathOrPaths `yaml:"queries"`
+	Schema  PathOrPaths `yaml:"schema"`
+}
+
+// overrideV1 is a top-level version 1 override, which can be limited to one engine.
+type overrideV1 struct {
+	Override `yaml:",inline"`
+
+	Engine string `yaml:"engine"`
+}
+
+// UpgradeV1 converts a version 1 sqlc.yaml into the version 2 layout.
+// Every package becomes a sql[] entry with gen.go options; top-level overrides and
+// renames are copied into each entry, honouring the engine filter of an override.
+func UpgradeV1(data []byte) (*SqlcConfig, error) {
+	var v1 configV1
+
+	err := yaml.Unmarshal(data, &v1)
+	if err != nil {
+		return nil, apperrors.Wrapf(err, apperrors.ErrConfigParseFailed, "failed to parse YAML")
+	}
+
+	if v1.Version != "1" {
+		return nil, apperrors.Newf(apperrors.ErrorCodeInvalidValue,
+			"expected a version 1 configuration, got version %q", v1.Version)
+	}
+
+	cfg := DefaultConfig()
+
+	for _, pkg := range v1.Packages {
+		engine := pkg.Engine
+		if engine == "" {
+			engine = EnginePostgreSQL // sqlc's default engine for version 1
+		}
+
+		gen := pkg.GoGenConfig
+		gen.Package = pkg.Name
+		gen.Out = pkg.Path
+		gen.Overrides = upgradeV1Overrides(v1.Overrides, engine, pkg.Overrides)
+
+		if len(v1.Rename) > 0 {
+			rename := maps.Clone(v1.Rename)
+			maps.Copy(rename, pkg.Rename)
+			gen.Rename = rename
+		}
+
+		cfg.SQL = append(cfg.SQL, SQLConfig{
+			Name:    pkg.Name,
+			Engine:  engine,
+			Queries: pkg.Queries,
+			Schema:  pkg.Schema,
+			Gen:     GenConfig{Go: &gen},
+		})
+	}
+
+	return cfg, nil
+}
+
+// upgradeV1Overrides returns the package overrides followed by the global ones for engine.
+func upgradeV1Overrides(global []overrideV1, engine string, local []Override) []Override {
+	overrides := append([]Override(nil), local...)
+
+	for _, override := range global {
+		if override.Engine == "" || override.Engine == engine {
+			overrides = append(overrides, override.Override)
+		}
+	}
+
+	return overrides
+}
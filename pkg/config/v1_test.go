@@ -0,0 +1,63 @@
+package config_test
+
+import (
+	. "github.com/LarsArtmann/SQLC-Wizzard/pkg/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UpgradeV1", func() {
+	It("should turn packages into sql entries with gen.go options", func() {
+		cfg, err := UpgradeV1([]byte(`version: "1"
+packages:
+  - name: db
+    path: internal/db
+    queries: ./sql/query/
+    schema: ./sql/schema/
+    engine: mysql
+    emit_json_tags: true
+    rename:
+      id: ID
+  - name: analytics
+    path: internal/analytics
+    queries: [./sql/analytics/]
+    schema: ./sql/schema/
+overrides:
+  - go_type: encoding/json.RawMessage
+    db_type: json
+    engine: mysql
+  - go_type: github.com/google/uuid.UUID
+    db_type: uuid
+    engine: postgresql
+rename:
+  url: URL
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Version).To(Equal("2"))
+		Expect(cfg.SQL).To(HaveLen(2))
+
+		db := cfg.SQL[0]
+		Expect(db.Name).To(Equal("db"))
+		Expect(db.Engine).To(Equal(EngineMySQL))
+		Expect(db.Queries.Strings()).To(Equal([]string{"./sql/query/"}))
+		Expect(db.Gen.Go.Package).To(Equal("db"))
+		Expect(db.Gen.Go.Out).To(Equal("internal/db"))
+		Expect(db.Gen.Go.EmitJSONTags).To(BeTrue())
+		Expect(db.Gen.Go.Rename).To(Equal(map[string]string{"id": "ID", "url": "URL"}))
+		Expect(db.Gen.Go.Overrides).To(Equal([]Override{
+			{DBType: "json", GoType: "encoding/json.RawMessage"},
+		}))
+
+		analytics := cfg.SQL[1]
+		Expect(analytics.Engine).To(Equal(EnginePostgreSQL))
+		Expect(analytics.Gen.Go.Overrides).To(HaveLen(1))
+		Expect(analytics.Gen.Go.Overrides[0].DBType).To(Equal("uuid"))
+
+		Expect(Validate(cfg).IsValid()).To(BeTrue())
+	})
+
+	It("should reject other versions", func() {
+		_, err := UpgradeV1([]byte(`version: "2"`))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -10,6 +10,10 @@ import (
 type ValidationError struct {
 	Field   string
 	Message string
+	// Code is a stable identifier of the check; empty for generic config field checks.
+	Code string
+	// Fix is set when the problem can be corrected automatically (validate --fix).
+	Fix *Fix
 }
 
 func (e ValidationError) Error() string {
@@ -37,6 +41,57 @@ func (r *ValidationResult) AddWarning(field, message string) {
 	r.Warnings = append(r.Warnings, ValidationError{Field: field, Message: message})
 }
 
+// AddErrorWithCode adds a validation error identified by a stable check code.
+func (r *ValidationResult) AddErrorWithCode(code, field, message string) {
+	r.Errors = append(r.Errors, ValidationError{Field: field, Message: message, Code: code})
+}
+
+// AddWarningWithCode adds a validation warning identified by a stable check code.
+func (r *ValidationResult) AddWarningWithCode(code, field, message string) {
+	r.Warnings = append(r.Warnings, ValidationError{Field: field, Message: message, Code: code})
+}
+
+// AddFixableError adds a validation error that can be corrected automatically.
+func (r *ValidationResult) AddFixableError(field, message string, fix *Fix) {
+	r.Errors = append(r.Errors, ValidationError{Field: field, Message: message, Fix: fix})
+}
+
+// AddFixableWarning adds a validation warning that can be corrected automatically.
+func (r *ValidationResult) AddFixableWarning(field, message string, fix *Fix) {
+	r.Warnings = append(r.Warnings, ValidationError{Field: field, Message: message, Fix: fix})
+}
+
+// Fixes returns the automatic fixes of all errors and warnings, errors first.
+func (r *ValidationResult) Fixes() []*Fix {
+	var fixes []*Fix
+
+	for _, items := range [][]ValidationError{r.Errors, r.Warnings} {
+		for _, item := range items {
+			if item.Fix != nil {
+				fixes = append(fixes, item.Fix)
+			}
+		}
+	}
+
+	return fixes
+}
+
+// Merge appends the errors and warnings of other to the result.
+func (r *ValidationResult) Merge(other *ValidationResult) {
+	if other == nil {
+		return
+	}
+
+	r.Errors = append(r.Errors, other.Errors...)
+	r.Warnings = append(r.Warnings, other.Warnings...)
+}
+
+// PromoteWarnings turns every warning into an error, as used by strict validation.
+func (r *ValidationResult) PromoteWarnings() {
+	r.Errors = append(r.Errors, r.Warnings...)
+	r.Warnings = nil
+}
+
 // Validate performs comprehensive validation on a SqlcConfig.
 func Validate(cfg *SqlcConfig) *ValidationResult {
 	result := &ValidationResult{}
@@ -50,7 +105,10 @@ func Validate(cfg *SqlcConfig) *ValidationResult {
 
 	// Validate version
 	if cfg.Version == "" {
-		result.AddError("version", "version is required")
+		result.AddFixableError("version", "version is required", &Fix{
+			Description: `set version to "2"`,
+			Apply:       func(cfg *SqlcConfig) { cfg.Version = "2" },
+		})
 	} else if cfg.Version != "1" && cfg.Version != "2" {
 		result.AddError(
 			"version",
@@ -69,6 +127,8 @@ func Validate(cfg *SqlcConfig) *ValidationResult {
 		validateSQLConfig(&sqlCfg, i, result)
 	}
 
+	validateRules(cfg, result)
+
 	return result
 }
 
@@ -76,7 +136,7 @@ func validateSQLConfig(cfg *SQLConfig, index int, result *ValidationResult) {
 	prefix := fmt.Sprintf("sql[%d]", index)
 
 	// Validate engine
-	validEngines := []string{"postgresql", "mysql", "sqlite"}
+	validEngines := []string{EnginePostgreSQL, EngineMySQL, EngineSQLite}
 	if cfg.Engine == "" {
 		result.AddError(prefix+".engine", "engine is required")
 	} else if !slices.Contains(validEngines, cfg.Engine) {
@@ -108,14 +168,25 @@ func validateSQLConfig(cfg *SQLConfig, index int, result *ValidationResult) {
 
 	// Validate Go gen config if present
 	if cfg.Gen.Go != nil {
-		validateGoGenConfig(cfg.Gen.Go, prefix+".gen.go", result)
+		validateGoGenConfig(cfg.Gen.Go, index, result)
+		validateGoCompatibility(cfg.Gen.Go, cfg.Engine, index, result)
 	}
 }
 
-func validateGoGenConfig(cfg *GoGenConfig, prefix string, result *ValidationResult) {
+func validateGoGenConfig(cfg *GoGenConfig, index int, result *ValidationResult) {
+	prefix := fmt.Sprintf("sql[%d].gen.go", index)
+
 	// Validate required fields
 	if cfg.Package == "" {
-		result.AddError(prefix+".package", "package name is required")
+		if pkg := packageFromOut(cfg.Out); pkg != "" {
+			result.AddFixableError(prefix+".package", "package name is required",
+				goGenFix(index, fmt.Sprintf("set package to %q (from out directory)", pkg), func(gen *GoGenConfig) {
+					gen.Package = pkg
+				}),
+			)
+		} else {
+			result.AddError(prefix+".package", "package name is required")
+		}
 	}
 
 	if cfg.Out == "" {
@@ -126,26 +197,36 @@ func validateGoGenConfig(cfg *GoGenConfig, prefix string, result *ValidationResu
 	if cfg.JSONTagsCaseStyle != "" {
 		validStyles := []string{"camel", "pascal", "snake"}
 		if !slices.Contains(validStyles, cfg.JSONTagsCaseStyle) {
-			result.AddError(
-				prefix+".json_tags_case_style",
-				fmt.Sprintf(
-					"invalid case style: %s (must be one of: %s)",
-					cfg.JSONTagsCaseStyle,
-					strings.Join(validStyles, ", "),
-				),
+			message := fmt.Sprintf(
+				"invalid case style: %s (must be one of: %s)",
+				cfg.JSONTagsCaseStyle,
+				strings.Join(validStyles, ", "),
 			)
+
+			if style := normalizeCaseStyle(cfg.JSONTagsCaseStyle); style != "" {
+				result.AddFixableError(prefix+".json_tags_case_style", message,
+					goGenFix(index, fmt.Sprintf("set json_tags_case_style to %q", style), func(gen *GoGenConfig) {
+						gen.JSONTagsCaseStyle = style
+					}),
+				)
+			} else {
+				result.AddError(prefix+".json_tags_case_style", message)
+			}
 		}
 	}
 
 	// Add warnings for best practices
 	if !cfg.EmitInterface {
-		result.AddWarning(
+		result.AddFixableWarning(
 			prefix+".emit_interface",
 			"consider enabling emit_interface for better testability",
+			goGenFix(index, "enable emit_interface", func(gen *GoGenConfig) {
+				gen.EmitInterface = true
+			}),
 		)
 	}
 
-	if !cfg.EmitPreparedQueries {
+	if !cfg.EmitPreparedQueries && !IsPgxPackage(cfg.SQLPackage) {
 		result.AddWarning(
 			prefix+".emit_prepared_queries",
 			"consider enabling emit_prepared_queries for better performance",